@@ -14,6 +14,7 @@ import (
 	"github.com/dusk-network/dusk-blockchain/pkg/core/candidate"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/chain"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/equivocation"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database/heavy"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/mempool"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/transactor"
@@ -33,6 +34,7 @@ type Server struct {
 	eventBus   *eventbus.EventBus
 	rpcBus     *rpcbus.RPCBus
 	loader     chain.Loader
+	mempool    *mempool.Mempool
 	dupeMap    *dupemap.DupeMap
 	counter    *chainsync.Counter
 	gossip     *processing.Gossip
@@ -90,11 +92,16 @@ func Setup() *Server {
 	candidateBroker := candidate.NewBroker(eventBus, rpcBus)
 	go candidateBroker.Listen()
 
+	// Setting up the equivocation detector
+	_, db := heavy.CreateDBConnection()
+	detector := equivocation.NewDetector(eventBus, db)
+	go detector.Listen()
+
 	// Setting up a dupemap
 	dupeBlacklist := launchDupeMap(eventBus)
 
 	// Instantiate gRPC server
-	rpcWrapper, err := rpc.StartgRPCServer(rpcBus)
+	rpcWrapper, err := rpc.StartgRPCServer(rpcBus, eventBus)
 	if err != nil {
 		log.WithError(err).Errorln("could not start gRPC server")
 	}
@@ -119,6 +126,7 @@ func Setup() *Server {
 		eventBus:   eventBus,
 		rpcBus:     rpcBus,
 		loader:     chainDBLoader,
+		mempool:    m,
 		dupeMap:    dupeBlacklist,
 		counter:    counter,
 		gossip:     processing.NewGossip(protocol.TestNet),
@@ -202,6 +210,7 @@ func (s *Server) OnConnection(conn net.Conn, addr string) {
 // Close the chain and the connections created through the RPC bus
 func (s *Server) Close() {
 	// TODO: disconnect peers
+	s.mempool.Quit()
 	_ = s.loader.Close(cfg.Get().Database.Driver)
 	s.rpcBus.Close()
 	s.rpcWrapper.Shutdown()
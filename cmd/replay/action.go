@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/replay"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/wallet"
+	logger "github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+)
+
+// passwordEnv is the environment variable holding the password of the
+// consensus keys file
+const passwordEnv = "DUSK_CONSENSUS_KEYS_PASS"
+
+func action(ctx *cli.Context) error {
+
+	// check arguments
+	if arguments := ctx.Args(); len(arguments) > 0 {
+		return fmt.Errorf("failed to read command argument: %q", arguments[0])
+	}
+
+	if logLevel := ctx.GlobalString(LogLevelFlag.Name); logLevel != "" {
+		log.WithField("logLevel", logLevel).Info("will configure log level")
+
+		var err error
+		log.Level, err = logger.ParseLevel(logLevel)
+		if err != nil {
+			log.WithError(err).Fatal("could not parse logLevel")
+		}
+	}
+
+	password, ok := os.LookupEnv(passwordEnv)
+	if !ok {
+		return fmt.Errorf("the consensus keys password must be set in %s", passwordEnv)
+	}
+
+	keys, err := wallet.LoadConsensusKeys(password, ctx.String(KeysFlag.Name))
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(ctx.String(TraceFlag.Name))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	trace, err := consensus.NewTraceReader(bufio.NewReader(f))
+	if err != nil {
+		return err
+	}
+
+	r, err := replay.New(keys, replay.DefaultFactories, ctx.Duration(WaitFlag.Name))
+	if err != nil {
+		return err
+	}
+	defer r.Stop()
+
+	quiet := ctx.Bool(QuietFlag.Name)
+	reproduced := 0
+	err = r.Replay(trace, func(i int, rec consensus.TraceRecord) {
+		reproduced++
+		if !quiet {
+			fmt.Printf("%8d %s\n", i, rec)
+		}
+	})
+
+	var d *replay.Divergence
+	if errors.As(err, &d) {
+		fmt.Println(d.Error())
+		for _, rec := range d.Unexpected {
+			fmt.Printf("unexpected %s\n", rec)
+		}
+		return errors.New("the trace was not reproduced")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%d events reproduced\n", reproduced)
+	return nil
+}
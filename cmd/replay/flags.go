@@ -0,0 +1,49 @@
+package main
+
+import (
+	"time"
+
+	"github.com/urfave/cli"
+)
+
+var (
+	// LogLevelFlag flag to set log level
+	LogLevelFlag = cli.StringFlag{
+		Name:  "loglevel",
+		Usage: "log level, eg: (warn, error, fatal, panic)",
+	}
+	// KeysFlag flag to set the file holding the consensus keys
+	KeysFlag = cli.StringFlag{
+		Name:  "keys",
+		Usage: "consensus keys file of the node which recorded the trace, exported with the signer export command. The password is read from the DUSK_CONSENSUS_KEYS_PASS environment variable",
+		Value: "consensus.keys",
+	}
+	// TraceFlag flag to set the trace to replay
+	TraceFlag = cli.StringFlag{
+		Name:  "trace",
+		Usage: "consensus trace recorded by the node (see traceFile in the [consensus] configuration)",
+		Value: "consensus.trace",
+	}
+	// WaitFlag flag to set how long to wait for an event to be reproduced
+	WaitFlag = cli.DurationFlag{
+		Name:  "wait",
+		Usage: "how long to wait for an event to be reproduced before reporting a divergence",
+		Value: 5 * time.Second,
+	}
+	// QuietFlag flag to only report the divergences
+	QuietFlag = cli.BoolFlag{
+		Name:  "quiet",
+		Usage: "do not print the reproduced events",
+	}
+)
+
+var (
+	// CLIFlags flags usable in a CLI context
+	CLIFlags = []cli.Flag{
+		LogLevelFlag,
+		KeysFlag,
+		TraceFlag,
+		WaitFlag,
+		QuietFlag,
+	}
+)
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+)
+
+var (
+	log *logrus.Entry
+	app = cli.NewApp()
+)
+
+func initLog() {
+	log = logrus.WithFields(logrus.Fields{
+		"app":    "replay",
+		"prefix": "main",
+	})
+}
+
+func init() {
+	initLog()
+
+	app.Action = action
+	app.Copyright = "Copyright (c) 2020 DUSK"
+	app.Name = "replay"
+	app.Usage = "Replays a consensus trace recorded by a node, for offline debugging"
+	app.Author = "DUSK 2020"
+	app.Version = "0.0.1"
+	app.Commands = []cli.Command{}
+	app.Flags = append(app.Flags, CLIFlags...)
+}
+
+func main() {
+	defer handlePanic()
+
+	if err := app.Run(os.Args); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func handlePanic() {
+	if r := recover(); r != nil {
+		log.WithError(fmt.Errorf("%+v", r)).Errorln("Application Replay panic")
+	}
+	time.Sleep(time.Second * 1)
+}
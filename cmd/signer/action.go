@@ -0,0 +1,137 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	blssigner "github.com/dusk-network/dusk-blockchain/pkg/core/consensus/signer"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/wallet"
+	"github.com/dusk-network/dusk-blockchain/pkg/rpc/signer"
+	logger "github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+const (
+	// walletPasswordEnv is the environment variable holding the wallet password
+	walletPasswordEnv = "DUSK_WALLET_PASS"
+	// keysPasswordEnv is the environment variable holding the password of the
+	// consensus keys file
+	keysPasswordEnv = "DUSK_CONSENSUS_KEYS_PASS"
+)
+
+// export writes the consensus keys of the wallet to the keys file. It runs on
+// the host of the wallet, so that the signer host never holds the wallet seed
+func export(ctx *cli.Context) error {
+	walletPassword, ok := os.LookupEnv(walletPasswordEnv)
+	if !ok {
+		return fmt.Errorf("the wallet password must be set in %s", walletPasswordEnv)
+	}
+
+	password, ok := os.LookupEnv(keysPasswordEnv)
+	if !ok {
+		return fmt.Errorf("the consensus keys password must be set in %s", keysPasswordEnv)
+	}
+
+	return wallet.ExportConsensusKeys(walletPassword, ctx.String(WalletFlag.Name), password, ctx.String(KeysFlag.Name))
+}
+
+func action(ctx *cli.Context) error {
+
+	// check arguments
+	if arguments := ctx.Args(); len(arguments) > 0 {
+		return fmt.Errorf("failed to read command argument: %q", arguments[0])
+	}
+
+	if logLevel := ctx.GlobalString(LogLevelFlag.Name); logLevel != "" {
+		log.WithField("logLevel", logLevel).Info("will configure log level")
+
+		var err error
+		log.Level, err = logger.ParseLevel(logLevel)
+		if err != nil {
+			log.WithError(err).Fatal("could not parse logLevel")
+		}
+	}
+
+	password, ok := os.LookupEnv(keysPasswordEnv)
+	if !ok {
+		return fmt.Errorf("the consensus keys password must be set in %s", keysPasswordEnv)
+	}
+
+	keys, err := wallet.LoadConsensusKeys(password, ctx.String(KeysFlag.Name))
+	if err != nil {
+		return err
+	}
+
+	wal, err := consensus.OpenWAL(ctx.String(WALFlag.Name))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = wal.Close()
+	}()
+
+	opts := make([]grpc.ServerOption, 0)
+	if certFile := ctx.String(CertFlag.Name); len(certFile) > 0 {
+		tlsConfig, err := serverTLSConfig(certFile, ctx.String(KeyFlag.Name), ctx.String(ClientCAFlag.Name))
+		if err != nil {
+			return err
+		}
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	} else if ctx.String(NetworkFlag.Name) != "unix" {
+		// anyone reaching the port could get votes signed
+		return errors.New("TLS is required unless listening on a unix socket")
+	}
+
+	l, err := net.Listen(ctx.String(NetworkFlag.Name), ctx.String(AddressFlag.Name))
+	if err != nil {
+		return err
+	}
+
+	grpcServer := grpc.NewServer(opts...)
+	signer.RegisterRemoteSignerServer(grpcServer, signer.NewServer(blssigner.NewLocal(keys), wal))
+
+	log.WithField("address", l.Addr().String()).Info("Remote signer up: accepting connections")
+	return grpcServer.Serve(l)
+}
+
+// serverTLSConfig returns the TLS configuration of the signer, which only
+// accepts the nodes presenting a certificate signed by the client CA
+func serverTLSConfig(certFile, keyFile, clientCAFile string) (*tls.Config, error) {
+	if len(keyFile) == 0 {
+		return nil, errors.New("a TLS key is required along with the certificate")
+	}
+
+	if len(clientCAFile) == 0 {
+		return nil, errors.New("a client CA is required along with the certificate")
+	}
+
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	pem, err := ioutil.ReadFile(clientCAFile) //nolint
+	if err != nil {
+		return nil, err
+	}
+
+	clientCAs := x509.NewCertPool()
+	if !clientCAs.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificate found in %s", clientCAFile)
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
@@ -0,0 +1,76 @@
+package main
+
+import "github.com/urfave/cli"
+
+var (
+	// LogLevelFlag flag to set log level
+	LogLevelFlag = cli.StringFlag{
+		Name:  "loglevel",
+		Usage: "log level, eg: (warn, error, fatal, panic)",
+	}
+	// KeysFlag flag to set the file holding the consensus keys
+	KeysFlag = cli.StringFlag{
+		Name:  "keys",
+		Usage: "consensus keys file of the provisioner, written by the export command. The password is read from the DUSK_CONSENSUS_KEYS_PASS environment variable",
+		Value: "consensus.keys",
+	}
+	// WalletFlag flag to set the wallet file the consensus keys are exported from
+	WalletFlag = cli.StringFlag{
+		Name:  "wallet",
+		Usage: "wallet file of the provisioner. The password is read from the DUSK_WALLET_PASS environment variable",
+		Value: "wallet.dat",
+	}
+	// WALFlag flag to set the write-ahead log of the signed votes
+	WALFlag = cli.StringFlag{
+		Name:  "wal",
+		Usage: "write-ahead log of the signed votes, preventing double signing",
+		Value: "signer.wal",
+	}
+	// NetworkFlag flag to set the network to listen on
+	NetworkFlag = cli.StringFlag{
+		Name:  "network",
+		Usage: "network to listen on, eg: (tcp, unix)",
+		Value: "tcp",
+	}
+	// AddressFlag flag to set the address to listen on
+	AddressFlag = cli.StringFlag{
+		Name:  "address",
+		Usage: "address to listen on",
+		Value: "127.0.0.1:9500",
+	}
+	// CertFlag flag to set the TLS certificate
+	CertFlag = cli.StringFlag{
+		Name:  "cert",
+		Usage: "TLS certificate file. TLS can only be disabled on a unix socket",
+	}
+	// KeyFlag flag to set the TLS key
+	KeyFlag = cli.StringFlag{
+		Name:  "key",
+		Usage: "TLS key file",
+	}
+	// ClientCAFlag flag to set the CA of the client certificates
+	ClientCAFlag = cli.StringFlag{
+		Name:  "clientca",
+		Usage: "CA certificate file the certificates of the nodes must be signed with",
+	}
+)
+
+var (
+	// CLIFlags flags usable in a CLI context
+	CLIFlags = []cli.Flag{
+		LogLevelFlag,
+		KeysFlag,
+		WALFlag,
+		NetworkFlag,
+		AddressFlag,
+		CertFlag,
+		KeyFlag,
+		ClientCAFlag,
+	}
+
+	// ExportFlags flags of the export command
+	ExportFlags = []cli.Flag{
+		WalletFlag,
+		KeysFlag,
+	}
+)
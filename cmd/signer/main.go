@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+)
+
+var (
+	log *logrus.Entry
+	app = cli.NewApp()
+)
+
+func initLog() {
+	log = logrus.WithFields(logrus.Fields{
+		"app":    "signer",
+		"prefix": "main",
+	})
+}
+
+func init() {
+	initLog()
+
+	app.Action = action
+	app.Copyright = "Copyright (c) 2020 DUSK"
+	app.Name = "signer"
+	app.Usage = "Remote signer of the consensus BLS signatures of a provisioner"
+	app.Author = "DUSK 2020"
+	app.Version = "0.0.1"
+	app.Commands = []cli.Command{
+		{
+			Name:   "export",
+			Usage:  "export the consensus keys of a wallet, to be copied to the signer host",
+			Action: export,
+			Flags:  ExportFlags,
+		},
+	}
+	app.Flags = append(app.Flags, CLIFlags...)
+}
+
+func main() {
+	defer handlePanic()
+
+	if err := app.Run(os.Args); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func handlePanic() {
+	if r := recover(); r != nil {
+		log.WithError(fmt.Errorf("%+v", r)).Errorln("Application Signer panic")
+	}
+	time.Sleep(time.Second * 1)
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/base64"
 	"time"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/rpc/nodeext"
 	"github.com/dusk-network/dusk-protobuf/autogen/go/node"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -12,9 +13,10 @@ import (
 
 // NodeClient holds node related fields
 type NodeClient struct {
-	dialTimeout int64
-	NodeClient  node.NodeClient
-	conn        *grpc.ClientConn
+	dialTimeout   int64
+	NodeClient    node.NodeClient
+	NodeExtClient nodeext.NodeExtClient
+	conn          *grpc.ClientConn
 }
 
 // NewNodeClient holds a nodeClient with fixed dialTimeout of 5s
@@ -86,6 +88,7 @@ func (c *NodeClient) Connect(conf rpcConfiguration) error {
 
 	c.conn = conn
 	c.NodeClient = node.NewNodeClient(conn)
+	c.NodeExtClient = nodeext.NewNodeExtClient(conn)
 
 	return nil
 }
@@ -15,6 +15,17 @@ import (
 func main() {
 	defer handlePanic()
 
+	// The offline mode signs the txs built by a watch-only wallet, without
+	// connecting to the node
+	if len(os.Args) > 1 && os.Args[1] == "offline" {
+		if err := prompt.OfflineMenu(); err != nil {
+			_, _ = fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+
+		return
+	}
+
 	config := conf.InitConfig()
 
 	// Establish a gRPC connection with the node.
@@ -38,7 +49,7 @@ func main() {
 	// If we have no wallet loaded, we open the menu to load or
 	// create one.
 	if !resp.Loaded {
-		if err := prompt.LoadMenu(client.NodeClient); err != nil {
+		if err := prompt.LoadMenu(client.NodeClient, client.NodeExtClient); err != nil {
 			// If we get an error from `LoadMenu`, it means we lost
 			// our connection to the node.
 			_, _ = fmt.Fprintln(os.Stderr, err.Error())
@@ -47,7 +58,7 @@ func main() {
 	}
 
 	// Once loaded, we open the menu for wallet operations.
-	if err := prompt.WalletMenu(client.NodeClient); err != nil {
+	if err := prompt.WalletMenu(client.NodeClient, client.NodeExtClient); err != nil {
 		_, _ = fmt.Fprintln(os.Stderr, err.Error())
 		os.Exit(1)
 	}
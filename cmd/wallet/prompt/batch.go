@@ -0,0 +1,215 @@
+package prompt
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/rpc/nodeext"
+	"github.com/dusk-network/dusk-wallet/v2/wallet"
+	"github.com/manifoldco/promptui"
+)
+
+// batchTransfer pays the recipients listed in a CSV batch file. Each row of
+// the file holds an address and an amount of DUSK, and an optional header row
+// names the columns `address,amount`. Lines starting with '#' are ignored.
+//
+// The recipients are paid by txs of up to transactions.MaxRecipients outputs
+// each. The change of a tx can not be spent until the tx is included in a
+// block, so each tx but the last is waited for until it is included, before
+// the next one is sent.
+func batchTransfer(client nodeext.NodeExtClient) (string, error) {
+	validate := func(input string) error {
+		_, err := readBatch(input)
+		return err
+	}
+
+	prompt := promptui.Prompt{
+		Label:    "Batch File",
+		Validate: validate,
+	}
+
+	path, err := prompt.Run()
+	if err != nil {
+		panic(err)
+	}
+
+	recipients, err := readBatch(path)
+	if err != nil {
+		return "", err
+	}
+
+	batches := splitBatch(recipients)
+
+	var total uint64
+	for _, r := range recipients {
+		total += r.Amount
+	}
+
+	confirm := promptui.Prompt{
+		Label:     fmt.Sprintf("Send %.8f DUSK to %d recipients within %d txs", float64(total)/float64(wallet.DUSK), len(recipients), len(batches)),
+		IsConfirm: true,
+	}
+
+	if _, err := confirm.Run(); err != nil {
+		if err == promptui.ErrInterrupt {
+			panic(err)
+		}
+
+		return "Batch transfer cancelled", nil
+	}
+
+	for i, batch := range batches {
+		if err := sendBatch(client, batch, i == len(batches)-1); err != nil {
+			return "", fmt.Errorf("batch transfer stopped at tx %d of %d, the recipients of the previous txs are paid: %v", i+1, len(batches), err)
+		}
+
+		_, _ = fmt.Fprintf(os.Stdout, "Tx %d of %d sent\n", i+1, len(batches))
+	}
+
+	return fmt.Sprintf("Paid %d recipients", len(recipients)), nil
+}
+
+// sendBatch sends a tx paying a batch of recipients. Unless it is the last
+// one, it waits for the tx to be included in a block, so that its change can
+// be spent by the next tx.
+func sendBatch(client nodeext.NodeExtClient, batch []*nodeext.Recipient, last bool) error {
+	if last {
+		resp, err := client.MultiTransfer(context.Background(), &nodeext.MultiTransferRequest{Recipients: batch})
+		if err != nil {
+			return err
+		}
+
+		_, _ = fmt.Fprintf(os.Stdout, "Tx hash: %s\n", hex.EncodeToString(resp.Hash))
+		return nil
+	}
+
+	// The events are subscribed before sending the tx, as its id is not known
+	// in advance and its events could be missed otherwise
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := client.SubscribeTxEvents(ctx, &nodeext.TxEventsRequest{})
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.MultiTransfer(context.Background(), &nodeext.MultiTransferRequest{Recipients: batch})
+	if err != nil {
+		return err
+	}
+
+	txid := hex.EncodeToString(resp.Hash)
+	_, _ = fmt.Fprintf(os.Stdout, "Tx hash: %s, waiting for it to be included in a block\n", txid)
+
+	for {
+		e, err := events.Recv()
+		if err != nil {
+			return err
+		}
+
+		if e.Txid != txid {
+			continue
+		}
+
+		switch e.Status {
+		case nodeext.TxStatus_INCLUDED:
+			return nil
+		case nodeext.TxStatus_REJECTED, nodeext.TxStatus_EVICTED:
+			return fmt.Errorf("tx %s was %s: %s", txid, strings.ToLower(e.Status.String()), e.Reason)
+		}
+	}
+}
+
+// readBatch reads the recipients of a batch file
+func readBatch(path string) ([]*nodeext.Recipient, error) {
+	f, err := os.Open(path) //nolint
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = f.Close()
+	}()
+
+	r := csv.NewReader(f)
+	r.Comment = '#'
+	r.FieldsPerRecord = 2
+	r.TrimLeadingSpace = true
+
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	recipients := make([]*nodeext.Recipient, 0, len(records))
+	for i, record := range records {
+		address, amount := strings.TrimSpace(record[0]), strings.TrimSpace(record[1])
+		if i == 0 && strings.EqualFold(address, "address") && strings.EqualFold(amount, "amount") {
+			continue
+		}
+
+		if err := validateAddress(address); err != nil {
+			return nil, fmt.Errorf("row %d: invalid address: %v", i+1, err)
+		}
+
+		units, err := parseDusk(amount)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %v", i+1, err)
+		}
+
+		recipients = append(recipients, &nodeext.Recipient{Address: []byte(address), Amount: units})
+	}
+
+	if len(recipients) == 0 {
+		return nil, errors.New("batch file lists no recipients")
+	}
+
+	return recipients, nil
+}
+
+// decimalAmount matches an amount of DUSK written as a plain decimal number.
+// big.Rat would parse fractions and exponents as well
+var decimalAmount = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?$`)
+
+// parseDusk converts an amount of DUSK into units, without the rounding
+// errors of a float
+func parseDusk(amount string) (uint64, error) {
+	if !decimalAmount.MatchString(amount) {
+		return 0, fmt.Errorf("amount %q must be a decimal number", amount)
+	}
+
+	r, ok := new(big.Rat).SetString(amount)
+	if !ok || r.Sign() <= 0 {
+		return 0, fmt.Errorf("amount %q must be a positive number", amount)
+	}
+
+	r.Mul(r, new(big.Rat).SetInt(new(big.Int).SetUint64(uint64(wallet.DUSK))))
+	if !r.IsInt() {
+		return 0, fmt.Errorf("amount %q has more than 8 decimals", amount)
+	}
+
+	if !r.Num().IsUint64() {
+		return 0, fmt.Errorf("amount %q is too high", amount)
+	}
+
+	return r.Num().Uint64(), nil
+}
+
+// splitBatch splits the recipients into batches which fit in a tx each
+func splitBatch(recipients []*nodeext.Recipient) [][]*nodeext.Recipient {
+	batches := make([][]*nodeext.Recipient, 0, (len(recipients)+transactions.MaxRecipients-1)/transactions.MaxRecipients)
+	for len(recipients) > transactions.MaxRecipients {
+		batches = append(batches, recipients[:transactions.MaxRecipients])
+		recipients = recipients[transactions.MaxRecipients:]
+	}
+
+	return append(batches, recipients)
+}
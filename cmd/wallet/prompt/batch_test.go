@@ -0,0 +1,162 @@
+package prompt
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/rpc/nodeext"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadBatch(t *testing.T) {
+	alice, bob := randAddress(t), randAddress(t)
+
+	tests := []struct {
+		name    string
+		content string
+		amounts []uint64
+		err     bool
+	}{
+		{"rows", alice + ",1\n" + bob + ",0.5\n", []uint64{100000000, 50000000}, false},
+		{"header row", "address,amount\n" + alice + ",1\n", []uint64{100000000}, false},
+		{"header row with spaces", " Address , Amount\n" + alice + ", 2\n", []uint64{200000000}, false},
+		{"comments", "# payouts\n" + alice + ",1\n# " + bob + ",1\n", []uint64{100000000}, false},
+		{"header row only", "address,amount\n", nil, true},
+		{"empty file", "", nil, true},
+		{"header row after the first row", alice + ",1\naddress,amount\n", nil, true},
+		{"missing amount", alice + "\n", nil, true},
+		{"extra column", alice + ",1,2\n", nil, true},
+		{"invalid address", "notanaddress,1\n", nil, true},
+		{"zero amount", alice + ",0\n", nil, true},
+		{"negative amount", alice + ",-1\n", nil, true},
+		{"too many decimals", alice + ",0.000000001\n", nil, true},
+		{"fraction", alice + ",1/3\n", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeBatch(t, tt.content)
+			defer os.Remove(path)
+
+			recipients, err := readBatch(path)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			if assert.Len(t, recipients, len(tt.amounts)) {
+				for i, amount := range tt.amounts {
+					assert.Equal(t, amount, recipients[i].Amount)
+				}
+			}
+		})
+	}
+
+	_, err := readBatch("nonexistent.csv")
+	assert.Error(t, err)
+}
+
+func TestParseDusk(t *testing.T) {
+	tests := []struct {
+		amount string
+		units  uint64
+		err    bool
+	}{
+		{"1", 100000000, false},
+		{"0.5", 50000000, false},
+		{"0.00000001", 1, false},
+		{"184467440737.09551615", 18446744073709551615, false},
+		{"0", 0, true},
+		{"0.000000001", 0, true},
+		{"184467440737.09551616", 0, true},
+		{"-1", 0, true},
+		{"+1", 0, true},
+		{"1/3", 0, true},
+		{"1e5", 0, true},
+		{"0x10", 0, true},
+		{".5", 0, true},
+		{"1.", 0, true},
+		{" 1", 0, true},
+		{"", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.amount, func(t *testing.T) {
+			units, err := parseDusk(tt.amount)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.units, units)
+		})
+	}
+}
+
+func TestSplitBatch(t *testing.T) {
+	limit := transactions.MaxRecipients
+	tests := []struct {
+		recipients int
+		batches    []int
+	}{
+		{1, []int{1}},
+		{limit - 1, []int{limit - 1}},
+		{limit, []int{limit}},
+		{limit + 1, []int{limit, 1}},
+		{2 * limit, []int{limit, limit}},
+		{2*limit + 1, []int{limit, limit, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.recipients), func(t *testing.T) {
+			recipients := make([]*nodeext.Recipient, tt.recipients)
+			for i := range recipients {
+				recipients[i] = &nodeext.Recipient{Amount: uint64(i)}
+			}
+
+			batches := splitBatch(recipients)
+			if !assert.Len(t, batches, len(tt.batches)) {
+				return
+			}
+
+			// The recipients are split in order
+			var next uint64
+			for i, batch := range batches {
+				assert.Len(t, batch, tt.batches[i])
+				for _, r := range batch {
+					assert.Equal(t, next, r.Amount)
+					next++
+				}
+			}
+		})
+	}
+}
+
+func randAddress(t *testing.T) string {
+	seed := make([]byte, 64)
+	_, err := rand.Read(seed)
+	assert.NoError(t, err)
+
+	address, err := key.NewKeyPair(seed).PublicKey().PublicAddress(2)
+	assert.NoError(t, err)
+	return address.String()
+}
+
+func writeBatch(t *testing.T, content string) string {
+	f, err := ioutil.TempFile("", "batch*.csv")
+	assert.NoError(t, err)
+	defer func() {
+		_ = f.Close()
+	}()
+
+	_, err = f.WriteString(content)
+	assert.NoError(t, err)
+	return f.Name()
+}
@@ -3,7 +3,12 @@ package prompt
 import (
 	"context"
 	"errors"
+	"fmt"
+	"os"
+	"strings"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/mnemonic"
+	"github.com/dusk-network/dusk-blockchain/pkg/rpc/nodeext"
 	"github.com/dusk-network/dusk-protobuf/autogen/go/node"
 	"github.com/manifoldco/promptui"
 )
@@ -13,9 +18,63 @@ func loadWallet(client node.NodeClient) (*node.LoadResponse, error) {
 	return client.LoadWallet(context.Background(), &node.LoadRequest{Password: pw})
 }
 
-func createWallet(client node.NodeClient) (*node.LoadResponse, error) {
+// createWallet creates a wallet and shows the mnemonic backup of its seed.
+// The node does not keep the mnemonic, so it is shown only once
+func createWallet(client nodeext.NodeExtClient) (*node.LoadResponse, error) {
 	pw := getPassword()
-	return client.CreateWallet(context.Background(), &node.CreateRequest{Password: pw})
+	resp, err := client.CreateWalletWithMnemonic(context.Background(), &nodeext.CreateWalletRequest{Password: pw})
+	if err != nil {
+		return nil, err
+	}
+
+	_, _ = fmt.Fprintln(os.Stdout, "Write down the following words, in order, and keep them in a safe place.")
+	_, _ = fmt.Fprintln(os.Stdout, "They are the only way to restore the wallet if the wallet file is lost.")
+	_, _ = fmt.Fprintln(os.Stdout, "They will not be shown again.")
+	words := strings.Fields(resp.Mnemonic)
+	for i, word := range words {
+		_, _ = fmt.Fprintf(os.Stdout, "%2d. %-10s", i+1, word)
+		if (i+1)%6 == 0 {
+			_, _ = fmt.Fprintln(os.Stdout)
+		}
+	}
+
+	confirm := promptui.Prompt{
+		Label:     "I have written down the words",
+		IsConfirm: true,
+	}
+
+	for {
+		_, err := confirm.Run()
+		if err == nil {
+			break
+		}
+
+		if err == promptui.ErrInterrupt {
+			panic(err)
+		}
+	}
+
+	return &node.LoadResponse{Key: &node.PubKey{PublicKey: []byte(resp.Address)}}, nil
+}
+
+func restoreFromMnemonic(client node.NodeClient) (*node.LoadResponse, error) {
+	validate := func(input string) error {
+		_, err := mnemonic.ToSeed(input)
+		return err
+	}
+
+	prompt := promptui.Prompt{
+		Label:    "Mnemonic",
+		Validate: validate,
+	}
+
+	phrase, err := prompt.Run()
+	if err != nil {
+		panic(err)
+	}
+
+	pw := getPassword()
+	return client.CreateFromSeed(context.Background(), &node.CreateRequest{Password: pw, Seed: []byte(phrase)})
 }
 
 func loadFromSeed(client node.NodeClient) (*node.LoadResponse, error) {
@@ -42,9 +101,23 @@ func loadFromSeed(client node.NodeClient) (*node.LoadResponse, error) {
 	return client.CreateFromSeed(context.Background(), &node.CreateRequest{Password: pw, Seed: []byte(seed)})
 }
 
+func changePassword(client nodeext.NodeExtClient) (*nodeext.ChangePasswordResponse, error) {
+	oldPw := getPasswordWithLabel("Current password")
+	newPw := getPasswordWithLabel("New password")
+	if getPasswordWithLabel("Confirm new password") != newPw {
+		return nil, errors.New("passwords do not match")
+	}
+
+	return client.ChangePassword(context.Background(), &nodeext.ChangePasswordRequest{OldPassword: oldPw, NewPassword: newPw})
+}
+
 func getPassword() string {
+	return getPasswordWithLabel("Password")
+}
+
+func getPasswordWithLabel(label string) string {
 	prompt := promptui.Prompt{
-		Label: "Password",
+		Label: label,
 		Mask:  '*',
 	}
 
@@ -0,0 +1,346 @@
+package prompt
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/mnemonic"
+	duskwallet "github.com/dusk-network/dusk-blockchain/pkg/core/data/wallet"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/rpc/nodeext"
+	"github.com/dusk-network/dusk-protobuf/autogen/go/node"
+	"github.com/dusk-network/dusk-wallet/v2/wallet"
+	"github.com/manifoldco/promptui"
+)
+
+// Offline signing
+//
+// A watch-only wallet, loaded on the online node from the view key of a
+// wallet, builds the unsigned txs and writes them to a file. The wallet is
+// started in offline mode on a device holding the seed, which signs the file
+// without any connection to the node. The signed tx is then carried back and
+// submitted to the online node. Both files hold hex-encoded data.
+
+// TODO: use netprefix inferred from config
+const offlineNetPrefix = byte(2)
+
+// OfflineMenu opens the prompt for signing txs offline. It does not connect to
+// the node.
+func OfflineMenu() error {
+	signer, err := loadSigner()
+	if err != nil {
+		return err
+	}
+
+	for {
+		prompt := promptui.Select{
+			Label: "Select action",
+			Items: []string{"Sign Transaction", "Show View Key", "Show Address", "Exit"},
+		}
+
+		_, result, err := prompt.Run()
+		if err != nil {
+			panic(err)
+		}
+
+		var res string
+		switch result {
+		case "Sign Transaction":
+			res, err = signTx(signer)
+			if err != nil {
+				return err
+			}
+		case "Show View Key":
+			res = "View key: " + hex.EncodeToString(signer.ViewKey())
+		case "Show Address":
+			addr, err := signer.PublicAddress()
+			if err != nil {
+				return err
+			}
+
+			res = "Address: " + addr
+		case "Exit":
+			os.Exit(0)
+		}
+
+		_, _ = fmt.Fprintln(os.Stdout, res)
+	}
+}
+
+// loadSigner opens the seed of the wallet, either from its seed file or from
+// its mnemonic
+func loadSigner() (*duskwallet.Signer, error) {
+	prompt := promptui.Select{
+		Label: "Open wallet from",
+		Items: []string{"Seed File", "Mnemonic"},
+	}
+
+	_, result, err := prompt.Run()
+	if err != nil {
+		panic(err)
+	}
+
+	if result == "Mnemonic" {
+		validate := func(input string) error {
+			_, err := mnemonic.ToSeed(input)
+			return err
+		}
+
+		phrasePrompt := promptui.Prompt{
+			Label:    "Mnemonic",
+			Validate: validate,
+			Mask:     '*',
+		}
+
+		phrase, err := phrasePrompt.Run()
+		if err != nil {
+			panic(err)
+		}
+
+		seed, err := mnemonic.ToSeed(phrase)
+		if err != nil {
+			return nil, err
+		}
+
+		return duskwallet.NewSigner(seed, offlineNetPrefix)
+	}
+
+	path := getPath("Seed File")
+	pw := getPassword()
+	return duskwallet.LoadSigner(offlineNetPrefix, pw, path)
+}
+
+// signTx signs an unsigned tx file, once the user confirms its outputs
+func signTx(signer *duskwallet.Signer) (string, error) {
+	validate := func(input string) error {
+		_, err := readUnsignedTx(input)
+		return err
+	}
+
+	prompt := promptui.Prompt{
+		Label:    "Unsigned Tx File",
+		Validate: validate,
+	}
+
+	path, err := prompt.Run()
+	if err != nil {
+		panic(err)
+	}
+
+	u, err := readUnsignedTx(path)
+	if err != nil {
+		return "", err
+	}
+
+	// The last output is the change back to the wallet
+	for i, o := range u.Outputs {
+		_, _ = fmt.Fprintf(os.Stdout, "Output %d: %.8f DUSK to %s\n", i+1, float64(o.Amount)/float64(wallet.DUSK), o.Address)
+	}
+
+	confirm := promptui.Prompt{
+		Label:     fmt.Sprintf("Sign the tx paying a fee of %.8f DUSK", float64(u.Fee)/float64(wallet.DUSK)),
+		IsConfirm: true,
+	}
+
+	if _, err := confirm.Run(); err != nil {
+		if err == promptui.ErrInterrupt {
+			panic(err)
+		}
+
+		return "Signing cancelled", nil
+	}
+
+	tx, err := signer.Sign(u)
+	if err != nil {
+		return "", err
+	}
+
+	buf := new(bytes.Buffer)
+	if err := message.MarshalTx(buf, tx); err != nil {
+		return "", err
+	}
+
+	txid, err := tx.CalculateHash()
+	if err != nil {
+		return "", err
+	}
+
+	out := getPath("Signed Tx File")
+	if err := ioutil.WriteFile(out, []byte(hex.EncodeToString(buf.Bytes())), 0600); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("Signed tx %s written to %s", hex.EncodeToString(txid), out), nil
+}
+
+// loadWatchOnlyWallet loads a watch-only wallet on the node, from the view key
+// shown by the offline mode
+func loadWatchOnlyWallet(client nodeext.NodeExtClient) (*node.LoadResponse, error) {
+	validate := func(input string) error {
+		if _, err := hex.DecodeString(strings.TrimSpace(input)); err != nil {
+			return errors.New("view key must be hex-encoded")
+		}
+
+		return nil
+	}
+
+	prompt := promptui.Prompt{
+		Label:    "View Key",
+		Validate: validate,
+		Mask:     '*',
+	}
+
+	viewKey, err := prompt.Run()
+	if err != nil {
+		panic(err)
+	}
+
+	pw := getPassword()
+	resp, err := client.LoadWatchOnlyWallet(context.Background(), &nodeext.LoadWatchOnlyRequest{ViewKey: viewKey, Password: pw})
+	if err != nil {
+		return nil, err
+	}
+
+	return &node.LoadResponse{Key: &node.PubKey{PublicKey: []byte(resp.Address)}}, nil
+}
+
+// createUnsignedTx builds a transfer from the watch-only wallet, and writes it
+// to a file to be signed offline
+func createUnsignedTx(client nodeext.NodeExtClient) (string, error) {
+	addressPrompt := promptui.Prompt{
+		Label:    "Address",
+		Validate: validateAddress,
+	}
+
+	address, err := addressPrompt.Run()
+	if err != nil {
+		return "", err
+	}
+
+	validateAmount := func(input string) error {
+		_, err := parseDusk(input)
+		return err
+	}
+
+	amountPrompt := promptui.Prompt{
+		Label:    "Amount",
+		Validate: validateAmount,
+	}
+
+	amountString, err := amountPrompt.Run()
+	if err != nil {
+		panic(err)
+	}
+
+	amount, _ := parseDusk(amountString)
+
+	// An empty fee pays the minimum fee
+	validateFee := func(input string) error {
+		if input == "" {
+			return nil
+		}
+
+		return validateAmount(input)
+	}
+
+	feePrompt := promptui.Prompt{
+		Label:    "Fee (empty for the minimum fee)",
+		Validate: validateFee,
+	}
+
+	feeString, err := feePrompt.Run()
+	if err != nil {
+		panic(err)
+	}
+
+	var fee uint64
+	if feeString != "" {
+		fee, _ = parseDusk(feeString)
+	}
+
+	req := &nodeext.MultiTransferRequest{
+		Recipients: []*nodeext.Recipient{{Address: []byte(address), Amount: amount}},
+		Fee:        fee,
+	}
+
+	resp, err := client.CreateUnsignedTx(context.Background(), req)
+	if err != nil {
+		return "", err
+	}
+
+	out := getPath("Unsigned Tx File")
+	if err := ioutil.WriteFile(out, []byte(hex.EncodeToString(resp.Tx)), 0600); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("Unsigned tx written to %s, sign it with the wallet in offline mode", out), nil
+}
+
+// submitSignedTx publishes a tx signed offline
+func submitSignedTx(client nodeext.NodeExtClient) (*nodeext.TransferResponse, error) {
+	validate := func(input string) error {
+		_, err := readHexFile(input)
+		return err
+	}
+
+	prompt := promptui.Prompt{
+		Label:    "Signed Tx File",
+		Validate: validate,
+	}
+
+	path, err := prompt.Run()
+	if err != nil {
+		panic(err)
+	}
+
+	tx, err := readHexFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.SubmitSignedTx(context.Background(), &nodeext.SubmitSignedTxRequest{Tx: tx})
+}
+
+func readUnsignedTx(path string) (*duskwallet.UnsignedTx, error) {
+	b, err := readHexFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return duskwallet.UnmarshalUnsignedTx(bytes.NewBuffer(b))
+}
+
+func readHexFile(path string) ([]byte, error) {
+	data, err := ioutil.ReadFile(path) //nolint
+	if err != nil {
+		return nil, err
+	}
+
+	return hex.DecodeString(strings.TrimSpace(string(data)))
+}
+
+func getPath(label string) string {
+	prompt := promptui.Prompt{
+		Label: label,
+		Validate: func(input string) error {
+			if input == "" {
+				return errors.New("path can not be empty")
+			}
+
+			return nil
+		},
+	}
+
+	path, err := prompt.Run()
+	if err != nil {
+		panic(err)
+	}
+
+	return path
+}
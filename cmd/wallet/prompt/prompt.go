@@ -9,17 +9,18 @@ import (
 	"strings"
 	"time"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/rpc/nodeext"
 	"github.com/dusk-network/dusk-protobuf/autogen/go/node"
 	"github.com/dusk-network/dusk-wallet/v2/wallet"
 	"github.com/manifoldco/promptui"
 )
 
 // LoadMenu opens the prompt for loading a wallet.
-func LoadMenu(client node.NodeClient) error {
+func LoadMenu(client node.NodeClient, extClient nodeext.NodeExtClient) error {
 
 	prompt := promptui.Select{
 		Label: "Select action",
-		Items: []string{"Load Wallet", "Create Wallet", "Load Wallet From Seed", "Exit"},
+		Items: []string{"Load Wallet", "Create Wallet", "Restore Wallet From Mnemonic", "Load Wallet From Seed", "Load Watch-Only Wallet", "Exit"},
 	}
 
 	_, result, err := prompt.Run()
@@ -33,9 +34,13 @@ func LoadMenu(client node.NodeClient) error {
 	case "Load Wallet":
 		resp, err = loadWallet(client)
 	case "Create Wallet":
-		resp, err = createWallet(client)
+		resp, err = createWallet(extClient)
+	case "Restore Wallet From Mnemonic":
+		resp, err = restoreFromMnemonic(client)
 	case "Load Wallet From Seed":
 		resp, err = loadFromSeed(client)
+	case "Load Watch-Only Wallet":
+		resp, err = loadWatchOnlyWallet(extClient)
 	case "Exit":
 		os.Exit(0)
 	}
@@ -49,7 +54,7 @@ func LoadMenu(client node.NodeClient) error {
 }
 
 // WalletMenu opens the prompt for doing wallet operations.
-func WalletMenu(client node.NodeClient) error {
+func WalletMenu(client node.NodeClient, extClient nodeext.NodeExtClient) error {
 	for {
 		// Get sync progress first and print it
 		resp, err := client.GetSyncProgress(context.Background(), &node.EmptyRequest{})
@@ -60,8 +65,8 @@ func WalletMenu(client node.NodeClient) error {
 
 		prompt := promptui.Select{
 			Label: "Select action",
-			Items: []string{"Transfer DUSK", "Stake DUSK", "Bid DUSK", "Show Balance", "Show Address", "Show Transaction History", "Automate Consensus Participation", "Exit"},
-			Size:  8,
+			Items: []string{"Transfer DUSK", "Batch Transfer DUSK", "Stake DUSK", "Bid DUSK", "Create Unsigned Transaction", "Submit Signed Transaction", "Show Balance", "Show Address", "Show Transaction History", "Automate Consensus Participation", "Change Password", "Exit"},
+			Size:  12,
 		}
 
 		_, result, err := prompt.Run()
@@ -78,6 +83,11 @@ func WalletMenu(client node.NodeClient) error {
 			}
 
 			res = "Tx hash: " + hex.EncodeToString(resp.Hash)
+		case "Batch Transfer DUSK":
+			res, err = batchTransfer(extClient)
+			if err != nil {
+				return err
+			}
 		case "Stake DUSK":
 			resp, err := stakeDusk(client)
 			if err != nil {
@@ -91,6 +101,18 @@ func WalletMenu(client node.NodeClient) error {
 				return err
 			}
 
+			res = "Tx hash: " + hex.EncodeToString(resp.Hash)
+		case "Create Unsigned Transaction":
+			res, err = createUnsignedTx(extClient)
+			if err != nil {
+				return err
+			}
+		case "Submit Signed Transaction":
+			resp, err := submitSignedTx(extClient)
+			if err != nil {
+				return err
+			}
+
 			res = "Tx hash: " + hex.EncodeToString(resp.Hash)
 		case "Show Balance":
 			resp, err := client.GetBalance(context.Background(), &node.EmptyRequest{})
@@ -120,6 +142,13 @@ func WalletMenu(client node.NodeClient) error {
 				return err
 			}
 
+			res = resp.Response
+		case "Change Password":
+			resp, err := changePassword(extClient)
+			if err != nil {
+				return err
+			}
+
 			res = resp.Response
 		case "Exit":
 			os.Exit(0)
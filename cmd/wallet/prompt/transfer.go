@@ -13,16 +13,6 @@ import (
 func transferDusk(client node.NodeClient) (*node.TransferResponse, error) {
 	amount := getAmount()
 
-	validateAddress := func(input string) error {
-		address := key.PublicAddress(input)
-		// TODO: use netprefix inferred from config
-		if _, err := address.ToKey(2); err != nil {
-			return err
-		}
-
-		return nil
-	}
-
 	addressPrompt := promptui.Prompt{
 		Label:    "Address",
 		Validate: validateAddress,
@@ -36,6 +26,16 @@ func transferDusk(client node.NodeClient) (*node.TransferResponse, error) {
 	return client.Transfer(context.Background(), &node.TransferRequest{Amount: amount, Address: []byte(address)})
 }
 
+func validateAddress(input string) error {
+	address := key.PublicAddress(input)
+	// TODO: use netprefix inferred from config
+	if _, err := address.ToKey(2); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func bidDusk(client node.NodeClient) (*node.TransferResponse, error) {
 	amount := getAmount()
 	lockTime := getLockTime()
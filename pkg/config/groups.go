@@ -100,9 +100,36 @@ type mempoolConfiguration struct {
 	PoolType    string
 	PreallocTxs uint32
 	MaxInvItems uint32
+
+	// mempool journal file. Empty path disables the journal
+	JournalFile     string
+	JournalInterval uint
 }
 
 type consensusConfiguration struct {
 	DefaultLockTime uint64
 	DefaultAmount   uint64
+
+	// bounds, in milliseconds, of the adaptive step timeouts
+	MinTimeout uint
+	MaxTimeout uint
+
+	// path of the write-ahead log of the signed votes. Empty disables it
+	WALFile string
+
+	// address of the remote BLS signer. Empty signs in-process
+	RemoteSigner string
+	// certificate of the remote BLS signer. Empty disables TLS
+	RemoteSignerCert string
+	// certificate and key the node authenticates with to the remote BLS
+	// signer. Required along with RemoteSignerCert
+	RemoteSignerClientCert string
+	RemoteSignerClientKey  string
+
+	// path of the trace of the consensus events. Empty disables tracing
+	TraceFile string
+
+	// path of the checkpoint of the consensus data. Empty replays the chain
+	// from genesis on startup
+	CheckpointFile string
 }
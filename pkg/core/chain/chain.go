@@ -10,6 +10,7 @@ import (
 	"sync"
 
 	"github.com/bwesterb/go-ristretto"
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer/peermsg"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer/processing/chainsync"
@@ -22,6 +23,7 @@ import (
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/txevent"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/verifiers"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/encoding"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
@@ -64,6 +66,22 @@ type Chain struct {
 	bidList  *user.BidList
 	counter  *chainsync.Counter
 
+	// slashed keeps track of the offences already punished, together with
+	// the round they were committed in
+	slashed map[string]uint64
+
+	// rewardSplit is the split of the rewards of the block following
+	// prevBlock, drawn from the provisioner set which certified prevBlock
+	rewardSplit verifiers.RewardSplit
+
+	// activation holds the heights from which the format of the blocks and
+	// of the consensus messages changes on the network
+	activation block.Activation
+
+	// checkpoint periodically saves the consensus data, so that it is not
+	// replayed from genesis on restart. It is nil if disabled
+	checkpoint *checkpoint
+
 	// loader abstracts away the persistence aspect of Block operations
 	loader Loader
 
@@ -146,6 +164,7 @@ func New(eventBus *eventbus.EventBus, rpcBus *rpcbus.RPCBus, counter *chainsync.
 		eventBus:                 eventBus,
 		rpcBus:                   rpcBus,
 		p:                        user.NewProvisioners(),
+		slashed:                  make(map[string]uint64),
 		bidList:                  &user.BidList{},
 		counter:                  counter,
 		certificateChan:          certificateChan,
@@ -158,6 +177,11 @@ func New(eventBus *eventbus.EventBus, rpcBus *rpcbus.RPCBus, counter *chainsync.
 		rebuildChainChan:         rebuildChainChan,
 		loader:                   loader,
 		verifier:                 verifier,
+		activation:               config.Activation(),
+	}
+
+	if checkpointFile := config.Get().Consensus.CheckpointFile; len(checkpointFile) > 0 {
+		chain.checkpoint = newCheckpoint(checkpointFile)
 	}
 
 	prevBlock, err := loader.LoadTip()
@@ -293,11 +317,35 @@ func (c *Chain) AcceptBlock(blk block.Block) error {
 	// at the same height, as the probability of the committee creating two valid certificates
 	// for the same round is negligible.
 	l.Trace("verifying block certificate")
-	if err := verifiers.CheckBlockCertificate(*c.p, blk); err != nil {
+	if err := verifiers.CheckBlockCertificate(*c.p, blk, c.activation); err != nil {
 		l.WithError(err).Warnln("certificate verification failed")
 		return err
 	}
 
+	// Check that the rewards go to the generator and to the provisioners
+	// certified by the previous block
+	l.Trace("verifying coinbase")
+	if err := verifiers.VerifyRewards(c.rewardSplit, blk, c.activation); err != nil {
+		l.WithError(err).Warnln("coinbase verification failed")
+		return err
+	}
+
+	// The provisioners certified by this block are drawn from the set which
+	// produced the certificate, before the txs of the block change it
+	rewardSplit := verifiers.NewRewardSplit(*c.p, blk, c.activation)
+
+	// The checkpoint holds the consensus data preceding the block, so that
+	// the block is replayed on restart, drawing the reward split again
+	var checkpoint []byte
+	if c.checkpoint != nil && blk.Header.Height%checkpointInterval == 0 {
+		buf := new(bytes.Buffer)
+		if err := marshalConsensusData(buf, c.consensusData()); err != nil {
+			l.WithError(err).Warnln("could not checkpoint the consensus data")
+		} else {
+			checkpoint = buf.Bytes()
+		}
+	}
+
 	// 3. Add provisioners and block generators
 	l.Trace("adding consensus nodes")
 	// We set the stake start height as blk.Header.Height+2.
@@ -315,7 +363,14 @@ func (c *Chain) AcceptBlock(blk block.Block) error {
 		return err
 	}
 
+	if checkpoint != nil {
+		if err := c.checkpoint.save(checkpoint); err != nil {
+			l.WithError(err).Warnln("could not save the consensus data checkpoint")
+		}
+	}
+
 	c.prevBlock = blk
+	c.rewardSplit = rewardSplit
 
 	// 5. Gossip advertise block Hash
 	l.Trace("gossiping block")
@@ -326,8 +381,11 @@ func (c *Chain) AcceptBlock(blk block.Block) error {
 
 	// 6. Remove expired provisioners and bids
 	l.Trace("removing expired consensus transactions")
-	c.removeExpiredProvisioners(blk.Header.Height)
-	c.removeExpiredBids(blk.Header.Height + 2)
+	c.removeExpiredConsensusNodes(blk.Header.Height)
+
+	// The committees extracted from the previous provisioner set can not be
+	// reused. The new cache is shared with the next RoundUpdate
+	c.p.ResetCommittees()
 
 	// 7. Notify other subsystems for the accepted block
 	// Subsystems listening for this topic:
@@ -338,6 +396,17 @@ func (c *Chain) AcceptBlock(blk block.Block) error {
 	msg := message.New(topics.AcceptedBlock, blk)
 	c.eventBus.Publish(topics.AcceptedBlock, msg)
 
+	// 8. Notify tx lifecycle subscribers for the included txs
+	for _, tx := range blk.Txs {
+		txid, err := tx.CalculateHash()
+		if err != nil {
+			l.WithError(err).Warnln("tx hash calculation failed")
+			continue
+		}
+
+		txevent.Publish(c.eventBus, txevent.Event{TxID: txid, Status: txevent.Included, Height: blk.Header.Height})
+	}
+
 	l.Trace("procedure ended")
 	return nil
 }
@@ -349,11 +418,12 @@ func (c *Chain) onInitialization(message.Message) error {
 func (c *Chain) sendRoundUpdate() error {
 	hdr := c.intermediateBlock.Header
 	ru := consensus.RoundUpdate{
-		Round:   hdr.Height + 1,
-		P:       *c.p,
-		BidList: *c.bidList,
-		Seed:    hdr.Seed,
-		Hash:    hdr.Hash,
+		Round:      hdr.Height + 1,
+		P:          *c.p,
+		BidList:    *c.bidList,
+		Seed:       hdr.Seed,
+		Hash:       hdr.Hash,
+		Activation: c.activation,
 	}
 	msg := message.New(topics.RoundUpdate, ru)
 	c.eventBus.Publish(topics.RoundUpdate, msg)
@@ -370,10 +440,22 @@ func (c *Chain) addConsensusNodes(txs []transactions.Transaction, startHeight ui
 			stake := tx.(*transactions.Stake)
 			if err := c.addProvisioner(stake.PubKeyBLS, stake.Outputs[0].EncryptedAmount.BigInt().Uint64(), startHeight, startHeight+stake.Lock-2); err != nil {
 				l.Errorf("adding provisioner failed: %s", err.Error())
+				continue
 			}
+			c.setRewardAddress(stake)
 		case transactions.BidType:
 			bid := tx.(*transactions.Bid)
 			c.addBidder(bid, startHeight)
+		case transactions.UnstakeType:
+			unstake := tx.(*transactions.Unstake)
+			if err := c.unstake(unstake.PubKeyBLS, startHeight); err != nil {
+				l.Errorf("unstaking provisioner failed: %s", err.Error())
+			}
+		case transactions.SlashType:
+			slash := tx.(*transactions.Slash)
+			if err := c.slash(slash); err != nil {
+				l.Errorf("slashing provisioner failed: %s", err.Error())
+			}
 		}
 	}
 }
@@ -388,10 +470,25 @@ func (c *Chain) processCandidateVerificationRequest(r rpcbus.Request) {
 	}
 	cm := r.Params.(message.Candidate)
 
-	err := c.verifier.CheckBlock(*c.intermediateBlock, *cm.Block)
+	err := c.verifyCandidate(cm)
 	r.RespChan <- rpcbus.Response{Resp: nil, Err: err}
 }
 
+// verifyCandidate checks a candidate block against the intermediate block
+func (c *Chain) verifyCandidate(cm message.Candidate) error {
+	if err := c.verifier.CheckBlock(*c.intermediateBlock, *cm.Block); err != nil {
+		return err
+	}
+
+	// The certificate of the intermediate block comes with the candidate,
+	// and determines the provisioners to be rewarded
+	hdr := *c.intermediateBlock.Header
+	hdr.Certificate = cm.Certificate
+	prevBlock := block.Block{Header: &hdr, Txs: c.intermediateBlock.Txs}
+
+	return verifiers.VerifyCoinbase(*c.p, prevBlock, *cm.Block, c.activation)
+}
+
 // Send Inventory message to all peers
 func (c *Chain) advertiseBlock(b block.Block) error {
 	msg := &peermsg.Inv{}
@@ -411,8 +508,12 @@ func (c *Chain) advertiseBlock(b block.Block) error {
 	return nil
 }
 
-// TODO: consensus data should be persisted to disk, to decrease
-// startup times
+// restoreConsensusData rebuilds the provisioners, the bids, the punished
+// offences and the reward split of the next block by replaying the chain,
+// block by block, as AcceptBlock does. The replay starts from the checkpoint,
+// if it matches the stored chain, and from genesis otherwise.
+// The stakes which already expired need to be replayed as well, since the
+// Slashes are subtracted from the oldest stakes of a provisioner
 func (c *Chain) restoreConsensusData() error {
 	currentHeight, err := c.loader.Height()
 	if err != nil {
@@ -420,44 +521,103 @@ func (c *Chain) restoreConsensusData() error {
 		currentHeight = 0
 	}
 
-	searchingHeight := uint64(0)
-	if currentHeight > transactions.MaxLockTime {
-		searchingHeight = currentHeight - transactions.MaxLockTime
+	start := uint64(0)
+	if data := c.loadCheckpoint(currentHeight); data != nil {
+		c.p, c.bidList, c.slashed = data.p, &data.bidList, data.slashed
+		start = data.height + 1
 	}
 
-	for {
-		blk, loadErr := c.loader.BlockAt(searchingHeight)
-		if loadErr != nil {
+	for height := start; height <= currentHeight; height++ {
+		blk, err := c.loader.BlockAt(height)
+		if err != nil {
 			log.WithError(err).Debugln("cannot fetch hash by heigth, quitting restoreConsensusData routine")
 			break
 		}
 
-		for _, tx := range blk.Txs {
-			switch t := tx.(type) {
-			case *transactions.Stake:
-				// Only add them if their stake is still valid
-				if searchingHeight+t.Lock > currentHeight {
-					amount := t.Outputs[0].EncryptedAmount.BigInt().Uint64()
-					if err := c.addProvisioner(t.PubKeyBLS, amount, searchingHeight+2, searchingHeight+t.Lock); err != nil {
-						return fmt.Errorf("unexpected error in adding provisioner following a stake transaction: %v", err)
-					}
-				}
-			case *transactions.Bid:
-				// TODO: The commitment to D is turned (in quite awful fashion) from a Point into a Scalar here,
-				// to work with the `zkproof` package. Investigate if we should change this (reserve for testnet v2,
-				// as this is most likely a consensus-breaking change)
-				if searchingHeight+t.Lock > currentHeight {
-					c.addBidder(t, searchingHeight)
-				}
+		c.rewardSplit = verifiers.NewRewardSplit(*c.p, blk, c.activation)
+		if height == 0 {
+			if err := c.addGenesisConsensusNodes(blk.Txs); err != nil {
+				return err
 			}
+		} else {
+			c.addConsensusNodes(blk.Txs, height+2)
 		}
 
-		searchingHeight++
+		c.removeExpiredConsensusNodes(height)
 	}
 
+	c.p.ResetCommittees()
 	return nil
 }
 
+// consensusData returns the consensus data left by the previous block
+func (c *Chain) consensusData() consensusData {
+	return consensusData{
+		height:  c.prevBlock.Header.Height,
+		hash:    c.prevBlock.Header.Hash,
+		p:       c.p,
+		bidList: *c.bidList,
+		slashed: c.slashed,
+	}
+}
+
+// loadCheckpoint returns the consensus data of the checkpoint, if it was left
+// by a block of the stored chain preceding `currentHeight`. It returns nil
+// otherwise
+func (c *Chain) loadCheckpoint(currentHeight uint64) *consensusData {
+	if c.checkpoint == nil {
+		return nil
+	}
+
+	data, err := c.checkpoint.load()
+	if err != nil {
+		log.WithError(err).Warnln("could not load the consensus data checkpoint")
+		return nil
+	}
+
+	if data == nil || data.height >= currentHeight {
+		return nil
+	}
+
+	blk, err := c.loader.BlockAt(data.height)
+	if err != nil || !bytes.Equal(blk.Header.Hash, data.hash) {
+		log.WithField("height", data.height).Warnln("the consensus data checkpoint does not match the chain")
+		return nil
+	}
+
+	return data
+}
+
+// addGenesisConsensusNodes adds the provisioners and the bids of the genesis
+// block, which is never accepted through AcceptBlock
+func (c *Chain) addGenesisConsensusNodes(txs []transactions.Transaction) error {
+	for _, tx := range txs {
+		switch t := tx.(type) {
+		case *transactions.Stake:
+			amount := t.Outputs[0].EncryptedAmount.BigInt().Uint64()
+			if err := c.addProvisioner(t.PubKeyBLS, amount, 2, t.Lock); err != nil {
+				return fmt.Errorf("unexpected error in adding provisioner following a stake transaction: %v", err)
+			}
+			c.setRewardAddress(t)
+		case *transactions.Bid:
+			// TODO: The commitment to D is turned (in quite awful fashion) from a Point into a Scalar here,
+			// to work with the `zkproof` package. Investigate if we should change this (reserve for testnet v2,
+			// as this is most likely a consensus-breaking change)
+			c.addBidder(t, 0)
+		}
+	}
+
+	return nil
+}
+
+// removeExpiredConsensusNodes removes the provisioners, the bids and the
+// offences expired once the block at the given height is accepted
+func (c *Chain) removeExpiredConsensusNodes(height uint64) {
+	c.removeExpiredProvisioners(height)
+	c.removeExpiredBids(height + 2)
+	c.removeExpiredOffences(height)
+}
+
 // RemoveExpired removes Provisioners which stake expired
 func (c *Chain) removeExpiredProvisioners(round uint64) {
 	for pk, member := range c.p.Members {
@@ -476,6 +636,81 @@ func (c *Chain) removeExpiredProvisioners(round uint64) {
 	}
 }
 
+// unstake brings forward the end of the stakes of a provisioner, which are
+// active at the given height, to the end of the unstake cooldown. The stake
+// outputs are unlocked at the same height by the database, when storing the
+// block (see transactions.UnstakeUnlockHeight)
+func (c *Chain) unstake(pubKeyBLS []byte, height uint64) error {
+	m := c.p.GetMember(pubKeyBLS)
+	if m == nil {
+		return errors.New("unstaking an unknown provisioner")
+	}
+
+	endHeight := height + transactions.UnstakeCooldown
+	for i := range m.Stakes {
+		if m.Stakes[i].StartHeight <= height && m.Stakes[i].EndHeight > endHeight {
+			m.Stakes[i].EndHeight = endHeight
+		}
+	}
+
+	return nil
+}
+
+// slash subtracts the SlashAmount from the stakes of the provisioner who
+// committed the offence proven by a Slash transaction. Each offence is
+// punished only once. Stakes which are left empty are removed, as is the
+// provisioner if none remains.
+func (c *Chain) slash(tx *transactions.Slash) error {
+	e := message.Evidence{}
+	if err := message.UnmarshalEvidence(bytes.NewBuffer(tx.Evidence), &e); err != nil {
+		return err
+	}
+
+	offence := string(e.Offence())
+	if _, punished := c.slashed[offence]; punished {
+		return nil
+	}
+
+	m := c.p.GetMember(e.Offender())
+	if m == nil {
+		return errors.New("slashing an unknown provisioner")
+	}
+	c.slashed[offence] = e.First.Header.Round
+
+	remaining := config.SlashAmount
+	for remaining > 0 {
+		subtracted := m.SubtractFromStake(remaining)
+		if subtracted == 0 {
+			break
+		}
+
+		remaining -= subtracted
+	}
+
+	for i := 0; i < len(m.Stakes); i++ {
+		if m.Stakes[i].Amount == 0 {
+			m.RemoveStake(i)
+			i--
+		}
+	}
+
+	if len(m.Stakes) == 0 {
+		c.removeProvisioner(e.Offender())
+	}
+
+	return nil
+}
+
+// removeExpiredOffences forgets the offences which can not be punished
+// anymore, as they are older than the longest possible stake
+func (c *Chain) removeExpiredOffences(round uint64) {
+	for offence, offenceRound := range c.slashed {
+		if offenceRound+transactions.MaxLockTime < round {
+			delete(c.slashed, offence)
+		}
+	}
+}
+
 // addProvisioner will add a Member to the Provisioners by using the bytes of a BLS public key.
 func (c *Chain) addProvisioner(pubKeyBLS []byte, amount, startHeight, endHeight uint64) error {
 	if len(pubKeyBLS) != 129 {
@@ -504,6 +739,18 @@ func (c *Chain) addProvisioner(pubKeyBLS []byte, amount, startHeight, endHeight
 	return nil
 }
 
+// setRewardAddress sets the reward address of the provisioner of a stake. The
+// address of the latest stake carrying one is used
+func (c *Chain) setRewardAddress(stake *transactions.Stake) {
+	if len(stake.RewardAddress) == 0 {
+		return
+	}
+
+	if m := c.p.GetMember(stake.PubKeyBLS); m != nil {
+		m.RewardAddress = stake.RewardAddress
+	}
+}
+
 // Remove a Member, designated by their BLS public key.
 func (c *Chain) removeProvisioner(pubKeyBLS []byte) bool {
 	delete(c.p.Members, string(pubKeyBLS))
@@ -556,18 +803,27 @@ func (c *Chain) removeExpiredBids(round uint64) {
 }
 
 func (c *Chain) handleCertificateMessage(cMsg certMsg) {
+	// A certificate for the round following the current one means that the
+	// Agreements of the current round went missing, while the rest of the
+	// network moved on
+	if c.intermediateBlock != nil && cMsg.round == c.intermediateBlock.Header.Height+2 {
+		if err := c.catchUp(cMsg); err != nil {
+			log.WithError(err).WithField("round", cMsg.round).Warnln("could not catch up with the network")
+		}
+		return
+	}
+
 	// Set latest certificate
 	c.lastCertificate = cMsg.cert
 
 	// Fetch new intermediate block and corresponding certificate
-	resp, err := c.rpcBus.Call(topics.GetCandidate, rpcbus.NewRequest(*bytes.NewBuffer(cMsg.hash)), 5*time.Second)
+	cm, err := c.fetchCandidate(cMsg.hash)
 	if err != nil {
 		// If the we can't get the block, we will fall
 		// back and catch up later.
 		log.WithError(err).Warnln("could not find winning candidate block")
 		return
 	}
-	cm := resp.(message.Candidate)
 
 	if c.intermediateBlock == nil {
 		// If we're missing the intermediate block, we will also fall
@@ -582,17 +838,99 @@ func (c *Chain) handleCertificateMessage(cMsg certMsg) {
 	}
 
 	// Set new intermediate block
-	c.intermediateBlock = cm.Block
-
-	// Notify mempool
-	msg := message.New(topics.IntermediateBlock, *cm.Block)
-	c.eventBus.Publish(topics.IntermediateBlock, msg)
+	c.setIntermediateBlock(cm.Block)
 
 	go func() {
 		_ = c.sendRoundUpdate()
 	}()
 }
 
+// catchUp brings the Chain to the round following the one certified by
+// `cMsg`, which is one round ahead of the consensus. The winning candidate of
+// the certified round carries the certificate of the current round, and links
+// to the winning candidate of the current round, which in turn carries the
+// certificate of the intermediate block. Both the intermediate block and the
+// block of the current round are accepted, and the certified block becomes
+// the new intermediate block.
+// The consensus is restarted once the intermediate block is accepted, from
+// the current round should the rest fail
+func (c *Chain) catchUp(cMsg certMsg) error {
+	next, err := c.fetchCandidate(cMsg.hash)
+	if err != nil {
+		return err
+	}
+
+	current, err := c.fetchCandidate(next.Block.Header.PrevBlockHash)
+	if err != nil {
+		return err
+	}
+
+	if next.Block.Header.Height != cMsg.round || current.Block.Header.Height+1 != cMsg.round {
+		return errors.New("winning candidates do not match the certified round")
+	}
+
+	if !bytes.Equal(current.Block.Header.PrevBlockHash, c.intermediateBlock.Header.Hash) {
+		return errors.New("winning candidate of the current round does not follow the intermediate block")
+	}
+
+	if err := c.finalizeIntermediateBlock(current.Certificate); err != nil {
+		return err
+	}
+
+	c.lastCertificate = next.Certificate
+	c.setIntermediateBlock(current.Block)
+	defer func() {
+		go func() {
+			_ = c.sendRoundUpdate()
+		}()
+	}()
+
+	// The certified block is verified as a candidate, and its certificate
+	// as the one of a block about to be accepted
+	if err := c.verifyCandidate(next); err != nil {
+		return err
+	}
+
+	hdr := *next.Block.Header
+	hdr.Certificate = cMsg.cert
+	if err := verifiers.CheckBlockCertificate(*c.p, block.Block{Header: &hdr, Txs: next.Block.Txs}, c.activation); err != nil {
+		return err
+	}
+
+	if err := c.finalizeIntermediateBlock(next.Certificate); err != nil {
+		return err
+	}
+
+	c.lastCertificate = cMsg.cert
+	c.setIntermediateBlock(next.Block)
+	log.WithField("round", cMsg.round+1).Infoln("caught up with the network")
+	return nil
+}
+
+// fetchCandidate requests the winning candidate block with the given hash
+func (c *Chain) fetchCandidate(hash []byte) (message.Candidate, error) {
+	resp, err := c.rpcBus.Call(topics.GetCandidate, rpcbus.NewRequest(*bytes.NewBuffer(hash)), 5*time.Second)
+	if err != nil {
+		return message.Candidate{}, err
+	}
+
+	cm := resp.(message.Candidate)
+	if cm.Block == nil {
+		return message.Candidate{}, errors.New("winning candidate block is missing")
+	}
+
+	return cm, nil
+}
+
+// setIntermediateBlock sets the block decided on by consensus, and notifies
+// the mempool
+func (c *Chain) setIntermediateBlock(blk *block.Block) {
+	c.intermediateBlock = blk
+
+	msg := message.New(topics.IntermediateBlock, *blk)
+	c.eventBus.Publish(topics.IntermediateBlock, msg)
+}
+
 func (c *Chain) finalizeIntermediateBlock(cert *block.Certificate) error {
 	c.intermediateBlock.Header.Certificate = cert
 	return c.AcceptBlock(*c.intermediateBlock)
@@ -636,7 +974,7 @@ func (c *Chain) requestRoundResults(round uint64) (*block.Block, *block.Certific
 			// Since this certificate is supposed to be for the
 			// intermediate block, we can just put it on there.
 			cm.Block.Header.Certificate = cm.Certificate
-			if err := verifiers.CheckBlockCertificate(*c.p, *cm.Block); err != nil {
+			if err := verifiers.CheckBlockCertificate(*c.p, *cm.Block, c.activation); err != nil {
 				continue
 			}
 
@@ -658,9 +996,7 @@ func (c *Chain) provideLastCertificate(r rpcbus.Request) {
 		return
 	}
 
-	buf := new(bytes.Buffer)
-	err := message.MarshalCertificate(buf, c.lastCertificate)
-	r.RespChan <- rpcbus.NewResponse(*buf, err)
+	r.RespChan <- rpcbus.NewResponse(*c.lastCertificate, nil)
 }
 
 func (c *Chain) provideRoundResults(r rpcbus.Request) {
@@ -687,7 +1023,8 @@ func (c *Chain) provideRoundResults(r rpcbus.Request) {
 		return
 	}
 
-	if err := message.MarshalCertificate(buf, c.lastCertificate); err != nil {
+	// same encoding as the Candidate messages
+	if err := message.MarshalBlockCertificate(buf, c.lastCertificate, c.intermediateBlock.Header.Version); err != nil {
 		r.RespChan <- rpcbus.NewResponse(bytes.Buffer{}, err)
 		return
 	}
@@ -764,6 +1101,7 @@ func (c *Chain) rebuild(r rpcbus.Request) {
 func (c *Chain) resetState() error {
 	c.p = user.NewProvisioners()
 	c.bidList = &user.BidList{}
+	c.slashed = make(map[string]uint64)
 	intermediateBlock, err := mockFirstIntermediateBlock(c.prevBlock.Header)
 	if err != nil {
 		return err
@@ -2,19 +2,26 @@ package chain
 
 import (
 	"bytes"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/bwesterb/go-ristretto"
 	cfg "github.com/dusk-network/dusk-blockchain/pkg/config"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	walletkey "github.com/dusk-network/dusk-blockchain/pkg/core/data/key"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/transactions"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database/heavy"
 	_ "github.com/dusk-network/dusk-blockchain/pkg/core/database/lite"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/tests/helper"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/verifiers"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer/processing/chainsync"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/encoding"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
@@ -113,7 +120,7 @@ func TestAcceptIntermediate(t *testing.T) {
 	cert = block.EmptyCertificate()
 	cert.Step = 5
 
-	c.handleCertificateMessage(certMsg{blk.Header.Hash, cert})
+	c.handleCertificateMessage(certMsg{blk.Header.Height, blk.Header.Hash, cert})
 
 	// Should have `blk` as intermediate block now
 	assert.True(t, blk.Equals(c.intermediateBlock))
@@ -156,13 +163,122 @@ func TestReturnOnNilIntermediateBlock(t *testing.T) {
 	c.intermediateBlock = nil
 
 	// Now pretend we finalized on it
-	c.handleCertificateMessage(certMsg{blk.Header.Hash, cert})
+	c.handleCertificateMessage(certMsg{blk.Header.Height, blk.Header.Hash, cert})
 
 	// Ensure everything is still the same
 	assert.True(t, currPrevBlock.Equals(&c.prevBlock))
 	assert.Nil(t, c.intermediateBlock)
 }
 
+// This test ensures that the Chain catches up with the network, when
+// receiving the certificate of the round following the current one.
+func TestCatchUp(t *testing.T) {
+	eb, rpc, c := setupChainTest(t, false)
+	intermediateChan := make(chan message.Message, 2)
+	eb.Subscribe(topics.IntermediateBlock, eventbus.NewChanListener(intermediateChan))
+	roundUpdateChan := make(chan message.Message, 1)
+	eb.Subscribe(topics.RoundUpdate, eventbus.NewChanListener(roundUpdateChan))
+
+	p, k := consensus.MockProvisioners(3)
+	c.p = p
+
+	// The consensus is in round 2, with block 1 as intermediate block.
+	// The Agreements of round 2 went missing, and a certificate for round
+	// 3 comes in
+	blk2 := mockCertifiableBlock(t, 2, c.intermediateBlock)
+	blk3 := mockCertifiableBlock(t, 3, blk2)
+	cert2 := mockCertificate(blk2.Header.Hash, 2, k, p)
+	cert3 := mockCertificate(blk3.Header.Hash, 3, k, p)
+
+	provideCandidates(rpc, message.MakeCandidate(blk2, block.EmptyCertificate()), message.MakeCandidate(blk3, cert2))
+	c.handleCertificateMessage(certMsg{3, blk3.Header.Hash, cert3})
+
+	// Blocks 1 and 2 should be accepted, and block 3 should be the
+	// intermediate block
+	assert.Equal(t, uint64(2), c.prevBlock.Header.Height)
+	assert.Equal(t, blk2.Header.Hash, c.prevBlock.Header.Hash)
+	assert.True(t, blk3.Equals(c.intermediateBlock))
+	assert.True(t, cert3.Equals(c.lastCertificate))
+
+	// The mempool should be notified of both intermediate blocks
+	assert.Equal(t, blk2.Header.Hash, (<-intermediateChan).Payload().(block.Block).Header.Hash)
+	assert.Equal(t, blk3.Header.Hash, (<-intermediateChan).Payload().(block.Block).Header.Hash)
+
+	// The consensus should jump to round 4
+	ru := (<-roundUpdateChan).Payload().(consensus.RoundUpdate)
+	assert.Equal(t, uint64(4), ru.Round)
+	assert.Equal(t, blk3.Header.Hash, ru.Hash)
+}
+
+// This test ensures that the Chain does not go past the current round, when
+// the certificate of the following round is invalid.
+func TestCatchUpInvalidCertificate(t *testing.T) {
+	eb, rpc, c := setupChainTest(t, false)
+	roundUpdateChan := make(chan message.Message, 1)
+	eb.Subscribe(topics.RoundUpdate, eventbus.NewChanListener(roundUpdateChan))
+
+	p, k := consensus.MockProvisioners(3)
+	c.p = p
+
+	blk2 := mockCertifiableBlock(t, 2, c.intermediateBlock)
+	blk3 := mockCertifiableBlock(t, 3, blk2)
+	cert2 := mockCertificate(blk2.Header.Hash, 2, k, p)
+	// The certificate is made for another block
+	cert3 := mockCertificate(blk2.Header.Hash, 3, k, p)
+
+	provideCandidates(rpc, message.MakeCandidate(blk2, block.EmptyCertificate()), message.MakeCandidate(blk3, cert2))
+	c.handleCertificateMessage(certMsg{3, blk3.Header.Hash, cert3})
+
+	// Only block 1 should be accepted, and the consensus should resume
+	// from round 3
+	assert.Equal(t, uint64(1), c.prevBlock.Header.Height)
+	assert.True(t, blk2.Equals(c.intermediateBlock))
+	assert.True(t, cert2.Equals(c.lastCertificate))
+
+	ru := (<-roundUpdateChan).Payload().(consensus.RoundUpdate)
+	assert.Equal(t, uint64(3), ru.Round)
+}
+
+// provideCandidates answers the candidate requests with the given candidates
+func provideCandidates(rpc *rpcbus.RPCBus, cms ...message.Candidate) {
+	c := make(chan rpcbus.Request, 1)
+	rpc.Register(topics.GetCandidate, c)
+
+	go func() {
+		for r := range c {
+			params := r.Params.(bytes.Buffer)
+			for _, cm := range cms {
+				if bytes.Equal(cm.Block.Header.Hash, params.Bytes()) {
+					r.RespChan <- rpcbus.NewResponse(cm, nil)
+					break
+				}
+			}
+		}
+	}()
+}
+
+// mockCertifiableBlock creates a block at the given height, following
+// `prevBlock`, which passes the verification of the coinbase
+func mockCertifiableBlock(t *testing.T, height uint64, prevBlock *block.Block) *block.Block {
+	blk := helper.RandomBlock(t, height, 1)
+	// Remove all txs except coinbase, as the helper transactions do not pass verification
+	blk.Txs = blk.Txs[0:1]
+	blk.Header.PrevBlockHash = prevBlock.Header.Hash
+	root, _ := blk.CalculateRoot()
+	blk.Header.TxRoot = root
+	hash, _ := blk.CalculateHash()
+	blk.Header.Hash = hash
+	return blk
+}
+
+// mockCertificate creates a valid certificate for a block, terminated at
+// step 3
+func mockCertificate(hash []byte, round uint64, keys []key.Keys, p *user.Provisioners) *block.Certificate {
+	cert := createMockedCertificate(hash, round, keys, p)
+	cert.Step = 3
+	return cert
+}
+
 //nolint:unused
 func provideCandidate(rpc *rpcbus.RPCBus, cm message.Candidate) {
 	c := make(chan rpcbus.Request, 1)
@@ -335,6 +451,192 @@ func TestRemoveExpiredProvisioners(t *testing.T) {
 	assert.Equal(t, 5, len(c.p.Members))
 }
 
+func TestUnstake(t *testing.T) {
+	_, _, c := setupChainTest(t, false)
+	keys, _ := key.NewRandKeys()
+	if err := c.addProvisioner(keys.BLSPubKeyBytes, 500, 0, 100000); err != nil {
+		t.Fatal(err)
+	}
+
+	tx := helper.RandomUnstakeTx(t, keys)
+	c.addConsensusNodes([]transactions.Transaction{tx}, 100)
+
+	m := c.p.GetMember(keys.BLSPubKeyBytes)
+	assert.Equal(t, uint64(100+transactions.UnstakeCooldown), m.Stakes[0].EndHeight)
+
+	// the provisioner is removed once the cooldown is over
+	c.removeExpiredProvisioners(100 + transactions.UnstakeCooldown + 1)
+	assert.Nil(t, c.p.GetMember(keys.BLSPubKeyBytes))
+}
+
+func TestSlash(t *testing.T) {
+	_, _, c := setupChainTest(t, false)
+	keys, _ := key.NewRandKeys()
+	if err := c.addProvisioner(keys.BLSPubKeyBytes, cfg.SlashAmount+500, 0, 1000); err != nil {
+		t.Fatal(err)
+	}
+
+	hash1, _ := crypto.RandEntropy(32)
+	hash2, _ := crypto.RandEntropy(32)
+	hash3, _ := crypto.RandEntropy(32)
+	tx := helper.RandomSlashTx(t, message.MockEvidence(hash1, hash2, 10, 1, []key.Keys{keys}))
+	c.addConsensusNodes([]transactions.Transaction{tx}, 12)
+
+	stake, err := c.p.GetStake(keys.BLSPubKeyBytes)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(500), stake)
+
+	// another evidence of the same offence is not punished twice
+	tx = helper.RandomSlashTx(t, message.MockEvidence(hash1, hash3, 10, 1, []key.Keys{keys}))
+	c.addConsensusNodes([]transactions.Transaction{tx}, 12)
+	stake, err = c.p.GetStake(keys.BLSPubKeyBytes)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(500), stake)
+
+	// a second offence wipes out the remaining stake, and the provisioner
+	tx = helper.RandomSlashTx(t, message.MockEvidence(hash1, hash2, 11, 1, []key.Keys{keys}))
+	c.addConsensusNodes([]transactions.Transaction{tx}, 13)
+	assert.Nil(t, c.p.GetMember(keys.BLSPubKeyBytes))
+}
+
+// A restarted node must restore the same consensus data as a node which
+// accepted the blocks, including the slashes of stakes expired since
+func TestRestoreConsensusData(t *testing.T) {
+	keys, _ := key.NewRandKeys()
+	hash1, _ := crypto.RandEntropy(32)
+	hash2, _ := crypto.RandEntropy(32)
+
+	// the slash is subtracted from the first stake, which expires at
+	// height 11
+	txs := map[uint64][]transactions.Transaction{
+		1: {mockStakeTx(t, keys, cfg.SlashAmount+500, 10)},
+		2: {mockStakeTx(t, keys, 700, 100)},
+		5: {helper.RandomSlashTx(t, message.MockEvidence(hash1, hash2, 5, 1, []key.Keys{keys}))},
+	}
+
+	loader := NewMockLoader()
+	assert.NoError(t, loader.Append(block.NewBlock()))
+	live := mockConsensusDataChain(loader)
+	assert.NoError(t, live.restoreConsensusData())
+
+	for height := uint64(1); height <= 20; height++ {
+		blk := block.NewBlock()
+		blk.Header.Height = height
+		blk.Txs = txs[height]
+		assert.NoError(t, loader.Append(blk))
+
+		// as in AcceptBlock
+		live.addConsensusNodes(blk.Txs, height+2)
+		live.removeExpiredConsensusNodes(height)
+	}
+
+	m := live.p.GetMember(keys.BLSPubKeyBytes)
+	if !assert.NotNil(t, m) {
+		t.FailNow()
+	}
+	assert.Equal(t, []user.Stake{{Amount: 700, StartHeight: 4, EndHeight: 102}}, m.Stakes)
+
+	restarted := mockConsensusDataChain(loader)
+	assert.NoError(t, restarted.restoreConsensusData())
+	assert.Equal(t, live.p.Members, restarted.p.Members)
+	assert.Equal(t, live.slashed, restarted.slashed)
+	assert.Equal(t, live.bidList, restarted.bidList)
+}
+
+// Test that the consensus data is restored from a checkpoint matching the
+// stored chain, and replayed from genesis otherwise.
+func TestRestoreConsensusDataFromCheckpoint(t *testing.T) {
+	keys, _ := key.NewRandKeys()
+	other, _ := key.NewRandKeys()
+
+	loader := NewMockLoader()
+	for height := uint64(0); height <= 20; height++ {
+		blk := block.NewBlock()
+		blk.Header.Height = height
+		blk.Header.Hash, _ = crypto.RandEntropy(32)
+		if height == 12 {
+			blk.Txs = []transactions.Transaction{mockStakeTx(t, keys, 500, 100)}
+		}
+		assert.NoError(t, loader.Append(blk))
+	}
+
+	dir, err := ioutil.TempDir("", "checkpoint")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// the checkpoint left by block 10 holds a provisioner which is not
+	// staking on the stored chain, as a witness of the checkpoint being used
+	live := mockConsensusDataChain(loader)
+	assert.NoError(t, live.restoreConsensusData())
+	assert.NoError(t, live.addProvisioner(other.BLSPubKeyBytes, 1000, 0, 200))
+	live.prevBlock, _ = loader.BlockAt(10)
+
+	cp := newCheckpoint(filepath.Join(dir, "consensus.checkpoint"))
+	buf := new(bytes.Buffer)
+	assert.NoError(t, marshalConsensusData(buf, live.consensusData()))
+	assert.NoError(t, cp.save(buf.Bytes()))
+
+	restarted := mockConsensusDataChain(loader)
+	restarted.checkpoint = cp
+	assert.NoError(t, restarted.restoreConsensusData())
+	assert.NotNil(t, restarted.p.GetMember(other.BLSPubKeyBytes))
+	assert.NotNil(t, restarted.p.GetMember(keys.BLSPubKeyBytes))
+
+	// a checkpoint left by a block which is not stored is ignored
+	live.prevBlock.Header.Hash, _ = crypto.RandEntropy(32)
+	buf = new(bytes.Buffer)
+	assert.NoError(t, marshalConsensusData(buf, live.consensusData()))
+	assert.NoError(t, cp.save(buf.Bytes()))
+
+	restarted = mockConsensusDataChain(loader)
+	restarted.checkpoint = cp
+	assert.NoError(t, restarted.restoreConsensusData())
+	assert.Nil(t, restarted.p.GetMember(other.BLSPubKeyBytes))
+	assert.NotNil(t, restarted.p.GetMember(keys.BLSPubKeyBytes))
+}
+
+// The coinbase of the block following a Slash rewards the provisioners
+// certified by the previous block, drawn from the set preceding the Slash, as
+// its generator did
+func TestAcceptBlockAfterSlash(t *testing.T) {
+	_, _, c := setupChainTest(t, false)
+	p, keys := consensus.MockProvisioners(4)
+	for _, k := range keys {
+		wallet := walletkey.NewKeyPair(k.BLSPubKeyBytes)
+		p.GetMember(k.BLSPubKeyBytes).RewardAddress = wallet.PublicKey().Bytes()
+	}
+	c.p = p
+	// the rewards are split from the genesis
+	c.activation = block.Activation{}
+
+	// the offender votes for block 2, which carries the Slash
+	offenderPK := p.CreateVotingCommittee(2, 1, len(keys)).MemberKeys()[0]
+	var offender key.Keys
+	others := make([]key.Keys, 0, len(keys)-1)
+	for _, k := range keys {
+		if bytes.Equal(k.BLSPubKeyBytes, offenderPK) {
+			offender = k
+			continue
+		}
+		others = append(others, k)
+	}
+
+	hash1, _ := crypto.RandEntropy(32)
+	hash2, _ := crypto.RandEntropy(32)
+	slash := helper.RandomSlashTx(t, message.MockEvidence(hash1, hash2, 1, 1, []key.Keys{offender}))
+	blk2 := mockRewardedBlock(t, 2, []transactions.Transaction{slash}, verifiers.RewardSplit{}, p, keys)
+
+	// the generator of block 3 draws the certified provisioners from the set
+	// of round 3, which predates the acceptance of block 2
+	split := verifiers.NewRewardSplit(*p, *blk2, c.activation)
+	assert.NoError(t, c.AcceptBlock(*blk2))
+	assert.Nil(t, c.p.GetMember(offenderPK))
+	assert.NotEqual(t, split, verifiers.NewRewardSplit(*c.p, *blk2, c.activation))
+
+	blk3 := mockRewardedBlock(t, 3, nil, split, c.p, others)
+	assert.NoError(t, c.AcceptBlock(*blk3))
+}
+
 func TestRebuildChain(t *testing.T) {
 	eb, rb, c := setupChainTest(t, true)
 	catchClearWalletDatabaseRequest(rb)
@@ -417,6 +719,71 @@ func catchClearWalletDatabaseRequest(rb *rpcbus.RPCBus) {
 	}()
 }
 
+// mockConsensusDataChain creates a Chain holding only the consensus data
+func mockConsensusDataChain(loader Loader) *Chain {
+	return &Chain{
+		p:       user.NewProvisioners(),
+		slashed: make(map[string]uint64),
+		bidList: &user.BidList{},
+		loader:  loader,
+	}
+}
+
+// mockStakeTx creates a stake of the given amount, locked for `lock` blocks
+func mockStakeTx(t *testing.T, keys key.Keys, amount, lock uint64) *transactions.Stake {
+	tx, err := transactions.NewStake(0, 2, 100, lock, keys.BLSPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx.Outputs = helper.RandomOutputs(t, 1)
+	tx.Outputs[0].EncryptedAmount.SetBigInt(big.NewInt(int64(amount)))
+	return tx
+}
+
+// mockRewardedBlock creates a block at `height`, carrying `txs` after a
+// coinbase distributing the rewards according to `split`, and certified by
+// the provisioners
+func mockRewardedBlock(t *testing.T, height uint64, txs []transactions.Transaction, split verifiers.RewardSplit, p *user.Provisioners, keys []key.Keys) *block.Block {
+	rewards := split.Rewards(verifiers.BlockFees(txs))
+	cb := transactions.NewCoinbase(make([]byte, 30), make([]byte, 32), 2)
+	generator := walletkey.NewKeyPair([]byte("generator"))
+	if err := cb.AddReward(*generator.PublicKey(), scalar(rewards.Generator)); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, reward := range rewards.Provisioners {
+		if err := cb.AddProvisionerReward(*reward.Address, scalar(reward.Amount)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	blk := helper.RandomBlock(t, height, 1)
+	blk.Header.Version = block.CurrentVersion
+	blk.Txs = append([]transactions.Transaction{cb}, txs...)
+	root, _ := blk.CalculateRoot()
+	blk.Header.TxRoot = root
+	hash, _ := blk.CalculateHash()
+	blk.Header.Hash = hash
+
+	votes := message.GenVotes(hash, height, 3, keys, p)
+	blk.Header.Certificate = &block.Certificate{
+		StepOneBatchedSig: votes[0].Signature.Compress(),
+		StepTwoBatchedSig: votes[1].Signature.Compress(),
+		Step:              3,
+		StepOneCommittee:  votes[0].BitSet,
+		StepTwoCommittee:  votes[1].BitSet,
+	}
+
+	return blk
+}
+
+func scalar(amount uint64) ristretto.Scalar {
+	var s ristretto.Scalar
+	s.SetBigInt(new(big.Int).SetUint64(amount))
+	return s
+}
+
 // mock a block which can be accepted by the chain.
 // note that this is only valid for height 1, as the certificate
 // is not checked on height 1 (for network bootstrapping)
@@ -0,0 +1,179 @@
+package chain
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/encoding"
+)
+
+// checkpointInterval is the amount of blocks between two checkpoints of the
+// consensus data
+const checkpointInterval = 1000
+
+// consensusData is the state of the consensus, as left by the block at
+// `height`: the provisioners, the bids and the punished offences
+type consensusData struct {
+	height  uint64
+	hash    []byte
+	p       *user.Provisioners
+	bidList user.BidList
+	slashed map[string]uint64
+}
+
+// checkpoint is a flat file keeping a snapshot of the consensus data, so that
+// the chain only replays the blocks following it on restart.
+//
+// The file format is the height and the hash of the block which left the
+// snapshot, followed by the provisioners, the bids and the punished offences.
+type checkpoint struct {
+	path string
+}
+
+func newCheckpoint(path string) *checkpoint {
+	return &checkpoint{path: path}
+}
+
+// save writes the marshaled consensus data into the checkpoint file. The file
+// is first written to a temporary location and then renamed, in order to not
+// corrupt the previous checkpoint in case of a crash.
+func (c *checkpoint) save(data []byte) error {
+	tmpPath := c.path + ".tmp"
+	if err := ioutil.WriteFile(tmpPath, data, 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, c.path)
+}
+
+// load reads the consensus data stored in the checkpoint file. A missing file
+// is not an error, as it simply means there is nothing to restore.
+func (c *checkpoint) load() (*consensusData, error) {
+	data, err := ioutil.ReadFile(c.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return unmarshalConsensusData(bytes.NewBuffer(data))
+}
+
+func marshalConsensusData(buf *bytes.Buffer, d consensusData) error {
+	if err := encoding.WriteUint64LE(buf, d.height); err != nil {
+		return err
+	}
+
+	if err := encoding.WriteVarBytes(buf, d.hash); err != nil {
+		return err
+	}
+
+	if err := user.MarshalProvisioners(buf, d.p); err != nil {
+		return err
+	}
+
+	if err := encoding.WriteVarInt(buf, uint64(len(d.bidList))); err != nil {
+		return err
+	}
+
+	for _, bid := range d.bidList {
+		if err := encoding.Write256(buf, bid.X[:]); err != nil {
+			return err
+		}
+
+		if err := encoding.Write256(buf, bid.M[:]); err != nil {
+			return err
+		}
+
+		if err := encoding.WriteUint64LE(buf, bid.EndHeight); err != nil {
+			return err
+		}
+	}
+
+	if err := encoding.WriteVarInt(buf, uint64(len(d.slashed))); err != nil {
+		return err
+	}
+
+	for offence, round := range d.slashed {
+		if err := encoding.WriteVarBytes(buf, []byte(offence)); err != nil {
+			return err
+		}
+
+		if err := encoding.WriteUint64LE(buf, round); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func unmarshalConsensusData(buf *bytes.Buffer) (*consensusData, error) {
+	d := &consensusData{}
+	if err := encoding.ReadUint64LE(buf, &d.height); err != nil {
+		return nil, err
+	}
+
+	if err := encoding.ReadVarBytes(buf, &d.hash); err != nil {
+		return nil, err
+	}
+
+	p, err := user.UnmarshalProvisioners(buf)
+	if err != nil {
+		return nil, err
+	}
+	d.p = &p
+
+	bids, err := encoding.ReadVarInt(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	// the counts are read from disk, so they are not trusted to preallocate
+	d.bidList = make(user.BidList, 0)
+	for i := uint64(0); i < bids; i++ {
+		var bid user.Bid
+		if err := encoding.Read256(buf, bid.X[:]); err != nil {
+			return nil, err
+		}
+
+		if err := encoding.Read256(buf, bid.M[:]); err != nil {
+			return nil, err
+		}
+
+		if err := encoding.ReadUint64LE(buf, &bid.EndHeight); err != nil {
+			return nil, err
+		}
+
+		d.bidList = append(d.bidList, bid)
+	}
+
+	offences, err := encoding.ReadVarInt(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	d.slashed = make(map[string]uint64)
+	for i := uint64(0); i < offences; i++ {
+		var offence []byte
+		if err := encoding.ReadVarBytes(buf, &offence); err != nil {
+			return nil, err
+		}
+
+		var round uint64
+		if err := encoding.ReadUint64LE(buf, &round); err != nil {
+			return nil, err
+		}
+
+		d.slashed[string(offence)] = round
+	}
+
+	if buf.Len() > 0 {
+		return nil, errors.New("trailing bytes in the checkpoint")
+	}
+
+	return d, nil
+}
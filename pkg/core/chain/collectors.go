@@ -13,8 +13,9 @@ type (
 	}
 
 	certMsg struct {
-		hash []byte
-		cert *block.Certificate
+		round uint64
+		hash  []byte
+		cert  *block.Certificate
 	}
 
 	highestSeenCollector struct {
@@ -33,7 +34,8 @@ func initCertificateCollector(subscriber eventbus.Subscriber) <-chan certMsg {
 func (c *certificateCollector) Collect(m message.Message) error {
 	aggro := m.Payload().(message.Agreement)
 	cert := aggro.GenerateCertificate()
-	c.certificateChan <- certMsg{aggro.State().BlockHash, cert}
+	hdr := aggro.State()
+	c.certificateChan <- certMsg{hdr.Round, hdr.BlockHash, cert}
 	return nil
 }
 
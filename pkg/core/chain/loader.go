@@ -5,8 +5,8 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
-	"github.com/dusk-network/dusk-blockchain/pkg/core/data/transactions"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/verifiers"
 )
@@ -26,6 +26,10 @@ type DBLoader struct {
 
 	// Output prefetched data
 	chainTip *block.Block
+
+	// activation holds the heights from which the format of the blocks
+	// changes on the network
+	activation block.Activation
 }
 
 // CheckBlock will verify whether a block is valid according to the rules of the consensus
@@ -44,7 +48,7 @@ func (l *DBLoader) CheckBlock(prevBlock block.Block, blk block.Block) error {
 		return err
 	}
 
-	if err := verifiers.CheckBlockHeader(prevBlock, blk); err != nil {
+	if err := verifiers.CheckBlockHeader(prevBlock, blk, l.activation); err != nil {
 		return err
 	}
 
@@ -52,21 +56,12 @@ func (l *DBLoader) CheckBlock(prevBlock block.Block, blk block.Block) error {
 		return err
 	}
 
-	for i, merklePayload := range blk.Txs {
-		tx, ok := merklePayload.(transactions.Transaction)
-		if !ok {
-			return errors.New("tx does not implement the transaction interface")
-		}
-		if err := verifiers.CheckTx(l.db, uint64(i), uint64(blk.Header.Timestamp), tx); err != nil {
-			return err
-		}
-	}
-	return nil
+	return verifiers.CheckTxs(l.db, blk.Header.Height, uint64(blk.Header.Timestamp), blk.Txs)
 }
 
 // NewDBLoader returns a Loader which gets the Chain Tip from the DB
 func NewDBLoader(db database.DB, genesis *block.Block) *DBLoader {
-	return &DBLoader{db: db, genesis: genesis}
+	return &DBLoader{db: db, genesis: genesis, activation: config.Activation()}
 }
 
 // Height returns the height of the blockchain stored in the DB
@@ -1,6 +1,7 @@
 package chain
 
 import (
+	"errors"
 	"math/big"
 
 	"github.com/bwesterb/go-ristretto"
@@ -37,12 +38,18 @@ func NewMockLoader() Loader {
 
 // Height returns the height currently known by the Loader
 func (m *MockLoader) Height() (uint64, error) {
-	return uint64(len(m.blockchain)), nil
+	if len(m.blockchain) == 0 {
+		return 0, errors.New("empty chain")
+	}
+	return uint64(len(m.blockchain) - 1), nil
 }
 
 // LoadTip of the chain
 func (m *MockLoader) LoadTip() (*block.Block, error) {
-	return &m.blockchain[len(m.blockchain)], nil
+	if len(m.blockchain) == 0 {
+		return nil, errors.New("empty chain")
+	}
+	return &m.blockchain[len(m.blockchain)-1], nil
 }
 
 // PerformSanityCheck on first N blocks and M last blocks
@@ -68,6 +75,9 @@ func (m *MockLoader) Append(blk *block.Block) error {
 
 // BlockAt the block to the internal blockchain representation
 func (m *MockLoader) BlockAt(index uint64) (block.Block, error) {
+	if index >= uint64(len(m.blockchain)) {
+		return block.Block{}, errors.New("block not found")
+	}
 	return m.blockchain[index], nil
 }
 
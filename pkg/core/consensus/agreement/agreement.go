@@ -23,17 +23,32 @@ type agreement struct {
 	workerAmount int
 	quitChan     chan struct{}
 
+	// catchUp accumulates the Agreements of the next round. A quorum of
+	// them means that the node missed the Agreements of the current round,
+	// and can catch up with the rest of the network
+	catchUp *Accumulator
+
+	// timeouts is shared with the other consensus components. A round
+	// reaching the Agreement is considered successful and shrinks all of
+	// them, while a round taking longer than the Agreement timeout grows it
+	timeouts *consensus.Timeouts
+	timer    consensus.Timer
+	// timeoutChan is signaled when the Agreement timeout expires
+	timeoutChan chan struct{}
+
 	agreementID uint32
 	round       uint64
 }
 
 // newComponent is used by the agreement factory to instantiate the component
-func newComponent(publisher eventbus.Publisher, keys key.Keys, workerAmount int) *agreement {
+func newComponent(publisher eventbus.Publisher, keys key.Keys, workerAmount int, timeouts *consensus.Timeouts) *agreement {
 	return &agreement{
 		publisher:    publisher,
 		keys:         keys,
 		workerAmount: workerAmount,
 		quitChan:     make(chan struct{}, 1),
+		timeouts:     timeouts,
+		timeoutChan:  make(chan struct{}, 1),
 	}
 }
 
@@ -42,8 +57,9 @@ func newComponent(publisher eventbus.Publisher, keys key.Keys, workerAmount int)
 // Implements consensus.Component.
 func (a *agreement) Initialize(eventPlayer consensus.EventPlayer, signer consensus.Signer, r consensus.RoundUpdate) []consensus.TopicListener {
 	a.eventPlayer = eventPlayer
-	a.handler = NewHandler(a.keys, r.P)
+	a.handler = NewHandler(a.keys, r.P, r.Activation)
 	a.accumulator = newAccumulator(a.handler, a.workerAmount)
+	a.catchUp = newAccumulator(a.handler, a.workerAmount)
 	a.round = r.Round
 	agreementSubscriber := consensus.TopicListener{
 		Topic:    topics.Agreement,
@@ -51,6 +67,7 @@ func (a *agreement) Initialize(eventPlayer consensus.EventPlayer, signer consens
 	}
 	a.agreementID = agreementSubscriber.Listener.ID()
 
+	a.timer = a.timeouts.AfterFunc(consensus.Agreement, a.expire)
 	go a.listen()
 	return []consensus.TopicListener{agreementSubscriber}
 }
@@ -68,7 +85,8 @@ func (a *agreement) Filter(hdr header.Header) bool {
 }
 
 // CollectAgreementEvent is the callback to get Events from the Coordinator. It forwards
-// the events to the accumulator until Quorum is reached
+// the events to the accumulator until Quorum is reached. The events of the
+// next round are forwarded to the catch-up accumulator
 func (a *agreement) CollectAgreementEvent(packet consensus.InternalPacket) error {
 	// casting to Agreement
 	aggro := packet.(message.Agreement)
@@ -77,20 +95,59 @@ func (a *agreement) CollectAgreementEvent(packet consensus.InternalPacket) error
 		"agreement": aggro,
 		"id":        a.agreementID,
 	}).Debugln("received event")
+
+	if aggro.State().Round == a.round+1 {
+		a.catchUp.Process(aggro)
+		return nil
+	}
+
 	a.accumulator.Process(aggro)
 	return nil
 }
 
 // Listen for results coming from the accumulator.
 func (a *agreement) listen() {
+	defer a.timer.Stop()
+	for {
+		select {
+		case evs := <-a.accumulator.CollectedVotesChan:
+			lg.WithField("id", a.agreementID).Debugln("quorum reached")
+			a.timeouts.Decrease()
+			// Start a goroutine here to release the lock held by
+			// Coordinator.CollectEvent
+			// Send the Agreement to the Certificate Collector within the Chain
+			go a.sendCertificate(evs[0])
+			return
+		case evs := <-a.catchUp.CollectedVotesChan:
+			// The rest of the network is already past this round. The
+			// certificate of the next round lets the Chain catch up with
+			// it. The timeouts are left untouched, as this round did not
+			// succeed
+			lg.WithFields(log.Fields{
+				"id":    a.agreementID,
+				"round": a.round + 1,
+			}).Infoln("quorum reached on the next round")
+			go a.sendCertificate(evs[0])
+			return
+		case <-a.timeoutChan:
+			// The Agreement timeout does not interrupt the round, which goes
+			// on until a quorum is reached. It only makes the next rounds
+			// more tolerant to a slow network
+			lg.WithFields(log.Fields{
+				"round":   a.round,
+				"timeout": a.timeouts.Get(consensus.Agreement),
+			}).Warnln("agreement not reached within the timeout")
+			a.timeouts.Increase(consensus.Agreement)
+		case <-a.quitChan:
+			return
+		}
+	}
+}
+
+func (a *agreement) expire() {
 	select {
-	case evs := <-a.accumulator.CollectedVotesChan:
-		lg.WithField("id", a.agreementID).Debugln("quorum reached")
-		// Start a goroutine here to release the lock held by
-		// Coordinator.CollectEvent
-		// Send the Agreement to the Certificate Collector within the Chain
-		go a.sendCertificate(evs[0])
-	case <-a.quitChan:
+	case a.timeoutChan <- struct{}{}:
+	default:
 	}
 }
 
@@ -106,6 +163,7 @@ func (a *agreement) sendCertificate(ag message.Agreement) {
 func (a *agreement) Finalize() {
 	a.eventPlayer.Pause(a.agreementID)
 	a.accumulator.Stop()
+	a.catchUp.Stop()
 	select {
 	case a.quitChan <- struct{}{}:
 	default:
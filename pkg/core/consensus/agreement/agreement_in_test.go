@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
 	crypto "github.com/dusk-network/dusk-crypto/hash"
@@ -14,7 +15,7 @@ func TestAccumulatorProcessing(t *testing.T) {
 	nr := 50
 	_, hlp := WireAgreement(nr)
 	hash, _ := crypto.RandEntropy(32)
-	handler := NewHandler(hlp.Keys[0], *hlp.P)
+	handler := NewHandler(hlp.Keys[0], *hlp.P, block.Activation{})
 	accumulator := newAccumulator(handler, 4)
 
 	for i := 0; i < nr; i++ {
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/agreement"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
 	crypto "github.com/dusk-network/dusk-crypto/hash"
@@ -17,7 +18,7 @@ func TestMockValidity(t *testing.T) {
 	nr := 50
 	_, hlp := agreement.WireAgreement(nr)
 	hash, _ := crypto.RandEntropy(32)
-	handler := agreement.NewHandler(hlp.Keys[0], *hlp.P)
+	handler := agreement.NewHandler(hlp.Keys[0], *hlp.P, block.Activation{})
 
 	for i := 0; i < nr; i++ {
 		a := message.MockAgreement(hash, 1, 3, hlp.Keys, hlp.P, i)
@@ -45,6 +46,24 @@ func TestAgreement(t *testing.T) {
 	assert.Equal(t, hash, cert.State().BlockHash)
 }
 
+// Test that a quorum of agreement events for the next round results in the
+// agreement component publishing the certificate of the next round.
+func TestCatchUp(t *testing.T) {
+	nr := 50
+	_, hlp := agreement.WireAgreement(nr)
+	hash, _ := crypto.RandEntropy(32)
+	for i := 0; i < nr; i++ {
+		a := message.MockAgreement(hash, 2, 3, hlp.Keys, hlp.P, i)
+		msg := message.New(topics.Agreement, a)
+		hlp.Bus.Publish(topics.Agreement, msg)
+	}
+
+	res := <-hlp.CertificateChan
+	cert := res.Payload().(message.Agreement)
+	assert.Equal(t, hash, cert.State().BlockHash)
+	assert.Equal(t, uint64(2), cert.State().Round)
+}
+
 // Test that we properly clean up after calling Finalize.
 // TODO: trap eventual errors
 func TestFinalize(t *testing.T) {
@@ -14,11 +14,12 @@ type Factory struct {
 	broker       eventbus.Broker
 	keys         key.Keys
 	workerAmount int
+	timeouts     *consensus.Timeouts
 	Republisher  *republisher.Republisher
 }
 
 // NewFactory instantiates a Factory.
-func NewFactory(broker eventbus.Broker, keys key.Keys) *Factory {
+func NewFactory(broker eventbus.Broker, keys key.Keys, timeouts *consensus.Timeouts) *Factory {
 	amount := cfg.Get().Performance.AccumulatorWorkers
 	r := republisher.New(broker, topics.Agreement)
 
@@ -26,6 +27,7 @@ func NewFactory(broker eventbus.Broker, keys key.Keys) *Factory {
 		broker:       broker,
 		keys:         keys,
 		workerAmount: amount,
+		timeouts:     timeouts,
 		Republisher:  r,
 	}
 }
@@ -33,5 +35,5 @@ func NewFactory(broker eventbus.Broker, keys key.Keys) *Factory {
 // Instantiate an agreement component and return it.
 // Implements consensus.ComponentFactory.
 func (f *Factory) Instantiate() consensus.Component {
-	return newComponent(f.broker, f.keys, f.workerAmount)
+	return newComponent(f.broker, f.keys, f.workerAmount, f.timeouts)
 }
@@ -11,14 +11,34 @@ import (
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/msg"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/sortedset"
 	"github.com/dusk-network/dusk-crypto/bls"
 )
 
-// MaxCommitteeSize represents the maximum size of the committee for an
-// Agreement quorum
-const MaxCommitteeSize = 64
+const (
+	// MaxCommitteeSize represents the maximum size of the committee for an
+	// Agreement quorum, from the activation of the bitsets on (see
+	// block.Activation). The Reduction committees
+	// share the same size, since the block certificates are verified against
+	// them
+	MaxCommitteeSize = 128
+
+	// LegacyMaxCommitteeSize is the maximum size of the committees before
+	// the activation of the bitsets, which the older nodes can represent with
+	// a uint64 bitmap
+	LegacyMaxCommitteeSize = 64
+)
+
+// MaxCommitteeSizeAt returns the maximum size of the committees of a round
+func MaxCommitteeSizeAt(act block.Activation, round uint64) int {
+	if !act.Bitset(round) {
+		return LegacyMaxCommitteeSize
+	}
+
+	return MaxCommitteeSize
+}
 
 // Handler interface is handy for tests
 type Handler interface {
@@ -32,41 +52,43 @@ type Handler interface {
 
 type handler struct {
 	*committee.Handler
+	act block.Activation
 }
 
 // NewHandler returns an initialized handler.
 //nolint:golint
-func NewHandler(keys key.Keys, p user.Provisioners) *handler {
+func NewHandler(keys key.Keys, p user.Provisioners, act block.Activation) *handler {
 	return &handler{
 		Handler: committee.NewHandler(keys, p),
+		act:     act,
 	}
 }
 
 // AmMember checks if we are part of the committee.
 func (a *handler) AmMember(round uint64, step uint8) bool {
-	return a.Handler.AmMember(round, step, MaxCommitteeSize)
+	return a.Handler.AmMember(round, step, MaxCommitteeSizeAt(a.act, round))
 }
 
 // IsMember delegates the committee.Handler to check if a Provisioner is in the
 // committee for a specified round and step
 func (a *handler) IsMember(pubKeyBLS []byte, round uint64, step uint8) bool {
-	return a.Handler.IsMember(pubKeyBLS, round, step, MaxCommitteeSize)
+	return a.Handler.IsMember(pubKeyBLS, round, step, MaxCommitteeSizeAt(a.act, round))
 }
 
 // Committee returns a VotingCommittee for a given round and step
 func (a *handler) Committee(round uint64, step uint8) user.VotingCommittee {
-	return a.Handler.Committee(round, step, MaxCommitteeSize)
+	return a.Handler.Committee(round, step, MaxCommitteeSizeAt(a.act, round))
 }
 
 // VotesFor delegates embedded committee.Handler to accumulate a vote for a
 // given round
 func (a *handler) VotesFor(pubKeyBLS []byte, round uint64, step uint8) int {
-	return a.Handler.VotesFor(pubKeyBLS, round, step, MaxCommitteeSize)
+	return a.Handler.VotesFor(pubKeyBLS, round, step, MaxCommitteeSizeAt(a.act, round))
 }
 
 // Quorum returns the amount of committee members necessary to reach a quorum
 func (a *handler) Quorum(round uint64) int {
-	return int(math.Ceil(float64(a.CommitteeSize(round, MaxCommitteeSize)) * 0.75))
+	return int(math.Ceil(float64(a.CommitteeSize(round, MaxCommitteeSizeAt(a.act, round))) * 0.75))
 }
 
 // Verify checks the signature of the set.
@@ -80,7 +102,7 @@ func (a *handler) Verify(ev message.Agreement) error {
 	for i, votes := range ev.VotesPerStep {
 		step := hdr.Step - 2 + uint8(i)
 		committee := a.Committee(hdr.Round, step)
-		subcommittee := committee.IntersectCluster(votes.BitSet)
+		subcommittee := committee.IntersectClusterBitset(votes.BitSet)
 
 		allVoters += subcommittee.TotalOccurrences()
 		apk, err := ReconstructApk(subcommittee.Set)
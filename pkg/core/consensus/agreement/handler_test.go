@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	crypto "github.com/dusk-network/dusk-crypto/hash"
 	"github.com/stretchr/testify/assert"
@@ -23,7 +24,7 @@ func TestVoteVerification(t *testing.T) {
 	p, keys := consensus.MockProvisioners(3)
 	hash, _ := crypto.RandEntropy(32)
 	ev := message.MockAgreement(hash, 1, 3, keys, p)
-	handler := NewHandler(keys[0], *p)
+	handler := NewHandler(keys[0], *p, block.Activation{})
 	if !assert.NoError(t, handler.Verify(ev)) {
 		assert.FailNow(t, "problems in verification logic")
 	}
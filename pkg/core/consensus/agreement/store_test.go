@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	crypto "github.com/dusk-network/dusk-crypto/hash"
 	"github.com/stretchr/testify/assert"
@@ -18,7 +19,7 @@ func mockAgreement(id string, blockHash []byte, step uint8) message.Agreement {
 	h.Round = uint64(1)
 	h.Step = step
 	h.PubKeyBLS = blsPubKey
-	a := message.NewAgreement(h)
+	a := message.NewAgreement(h, block.Activation{})
 	a.SetSignature([]byte(id))
 	return *a
 }
@@ -3,6 +3,7 @@ package agreement
 import (
 	"sync"
 
+	cfg "github.com/dusk-network/dusk-blockchain/pkg/config"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
@@ -26,7 +27,7 @@ type Helper struct {
 func WireAgreement(nrProvisioners int) (*consensus.Coordinator, *Helper) {
 	eb := eventbus.New()
 	h := NewHelper(eb, nrProvisioners)
-	factory := NewFactory(eb, h.Keys[0])
+	factory := NewFactory(eb, h.Keys[0], consensus.MockTimeouts(cfg.ConsensusTimeOut))
 	coordinator := consensus.Start(eb, h.Keys[0], factory)
 	// starting up the coordinator
 	ru := consensus.MockRoundUpdate(1, h.P, nil)
@@ -44,7 +45,7 @@ func WireAgreement(nrProvisioners int) (*consensus.Coordinator, *Helper) {
 // NewHelper creates a Helper
 func NewHelper(eb *eventbus.EventBus, provisioners int) *Helper {
 	p, keys := consensus.MockProvisioners(provisioners)
-	factory := NewFactory(eb, keys[0])
+	factory := NewFactory(eb, keys[0], consensus.MockTimeouts(cfg.ConsensusTimeOut))
 	a := factory.Instantiate()
 	aggro := a.(*agreement)
 	hlp := &Helper{eb, p, keys, aggro, make(chan message.Message, 1), provisioners}
@@ -9,6 +9,7 @@ import (
 	"github.com/dusk-network/dusk-blockchain/pkg/config"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/verifiers"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
 
@@ -118,18 +119,13 @@ func (bg *Generator) Collect(e consensus.InternalPacket) error {
 	if err != nil {
 		return err
 	}
-	certBuf := resp.(bytes.Buffer)
-
-	cert := block.EmptyCertificate()
-	if err := message.UnmarshalCertificate(&certBuf, cert); err != nil {
-		return err
-	}
+	cert := resp.(block.Certificate)
 
 	// Since the Candidate message goes straight to the Chain, there is
 	// no need to use `SendAuthenticated`, as the header is irrelevant.
 	// Thus, we will instead gossip it directly.
 	lg.Debugln("sending candidate")
-	candidateMsg := message.MakeCandidate(blk, cert)
+	candidateMsg := message.MakeCandidate(blk, &cert)
 	msg = message.New(topics.Candidate, candidateMsg)
 	return bg.signer.Gossip(msg, bg.ID())
 }
@@ -147,9 +143,10 @@ func (bg *Generator) GenerateBlock(round uint64, seed, proof, score, prevBlockHa
 		return nil, err
 	}
 
-	// Construct header
+	// Construct header. The version is set by the height, as the Chain
+	// rejects any other
 	h := &block.Header{
-		Version:       0,
+		Version:       bg.roundInfo.Activation.VersionAt(round),
 		Timestamp:     time.Now().Unix(),
 		Height:        round,
 		PrevBlockHash: prevBlockHash,
@@ -187,11 +184,6 @@ func (bg *Generator) ConstructBlockTxs(proof, score []byte) ([]transactions.Tran
 
 	txs := make([]transactions.Transaction, 0)
 
-	// Construct and append coinbase Tx to reward the generator
-	coinbaseTx := constructCoinbaseTx(bg.genPubKey, proof, score)
-
-	txs = append(txs, coinbaseTx)
-
 	// Retrieve and append the verified transactions from Mempool
 	if bg.rpcBus != nil {
 
@@ -210,13 +202,56 @@ func (bg *Generator) ConstructBlockTxs(proof, score []byte) ([]transactions.Tran
 		txs = append(txs, resp.([]transactions.Transaction)...)
 	}
 
-	// TODO Append Provisioners rewards
+	// Construct and prepend coinbase Tx to reward the generator and the
+	// Provisioners. Before the activation of the reward split, the generator
+	// only gets the fixed reward
+	rewards := verifiers.Rewards{Generator: config.GeneratorReward}
+	if bg.roundInfo.Activation.RewardSplit(bg.roundInfo.Round) {
+		prevBlock, err := bg.fetchPrevBlock()
+		if err != nil {
+			return nil, err
+		}
+
+		rewards = verifiers.BlockRewards(bg.roundInfo.P, *prevBlock, verifiers.BlockFees(txs), bg.roundInfo.Activation)
+	}
+
+	coinbaseTx := constructCoinbaseTx(bg.genPubKey, proof, score, rewards)
 
-	return txs, nil
+	return append([]transactions.Transaction{coinbaseTx}, txs...), nil
 }
 
-// ConstructCoinbaseTx forges the transaction to reward the block generator.
-func constructCoinbaseTx(rewardReceiver *key.PublicKey, proof []byte, score []byte) *transactions.Coinbase {
+// fetchPrevBlock returns the intermediate block, with the certificate which
+// determines the Provisioners to be rewarded. The blocks below height 2 carry
+// no certificate, so they are not fetched
+func (bg *Generator) fetchPrevBlock() (*block.Block, error) {
+	prevBlock := block.NewBlock()
+	if bg.rpcBus == nil || bg.roundInfo.Round < 3 {
+		return prevBlock, nil
+	}
+
+	round := new(bytes.Buffer)
+	if err := encoding.WriteUint64LE(round, bg.roundInfo.Round-1); err != nil {
+		return nil, err
+	}
+
+	resp, err := bg.rpcBus.Call(topics.GetRoundResults, rpcbus.NewRequest(*round), 5*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	buf := resp.(bytes.Buffer)
+
+	cm := message.NewCandidate()
+	if err := message.UnmarshalCandidate(&buf, cm); err != nil {
+		return nil, err
+	}
+
+	cm.Block.Header.Certificate = cm.Certificate
+	return cm.Block, nil
+}
+
+// ConstructCoinbaseTx forges the transaction to reward the block generator
+// and the Provisioners.
+func constructCoinbaseTx(rewardReceiver *key.PublicKey, proof []byte, score []byte, rewards verifiers.Rewards) *transactions.Coinbase {
 	// The rewards for both the Generator and the Provisioners are disclosed.
 	// Provisioner reward addresses do not require obfuscation
 	// The Generator address rewards do.
@@ -234,12 +269,18 @@ func constructCoinbaseTx(rewardReceiver *key.PublicKey, proof []byte, score []by
 
 	// Disclose  reward
 	var reward ristretto.Scalar
-	reward.SetBigInt(big.NewInt(int64(config.GeneratorReward)))
+	reward.SetBigInt(new(big.Int).SetUint64(rewards.Generator))
 
 	// Store the reward in the coinbase tx
 	// TODO: what happens if the maximum amount of outputs has been reached?
 	_ = tx.AddReward(*rewardReceiver, reward)
 
+	for _, p := range rewards.Provisioners {
+		var amount ristretto.Scalar
+		amount.SetBigInt(new(big.Int).SetUint64(p.Amount))
+		_ = tx.AddProvisionerReward(*p.Address, amount)
+	}
+
 	// TODO: Optional here could be to verify if the reward is spendable by the generator wallet.
 	// This could be achieved with a request to dusk-blockchain/pkg/core/data
 	return tx
@@ -51,6 +51,8 @@ type Helper struct {
 
 	ScoreChan, CandidateChan chan message.Message
 	txBatchCount             uint16
+	// intermediate block of the previous round
+	intermediate *block.Block
 }
 
 // NewHelper creates a Helper
@@ -71,6 +73,7 @@ func NewHelper(t *testing.T, eb *eventbus.EventBus, rpcBus *rpcbus.RPCBus, txBat
 		ScoreChan:     make(chan message.Message, 1),
 		CandidateChan: make(chan message.Message, 1),
 		txBatchCount:  txBatchCount,
+		intermediate:  helper.RandomBlock(t, 0, 1),
 	}
 	hlp.createResultChans()
 	hlp.ProvideTransactions(t)
@@ -91,6 +94,8 @@ func (h *Helper) createResultChans() {
 func (h *Helper) Initialize(ru consensus.RoundUpdate) {
 	h.Generator.Initialize(h, h.signer, ru)
 	provideCertificate(h.RBus)
+	h.intermediate.Header.Height = ru.Round - 1
+	provideRoundResults(h.RBus, h.intermediate)
 }
 
 func provideCertificate(rpcBus *rpcbus.RPCBus) {
@@ -99,11 +104,24 @@ func provideCertificate(rpcBus *rpcbus.RPCBus) {
 		panic(err)
 	}
 
+	go func(c chan rpcbus.Request) {
+		r := <-c
+		r.RespChan <- rpcbus.NewResponse(*block.EmptyCertificate(), nil)
+	}(c)
+}
+
+// provideRoundResults answers the request of the intermediate block, whose
+// certificate determines the Provisioners to be rewarded
+func provideRoundResults(rpcBus *rpcbus.RPCBus, intermediate *block.Block) {
+	c := make(chan rpcbus.Request, 1)
+	if err := rpcBus.Register(topics.GetRoundResults, c); err != nil {
+		panic(err)
+	}
+
 	go func(c chan rpcbus.Request) {
 		r := <-c
 		buf := new(bytes.Buffer)
-		cert := block.EmptyCertificate()
-		err := message.MarshalCertificate(buf, cert)
+		err := message.MarshalCandidate(buf, message.MakeCandidate(intermediate, block.EmptyCertificate()))
 		r.RespChan <- rpcbus.NewResponse(*buf, err)
 	}(c)
 }
@@ -0,0 +1,32 @@
+package consensus
+
+import "time"
+
+// Clock is the source of time of the consensus timers. It allows the
+// consensus to run on a simulated clock
+type Clock interface {
+	// Now returns the current time
+	Now() time.Time
+	// AfterFunc calls `f` once `d` has elapsed
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Timer is a timer started by a Clock
+type Timer interface {
+	// Stop prevents the Timer from firing. It returns false if the Timer
+	// already fired or was already stopped
+	Stop() bool
+}
+
+// SystemClock is the Clock of the operating system
+type SystemClock struct{}
+
+// Now implements Clock
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// AfterFunc implements Clock. `f` is called in its own goroutine
+func (SystemClock) AfterFunc(d time.Duration, f func()) Timer {
+	return time.AfterFunc(d, f)
+}
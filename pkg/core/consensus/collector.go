@@ -21,13 +21,15 @@ type (
 	}
 
 	// RoundUpdate carries the data about the new Round, such as the active
-	// Provisioners, the BidList, the Seed and the Hash
+	// Provisioners, the BidList, the Seed and the Hash, together with the
+	// activation heights of the network
 	RoundUpdate struct {
-		Round   uint64
-		P       user.Provisioners
-		BidList user.BidList
-		Seed    []byte
-		Hash    []byte
+		Round      uint64
+		P          user.Provisioners
+		BidList    user.BidList
+		Seed       []byte
+		Hash       []byte
+		Activation block.Activation
 	}
 )
 
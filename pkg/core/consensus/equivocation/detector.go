@@ -0,0 +1,300 @@
+package equivocation
+
+import (
+	"bytes"
+	"encoding/hex"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/agreement"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
+	log "github.com/sirupsen/logrus"
+)
+
+var lg = log.WithField("process", "equivocation")
+
+const (
+	// keptRounds is the amount of rounds, behind the latest accepted block,
+	// for which the votes are remembered
+	keptRounds = 2
+	// maxRoundsAhead is the maximum distance from the latest accepted block
+	// for which votes are tracked. It bounds the memory that a peer flooding
+	// us with far-future votes could make us allocate
+	maxRoundsAhead = 10
+	// maxVotesPerRound bounds the amount of votes remembered for a single
+	// round, across topics and steps
+	maxVotesPerRound = 4096
+	// chanSize is the buffer size of the vote channels. Votes exceeding it are
+	// dropped by the eventbus, which at worst makes the Detector miss an
+	// equivocation
+	chanSize = 100
+)
+
+type (
+	// voteKey identifies the slot a provisioner is allowed to cast a single
+	// vote in
+	voteKey struct {
+		topic  topics.Topic
+		round  uint64
+		step   uint8
+		pubKey string
+	}
+
+	voteSlot struct {
+		vote message.Vote
+		// reported is set once an Evidence has been produced for the slot, so
+		// that further conflicting votes do not generate new Evidence
+		reported bool
+	}
+
+	// Detector watches the Reduction and Agreement messages received from
+	// the network, looking for provisioners that signed two different block
+	// hashes for the same round and step. When it finds one, it stores the
+	// resulting Evidence and gossips it to the network. It also collects
+	// and propagates the Evidence gossiped by other nodes.
+	Detector struct {
+		publisher eventbus.Publisher
+		db        database.DB
+
+		votes      map[voteKey]*voteSlot
+		roundVotes map[uint64]int
+		height     uint64
+
+		// provisioners and act are the ones of the latest round update. The
+		// committees of every tracked round are extracted from them
+		provisioners *user.Provisioners
+		act          block.Activation
+
+		roundChan         <-chan consensus.RoundUpdate
+		reductionChan     <-chan message.Message
+		agreementChan     <-chan message.Message
+		evidenceChan      <-chan message.Message
+		acceptedBlockChan <-chan block.Block
+	}
+)
+
+// NewDetector returns an initialized Detector. It will still need to be
+// started by calling `Listen`.
+func NewDetector(broker eventbus.Broker, db database.DB) *Detector {
+	reductionChan := make(chan message.Message, chanSize)
+	broker.Subscribe(topics.Reduction, eventbus.NewChanListener(reductionChan))
+	agreementChan := make(chan message.Message, chanSize)
+	broker.Subscribe(topics.Agreement, eventbus.NewChanListener(agreementChan))
+	evidenceChan := make(chan message.Message, chanSize)
+	broker.Subscribe(topics.Evidence, eventbus.NewChanListener(evidenceChan))
+	acceptedBlockChan, _ := consensus.InitAcceptedBlockUpdate(broker)
+	roundChan := consensus.InitRoundUpdate(broker)
+
+	var height uint64
+	_ = db.View(func(t database.Transaction) error {
+		var err error
+		height, err = t.FetchCurrentHeight()
+		return err
+	})
+
+	return &Detector{
+		publisher:         broker,
+		db:                db,
+		votes:             make(map[voteKey]*voteSlot),
+		roundVotes:        make(map[uint64]int),
+		height:            height,
+		roundChan:         roundChan,
+		reductionChan:     reductionChan,
+		agreementChan:     agreementChan,
+		evidenceChan:      evidenceChan,
+		acceptedBlockChan: acceptedBlockChan,
+	}
+}
+
+// Listen for incoming votes, Evidence, round updates and accepted blocks.
+// Should be run in a goroutine.
+func (d *Detector) Listen() {
+	for {
+		select {
+		case r := <-d.roundChan:
+			d.updateRound(r)
+		case m := <-d.reductionChan:
+			if r, ok := m.Payload().(message.Reduction); ok {
+				d.collectVote(topics.Reduction, message.Vote{Header: r.State(), Signature: r.SignedHash})
+			}
+		case m := <-d.agreementChan:
+			if a, ok := m.Payload().(message.Agreement); ok {
+				d.collectVote(topics.Agreement, message.Vote{Header: a.State(), Signature: a.SignedVotes()})
+			}
+		case m := <-d.evidenceChan:
+			if e, ok := m.Payload().(message.Evidence); ok {
+				d.collectEvidence(e)
+			}
+		case blk := <-d.acceptedBlockChan:
+			d.prune(blk.Header.Height)
+		}
+	}
+}
+
+// updateRound sets the provisioners against which the committee membership
+// of the votes is checked. They carry the committee cache shared with the
+// consensus components, so committees are not extracted twice.
+func (d *Detector) updateRound(r consensus.RoundUpdate) {
+	d.provisioners = &r.P
+	d.act = r.Activation
+}
+
+// collectVote remembers the first vote cast by a committee member in a given
+// slot, and produces an Evidence as soon as a conflicting vote shows up.
+// Only votes with a valid signature are remembered, so that a forged vote can
+// neither shadow a genuine equivocation nor frame an honest provisioner.
+func (d *Detector) collectVote(topic topics.Topic, v message.Vote) {
+	if !d.isTracked(v.Header) || !d.isMember(v.Header) {
+		return
+	}
+
+	k := newVoteKey(topic, v.Header)
+	slot, ok := d.votes[k]
+	if ok && (slot.reported || bytes.Equal(slot.vote.Header.BlockHash, v.Header.BlockHash)) {
+		return
+	}
+
+	if !ok && d.roundVotes[k.round] >= maxVotesPerRound {
+		lg.WithField("round", k.round).Debugln("too many votes for the round, discarding")
+		return
+	}
+
+	if err := v.Verify(); err != nil {
+		lg.WithError(err).Debugln("discarding vote with invalid signature")
+		return
+	}
+
+	if !ok {
+		d.votes[k] = &voteSlot{vote: v}
+		d.roundVotes[k.round]++
+		return
+	}
+
+	slot.reported = true
+	d.processEvidence(message.NewEvidence(topic, slot.vote, v))
+}
+
+// collectEvidence verifies an Evidence received from the network, and
+// processes it if it is valid
+func (d *Detector) collectEvidence(e message.Evidence) {
+	if err := e.Verify(); err != nil {
+		lg.WithError(err).Debugln("discarding invalid evidence")
+		return
+	}
+
+	d.processEvidence(e)
+}
+
+// processEvidence stores a verified Evidence and gossips it to the network,
+// unless it is already known
+func (d *Detector) processEvidence(e message.Evidence) {
+	id, err := e.ID()
+	if err != nil {
+		lg.WithError(err).Errorln("could not compute the evidence id")
+		return
+	}
+
+	buf := new(bytes.Buffer)
+	if err := message.MarshalEvidence(buf, e); err != nil {
+		lg.WithError(err).Errorln("could not marshal the evidence")
+		return
+	}
+
+	var exists bool
+	err = d.db.Update(func(t database.Transaction) error {
+		var err error
+		exists, err = t.FetchEvidenceExists(id)
+		if err != nil || exists {
+			return err
+		}
+
+		return t.StoreEvidence(id, buf.Bytes())
+	})
+	if err != nil {
+		lg.WithError(err).Errorln("could not store the evidence")
+		return
+	}
+
+	if exists {
+		return
+	}
+
+	lg.WithFields(log.Fields{
+		"offender": hex.EncodeToString(e.Offender()),
+		"round":    e.First.Header.Round,
+		"step":     e.First.Header.Step,
+		"topic":    e.VoteTopic.String(),
+	}).Warnln("equivocation detected")
+
+	if err := d.gossip(e); err != nil {
+		lg.WithError(err).Errorln("could not gossip the evidence")
+	}
+}
+
+func (d *Detector) gossip(e message.Evidence) error {
+	buf, err := message.Marshal(message.New(topics.Evidence, e))
+	if err != nil {
+		return err
+	}
+
+	d.publisher.Publish(topics.Gossip, message.New(topics.Evidence, buf))
+	return nil
+}
+
+// isTracked returns whether the vote falls within the window of rounds
+// the Detector keeps track of
+func (d *Detector) isTracked(h header.Header) bool {
+	if h.Round+keptRounds <= d.height {
+		return false
+	}
+
+	return h.Round <= d.height+maxRoundsAhead
+}
+
+// isMember returns whether the voter belongs to the committee of the round
+// and step of the vote. Votes for rounds ahead of the latest round update are
+// checked against its provisioners, as they are the best guess we have. No
+// vote is a member until the first round update is received.
+func (d *Detector) isMember(h header.Header) bool {
+	if d.provisioners == nil {
+		return false
+	}
+
+	size := d.provisioners.SubsetSizeAt(h.Round)
+	if maxSize := agreement.MaxCommitteeSizeAt(d.act, h.Round); size > maxSize {
+		size = maxSize
+	}
+
+	return d.provisioners.CreateVotingCommittee(h.Round, h.Step, size).IsMember(h.PubKeyBLS)
+}
+
+// prune forgets the votes which fell out of the tracked window after a new
+// block got accepted
+func (d *Detector) prune(height uint64) {
+	d.height = height
+	for k := range d.votes {
+		if k.round+keptRounds <= height {
+			delete(d.votes, k)
+		}
+	}
+
+	for round := range d.roundVotes {
+		if round+keptRounds <= height {
+			delete(d.roundVotes, round)
+		}
+	}
+}
+
+func newVoteKey(topic topics.Topic, h header.Header) voteKey {
+	return voteKey{
+		topic:  topic,
+		round:  h.Round,
+		step:   h.Step,
+		pubKey: string(h.PubKeyBLS),
+	}
+}
@@ -0,0 +1,229 @@
+package equivocation
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database/lite"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
+	crypto "github.com/dusk-network/dusk-crypto/hash"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test that two Reduction votes of the same provisioner for different block
+// hashes produce an Evidence, which gets stored and gossiped only once
+func TestDetectEquivocation(t *testing.T) {
+	bus, db, gossipChan, k := setup(t)
+
+	hash1, _ := crypto.RandEntropy(32)
+	hash2, _ := crypto.RandEntropy(32)
+	hash3, _ := crypto.RandEntropy(32)
+	bus.Publish(topics.Reduction, message.New(topics.Reduction, message.MockReduction(hash1, 1, 1, []key.Keys{k})))
+	bus.Publish(topics.Reduction, message.New(topics.Reduction, message.MockReduction(hash2, 1, 1, []key.Keys{k})))
+
+	e := nextEvidence(t, gossipChan)
+	assert.NoError(t, e.Verify())
+	assert.Equal(t, k.BLSPubKeyBytes, e.Offender())
+	assertStored(t, db, e)
+
+	// a further conflicting vote should not generate other Evidence
+	bus.Publish(topics.Reduction, message.New(topics.Reduction, message.MockReduction(hash3, 1, 1, []key.Keys{k})))
+	assertNoGossip(t, gossipChan)
+}
+
+// Test that honest votes do not produce any Evidence
+func TestNoEquivocation(t *testing.T) {
+	bus, _, gossipChan, k := setup(t)
+
+	hash, _ := crypto.RandEntropy(32)
+	// same vote twice, and the same hash in another step
+	bus.Publish(topics.Reduction, message.New(topics.Reduction, message.MockReduction(hash, 1, 1, []key.Keys{k})))
+	bus.Publish(topics.Reduction, message.New(topics.Reduction, message.MockReduction(hash, 1, 1, []key.Keys{k})))
+	bus.Publish(topics.Reduction, message.New(topics.Reduction, message.MockReduction(hash, 1, 2, []key.Keys{k})))
+	assertNoGossip(t, gossipChan)
+}
+
+// Test that a forged first vote does not shadow a later equivocation
+func TestForgedVote(t *testing.T) {
+	_, db := lite.CreateDBConnection()
+	d := NewDetector(eventbus.New(), db)
+	k := mockRoundUpdate(d)
+
+	hash1, _ := crypto.RandEntropy(32)
+	hash2, _ := crypto.RandEntropy(32)
+	hash3, _ := crypto.RandEntropy(32)
+	r1 := message.MockReduction(hash1, 1, 1, []key.Keys{k})
+	r2 := message.MockReduction(hash2, 1, 1, []key.Keys{k})
+	r3 := message.MockReduction(hash3, 1, 1, []key.Keys{k})
+
+	// a vote for hash1 carrying the signature of hash2
+	forged := message.Vote{Header: r1.State(), Signature: r2.SignedHash}
+	d.collectVote(topics.Reduction, forged)
+	assert.Empty(t, d.votes)
+
+	d.collectVote(topics.Reduction, message.Vote{Header: r2.State(), Signature: r2.SignedHash})
+	assert.Empty(t, fetchEvidence(t, db))
+
+	d.collectVote(topics.Reduction, message.Vote{Header: r3.State(), Signature: r3.SignedHash})
+	assert.Len(t, fetchEvidence(t, db), 1)
+}
+
+// Test that Evidence received from the network is verified, stored and
+// propagated only once
+func TestCollectEvidence(t *testing.T) {
+	bus, db, gossipChan, k := setup(t)
+
+	hash1, _ := crypto.RandEntropy(32)
+	hash2, _ := crypto.RandEntropy(32)
+	e := message.MockEvidence(hash1, hash2, 1, 1, []key.Keys{k})
+
+	bus.Publish(topics.Evidence, message.New(topics.Evidence, e))
+	assert.Equal(t, e, nextEvidence(t, gossipChan))
+	assertStored(t, db, e)
+
+	bus.Publish(topics.Evidence, message.New(topics.Evidence, e))
+	assertNoGossip(t, gossipChan)
+
+	// invalid evidence should be discarded
+	invalid := message.MockEvidence(hash1, hash1, 1, 2, []key.Keys{k})
+	bus.Publish(topics.Evidence, message.New(topics.Evidence, invalid))
+	assertNoGossip(t, gossipChan)
+}
+
+// Test that votes falling behind the accepted chain tip are forgotten
+func TestPrune(t *testing.T) {
+	_, db := lite.CreateDBConnection()
+	d := NewDetector(eventbus.New(), db)
+	k := mockRoundUpdate(d)
+
+	hash, _ := crypto.RandEntropy(32)
+	for round := uint64(1); round <= 5; round++ {
+		r := message.MockReduction(hash, round, 1, []key.Keys{k})
+		d.collectVote(topics.Reduction, message.Vote{Header: r.State(), Signature: r.SignedHash})
+	}
+	assert.Len(t, d.votes, 5)
+
+	// rounds 1 and 2 fall out of the window
+	d.prune(4)
+	assert.Len(t, d.votes, 3)
+
+	// votes for old rounds are not tracked anymore
+	r := message.MockReduction(hash, 1, 1, []key.Keys{k})
+	d.collectVote(topics.Reduction, message.Vote{Header: r.State(), Signature: r.SignedHash})
+	assert.Len(t, d.votes, 3)
+
+	// and neither are votes too far in the future
+	r = message.MockReduction(hash, 4+maxRoundsAhead+1, 1, []key.Keys{k})
+	d.collectVote(topics.Reduction, message.Vote{Header: r.State(), Signature: r.SignedHash})
+	assert.Len(t, d.votes, 3)
+	assert.Len(t, d.roundVotes, 3)
+}
+
+// Test that only the votes of committee members are remembered
+func TestNonMemberVote(t *testing.T) {
+	_, db := lite.CreateDBConnection()
+	d := NewDetector(eventbus.New(), db)
+	k, _ := key.NewRandKeys()
+
+	hash1, _ := crypto.RandEntropy(32)
+	hash2, _ := crypto.RandEntropy(32)
+	r1 := message.MockReduction(hash1, 1, 1, []key.Keys{k})
+	r2 := message.MockReduction(hash2, 1, 1, []key.Keys{k})
+
+	// no provisioner is known before the first round update
+	d.collectVote(topics.Reduction, message.Vote{Header: r1.State(), Signature: r1.SignedHash})
+	assert.Empty(t, d.votes)
+
+	// k is not a provisioner
+	mockRoundUpdate(d)
+	d.collectVote(topics.Reduction, message.Vote{Header: r1.State(), Signature: r1.SignedHash})
+	d.collectVote(topics.Reduction, message.Vote{Header: r2.State(), Signature: r2.SignedHash})
+	assert.Empty(t, d.votes)
+	assert.Empty(t, fetchEvidence(t, db))
+}
+
+// Test that the votes remembered for a single round are capped
+func TestMaxVotesPerRound(t *testing.T) {
+	_, db := lite.CreateDBConnection()
+	d := NewDetector(eventbus.New(), db)
+	k := mockRoundUpdate(d)
+	d.roundVotes[1] = maxVotesPerRound
+
+	hash, _ := crypto.RandEntropy(32)
+	r := message.MockReduction(hash, 1, 1, []key.Keys{k})
+	d.collectVote(topics.Reduction, message.Vote{Header: r.State(), Signature: r.SignedHash})
+	assert.Empty(t, d.votes)
+
+	// other rounds are not affected
+	r = message.MockReduction(hash, 2, 1, []key.Keys{k})
+	d.collectVote(topics.Reduction, message.Vote{Header: r.State(), Signature: r.SignedHash})
+	assert.Len(t, d.votes, 1)
+}
+
+func setup(t *testing.T) (*eventbus.EventBus, database.DB, chan message.Message, key.Keys) {
+	bus := eventbus.New()
+	gossipChan := make(chan message.Message, 10)
+	bus.Subscribe(topics.Gossip, eventbus.NewChanListener(gossipChan))
+
+	_, db := lite.CreateDBConnection()
+	d := NewDetector(bus, db)
+	k := mockRoundUpdate(d)
+	go d.Listen()
+	return bus, db, gossipChan, k
+}
+
+// mockRoundUpdate sets a single provisioner on the Detector, which is
+// therefore the only member of every committee, and returns its keys
+func mockRoundUpdate(d *Detector) key.Keys {
+	p, keys := consensus.MockProvisioners(1)
+	d.updateRound(consensus.RoundUpdate{Round: 1, P: *p})
+	return keys[0]
+}
+
+func nextEvidence(t *testing.T, gossipChan chan message.Message) message.Evidence {
+	select {
+	case m := <-gossipChan:
+		buf := m.Payload().(bytes.Buffer)
+		decoded, err := message.Unmarshal(&buf, block.Activation{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return decoded.Payload().(message.Evidence)
+	case <-time.After(time.Second):
+		t.Fatal("evidence was not gossiped")
+	}
+
+	return message.Evidence{}
+}
+
+func assertNoGossip(t *testing.T, gossipChan chan message.Message) {
+	select {
+	case <-gossipChan:
+		t.Fatal("unexpected gossip")
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func assertStored(t *testing.T, db database.DB, e message.Evidence) {
+	buf := new(bytes.Buffer)
+	assert.NoError(t, message.MarshalEvidence(buf, e))
+	assert.Contains(t, fetchEvidence(t, db), buf.Bytes())
+}
+
+func fetchEvidence(t *testing.T, db database.DB) [][]byte {
+	var evidence [][]byte
+	assert.NoError(t, db.View(func(tx database.Transaction) error {
+		var err error
+		evidence, err = tx.FetchEvidence()
+		return err
+	}))
+	return evidence
+}
@@ -1,8 +1,16 @@
 package factory
 
 import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
 	"time"
 
+	cfg "github.com/dusk-network/dusk-blockchain/pkg/config"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/agreement"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/candidate"
@@ -12,10 +20,14 @@ import (
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/reduction/firststep"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/reduction/secondstep"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/selection"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/signer"
 	pkey "github.com/dusk-network/dusk-blockchain/pkg/core/data/key"
+	remotesigner "github.com/dusk-network/dusk-blockchain/pkg/rpc/signer"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
 	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 // ConsensusFactory is responsible for initializing the consensus processes
@@ -28,18 +40,20 @@ type ConsensusFactory struct {
 	rpcBus   *rpcbus.RPCBus
 
 	walletPubKey *pkey.PublicKey
+	// Keys only hold the BLS public key when signing through a remote signer
 	key.Keys
-	timerLength time.Duration
+	timeouts *consensus.Timeouts
 }
 
-// New returns an initialized ConsensusFactory.
+// New returns an initialized ConsensusFactory. The step timeouts start at
+// `timerLength` and adapt within the bounds set in the configuration.
 func New(eventBus *eventbus.EventBus, rpcBus *rpcbus.RPCBus, timerLength time.Duration, walletPubKey *pkey.PublicKey, keys key.Keys) *ConsensusFactory {
 	return &ConsensusFactory{
 		eventBus:     eventBus,
 		rpcBus:       rpcBus,
 		walletPubKey: walletPubKey,
 		Keys:         keys,
-		timerLength:  timerLength,
+		timeouts:     consensus.NewTimeouts(timerLength, minTimeout(), maxTimeout()),
 	}
 }
 
@@ -47,14 +61,125 @@ func New(eventBus *eventbus.EventBus, rpcBus *rpcbus.RPCBus, timerLength time.Du
 // start the consensus components.
 func (c *ConsensusFactory) StartConsensus() {
 	log.WithField("process", "factory").Info("Starting consensus")
+	blsSigner, err := c.newSigner()
+	if err != nil {
+		log.WithField("process", "factory").WithError(err).Panic("could not set up the consensus signer")
+	}
+
 	gen := generation.NewFactory()
 	cgen := candidate.NewFactory(c.eventBus, c.rpcBus, c.walletPubKey)
-	sgen := score.NewFactory(c.eventBus, c.Keys, nil)
-	sel := selection.NewFactory(c.eventBus, c.timerLength)
-	redFirstStep := firststep.NewFactory(c.eventBus, c.rpcBus, c.Keys, c.timerLength)
-	redSecondStep := secondstep.NewFactory(c.eventBus, c.rpcBus, c.Keys, c.timerLength)
-	agr := agreement.NewFactory(c.eventBus, c.Keys)
+	sgen := score.NewFactory(c.eventBus, blsSigner, nil)
+	sel := selection.NewFactory(c.eventBus, c.timeouts)
+	redFirstStep := firststep.NewFactory(c.eventBus, c.rpcBus, c.Keys, c.timeouts)
+	redSecondStep := secondstep.NewFactory(c.eventBus, c.rpcBus, c.Keys, c.timeouts)
+	agr := agreement.NewFactory(c.eventBus, c.Keys, c.timeouts)
+
+	if err := consensus.ServeTimeouts(c.rpcBus, c.timeouts); err != nil {
+		log.WithField("process", "factory").WithError(err).Warnln("could not serve the consensus timeouts")
+	}
+
+	var wal *consensus.WAL
+	if walFile := cfg.Get().Consensus.WALFile; len(walFile) > 0 {
+		if wal, err = consensus.OpenWAL(walFile); err != nil {
+			// signing without the WAL could lead to double signing
+			log.WithField("process", "factory").WithError(err).Panic("could not open the consensus WAL")
+		}
+	}
+
+	coordinator := consensus.StartWithSigner(c.eventBus, blsSigner, wal, cgen, sgen, sel, redFirstStep, redSecondStep, agr, gen)
 
-	consensus.Start(c.eventBus, c.Keys, cgen, sgen, sel, redFirstStep, redSecondStep, agr, gen)
+	if traceFile := cfg.Get().Consensus.TraceFile; len(traceFile) > 0 {
+		trace, err := consensus.CreateTrace(traceFile)
+		if err != nil {
+			log.WithField("process", "factory").WithError(err).Warnln("could not create the consensus trace")
+		} else {
+			coordinator.Record(trace, c.timeouts)
+			log.WithField("process", "factory").WithField("file", traceFile).Info("Recording the consensus events")
+		}
+	}
 	log.WithField("process", "factory").Info("Consensus Started")
 }
+
+func minTimeout() time.Duration {
+	if ms := cfg.Get().Consensus.MinTimeout; ms > 0 {
+		return time.Duration(ms) * time.Millisecond
+	}
+	return cfg.ConsensusMinTimeOut
+}
+
+func maxTimeout() time.Duration {
+	if ms := cfg.Get().Consensus.MaxTimeout; ms > 0 {
+		return time.Duration(ms) * time.Millisecond
+	}
+	return cfg.ConsensusMaxTimeOut
+}
+
+// newSigner returns the signer producing the BLS signatures of the node. If a
+// remote signer is configured, the node only holds the BLS public key and the
+// remote signer must hold the matching secret key
+func (c *ConsensusFactory) newSigner() (signer.Signer, error) {
+	conf := cfg.Get().Consensus
+	if len(conf.RemoteSigner) == 0 {
+		return signer.NewLocal(c.Keys), nil
+	}
+
+	opt := grpc.WithInsecure()
+	if len(conf.RemoteSignerCert) > 0 {
+		tlsConfig, err := remoteSignerTLSConfig(conf.RemoteSignerCert, conf.RemoteSignerClientCert, conf.RemoteSignerClientKey)
+		if err != nil {
+			return nil, err
+		}
+		opt = grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.RemoteSignerTimeOut)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, conf.RemoteSigner, opt, grpc.WithBlock())
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := remotesigner.NewClient(conn, cfg.RemoteSignerTimeOut)
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	if !bytes.Equal(client.PubKeyBLS(), c.BLSPubKeyBytes) {
+		_ = conn.Close()
+		return nil, errors.New("the remote signer key does not match the wallet consensus key")
+	}
+
+	log.WithField("process", "factory").WithField("address", conf.RemoteSigner).Info("Signing through the remote signer")
+	return client, nil
+}
+
+// remoteSignerTLSConfig returns the TLS configuration trusting the remote
+// signer certificate, and authenticating the node with its client certificate
+func remoteSignerTLSConfig(certFile, clientCertFile, clientKeyFile string) (*tls.Config, error) {
+	if len(clientCertFile) == 0 || len(clientKeyFile) == 0 {
+		return nil, errors.New("the remote signer requires a client certificate and key")
+	}
+
+	clientCert, err := tls.LoadX509KeyPair(clientCertFile, clientKeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	pem, err := ioutil.ReadFile(certFile) //nolint
+	if err != nil {
+		return nil, err
+	}
+
+	rootCAs := x509.NewCertPool()
+	if !rootCAs.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificate found in %s", certFile)
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{clientCert},
+		RootCAs:      rootCAs,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
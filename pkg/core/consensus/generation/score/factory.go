@@ -3,7 +3,7 @@ package score
 import (
 	"github.com/bwesterb/go-ristretto"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
-	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/signer"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database/heavy"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
@@ -12,20 +12,20 @@ import (
 
 // Factory creates a score.Generator.
 type Factory struct {
-	Bus eventbus.Broker
-	db  database.DB
-	key.Keys
+	Bus       eventbus.Broker
+	db        database.DB
+	blsSigner signer.Signer
 }
 
-// NewFactory instantiates a Factory.
-func NewFactory(broker eventbus.Broker, consensusKeys key.Keys, db database.DB) *Factory {
+// NewFactory instantiates a Factory. The seeds are signed through `blsSigner`.
+func NewFactory(broker eventbus.Broker, blsSigner signer.Signer, db database.DB) *Factory {
 	if db == nil {
 		_, db = heavy.CreateDBConnection()
 	}
 	return &Factory{
-		Bus:  broker,
-		db:   db,
-		Keys: consensusKeys,
+		Bus:       broker,
+		db:        db,
+		blsSigner: blsSigner,
 	}
 }
 
@@ -54,5 +54,5 @@ func (f *Factory) Instantiate() consensus.Component {
 		log.WithField("process", "score generator factory").WithError(err).Errorln("could not unmarshal K bytes into a scalar")
 	}
 
-	return NewComponent(f.Bus, f.Keys, dScalar, kScalar)
+	return NewComponent(f.Bus, f.blsSigner, dScalar, kScalar)
 }
@@ -7,12 +7,11 @@ import (
 	"github.com/bwesterb/go-ristretto"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
-	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/signer"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
-	"github.com/dusk-network/dusk-crypto/bls"
 	zkproof "github.com/dusk-network/dusk-zkproof"
 	log "github.com/sirupsen/logrus"
 )
@@ -23,10 +22,10 @@ var emptyHash [32]byte
 var lg = log.WithField("process", "score generator")
 
 // NewComponent returns an uninitialized Generator.
-func NewComponent(publisher eventbus.Publisher, consensusKeys key.Keys, d, k ristretto.Scalar) *Generator {
+func NewComponent(publisher eventbus.Publisher, blsSigner signer.Signer, d, k ristretto.Scalar) *Generator {
 	return &Generator{
 		publisher: publisher,
-		Keys:      consensusKeys,
+		blsSigner: blsSigner,
 		k:         k,
 		d:         d,
 		threshold: consensus.NewThreshold(),
@@ -41,7 +40,7 @@ type Generator struct {
 	roundInfo consensus.RoundUpdate
 	seed      []byte
 	d, k      ristretto.Scalar
-	key.Keys
+	blsSigner signer.Signer
 	lock      sync.RWMutex
 	threshold *consensus.Threshold
 
@@ -85,7 +84,7 @@ func (sf scoreFactory) Create(sender []byte, round uint64, step uint8) consensus
 func (g *Generator) Initialize(eventPlayer consensus.EventPlayer, signer consensus.Signer, ru consensus.RoundUpdate) []consensus.TopicListener {
 	g.signer = signer
 	g.roundInfo = ru
-	signedSeed, err := g.sign(ru.Seed)
+	signedSeed, err := g.blsSigner.SignSeed(ru.Round, ru.Seed)
 	if err != nil {
 		lg.WithField("category", "BUG").WithError(err).Errorln("could not sign seed")
 		return nil
@@ -197,12 +196,3 @@ func inBidList(d, k ristretto.Scalar, bidList user.BidList) bool {
 	copy(bid.X[:], x.Bytes())
 	return bidList.Contains(bid)
 }
-
-func (g *Generator) sign(seed []byte) ([]byte, error) {
-	signedSeed, err := bls.Sign(g.Keys.BLSSecretKey, g.Keys.BLSPubKey, seed)
-	if err != nil {
-		return nil, err
-	}
-	compSeed := signedSeed.Compress()
-	return compSeed, nil
-}
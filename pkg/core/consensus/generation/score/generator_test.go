@@ -6,6 +6,7 @@ import (
 	"github.com/bwesterb/go-ristretto"
 	"github.com/dusk-network/dusk-blockchain/pkg/config"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/signer"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database/lite"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/tests/helper"
@@ -18,7 +19,7 @@ func TestCorrectBidValues(t *testing.T) {
 	keys, _ := key.NewRandKeys()
 	_, db := lite.CreateDBConnection()
 
-	f := NewFactory(eb, keys, db)
+	f := NewFactory(eb, signer.NewLocal(keys), db)
 
 	genesis := config.DecodeGenesis()
 	assert.NoError(t, db.Update(func(t database.Transaction) error {
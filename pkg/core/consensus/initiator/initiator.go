@@ -5,6 +5,7 @@ import (
 
 	cfg "github.com/dusk-network/dusk-blockchain/pkg/config"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/factory"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/transactions"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/wallet"
@@ -30,7 +31,16 @@ func LaunchConsensus(eventBroker *eventbus.EventBus, rpcBus *rpcbus.RPCBus, w *w
 func startProvisioner(eventBroker *eventbus.EventBus, rpcBus *rpcbus.RPCBus, w *wallet.Wallet) error {
 	// Setting up the consensus factory
 	pubKey := w.PublicKey()
-	f := factory.New(eventBroker, rpcBus, cfg.ConsensusTimeOut, &pubKey, w.Keys())
+	keys := w.Keys()
+	if len(cfg.Get().Consensus.RemoteSigner) > 0 {
+		// the remote signer holds the BLS secret key
+		var err error
+		if keys, err = key.NewPublicKeys(keys.BLSPubKeyBytes); err != nil {
+			return err
+		}
+	}
+
+	f := factory.New(eventBroker, rpcBus, cfg.ConsensusTimeOut, &pubKey, keys)
 	f.StartConsensus()
 
 	// If we are on genesis, we should kickstart the consensus
@@ -44,6 +44,21 @@ func NewKeysFromReader(r io.Reader) (Keys, error) {
 	}, nil
 }
 
+// NewPublicKeys returns the Keys holding only the BLS public key marshaled in
+// `pubKeyBytes`. They can not sign, and are used by the nodes delegating
+// the signatures to a remote signer
+func NewPublicKeys(pubKeyBytes []byte) (Keys, error) {
+	blsPub, err := bls.UnmarshalPk(pubKeyBytes)
+	if err != nil {
+		return Keys{}, err
+	}
+
+	return Keys{
+		BLSPubKey:      blsPub,
+		BLSPubKeyBytes: pubKeyBytes,
+	}, nil
+}
+
 // NewKeysFromBytes gets an array of bytes as seed for the pseudo-random generation of Keys
 func NewKeysFromBytes(seed []byte) (Keys, error) {
 
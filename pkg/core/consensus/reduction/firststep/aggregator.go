@@ -97,7 +97,7 @@ func (a *aggregator) collectVote(ev message.Reduction) error {
 
 func (a *aggregator) addBitSet(sv *message.StepVotes, cluster sortedset.Cluster, round uint64, step uint8) {
 	committee := a.handler.Committee(round, step)
-	sv.BitSet = committee.Bits(cluster.Set)
+	sv.BitSet = committee.BitsetOf(cluster.Set)
 }
 
 func verifyCandidateBlock(rpcBus *rpcbus.RPCBus, blockHash []byte) error {
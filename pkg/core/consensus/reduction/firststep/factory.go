@@ -1,8 +1,6 @@
 package firststep
 
 import (
-	"time"
-
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/reduction"
@@ -17,18 +15,18 @@ type Factory struct {
 	Bus         eventbus.Broker
 	RBus        *rpcbus.RPCBus
 	Keys        key.Keys
-	timeout     time.Duration
+	timeouts    *consensus.Timeouts
 	Republisher *republisher.Republisher
 }
 
 // NewFactory instantiates a Factory
-func NewFactory(broker eventbus.Broker, rpcBus *rpcbus.RPCBus, keys key.Keys, timeout time.Duration) *Factory {
+func NewFactory(broker eventbus.Broker, rpcBus *rpcbus.RPCBus, keys key.Keys, timeouts *consensus.Timeouts) *Factory {
 	r := republisher.New(broker, topics.Reduction)
 	return &Factory{
 		broker,
 		rpcBus,
 		keys,
-		timeout,
+		timeouts,
 		r,
 	}
 }
@@ -36,12 +34,12 @@ func NewFactory(broker eventbus.Broker, rpcBus *rpcbus.RPCBus, keys key.Keys, ti
 // Instantiate a first step reduction Component
 // Implements consensus.ComponentFactory.
 func (f *Factory) Instantiate() consensus.Component {
-	return NewComponent(f.Bus, f.RBus, f.Keys, f.timeout)
+	return NewComponent(f.Bus, f.RBus, f.Keys, f.timeouts)
 }
 
 // CreateReducer is a reduction.FactoryFunc
-func CreateReducer(broker *eventbus.EventBus, rpcBus *rpcbus.RPCBus, keys key.Keys, timeout time.Duration) reduction.Reducer {
-	f := NewFactory(broker, rpcBus, keys, timeout)
+func CreateReducer(broker *eventbus.EventBus, rpcBus *rpcbus.RPCBus, keys key.Keys, timeouts *consensus.Timeouts) reduction.Reducer {
+	f := NewFactory(broker, rpcBus, keys, timeouts)
 	component := f.Instantiate()
 	return component.(*Reducer)
 }
@@ -2,7 +2,6 @@ package firststep
 
 import (
 	"encoding/hex"
-	"time"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
@@ -31,18 +30,18 @@ type Reducer struct {
 
 	handler    *reduction.Handler
 	aggregator *aggregator
-	timeOut    time.Duration
+	timeouts   *consensus.Timeouts
 	Timer      *reduction.Timer
 	round      uint64
 }
 
 // NewComponent returns an uninitialized reduction component.
-func NewComponent(broker eventbus.Broker, rpcBus *rpcbus.RPCBus, keys key.Keys, timeOut time.Duration) reduction.Reducer {
+func NewComponent(broker eventbus.Broker, rpcBus *rpcbus.RPCBus, keys key.Keys, timeouts *consensus.Timeouts) reduction.Reducer {
 	return &Reducer{
-		broker:  broker,
-		rpcBus:  rpcBus,
-		keys:    keys,
-		timeOut: timeOut,
+		broker:   broker,
+		rpcBus:   rpcBus,
+		keys:     keys,
+		timeouts: timeouts,
 	}
 }
 
@@ -52,7 +51,7 @@ func NewComponent(broker eventbus.Broker, rpcBus *rpcbus.RPCBus, keys key.Keys,
 func (r *Reducer) Initialize(eventPlayer consensus.EventPlayer, signer consensus.Signer, ru consensus.RoundUpdate) []consensus.TopicListener {
 	r.eventPlayer = eventPlayer
 	r.signer = signer
-	r.handler = reduction.NewHandler(r.keys, ru.P)
+	r.handler = reduction.NewHandler(r.keys, ru.P, ru.Activation)
 	r.Timer = reduction.NewTimer(r.Halt)
 	r.round = ru.Round
 
@@ -111,7 +110,7 @@ func (r *Reducer) Filter(hdr header.Header) bool {
 }
 
 func (r *Reducer) startReduction() {
-	r.Timer.Start(r.timeOut)
+	r.Timer.Start(r.timeouts, consensus.FirstReduction)
 	r.aggregator = newAggregator(r.Halt, r.handler, r.rpcBus)
 }
 
@@ -169,7 +168,7 @@ func (r *Reducer) Halt(hash []byte, svs ...*message.StepVotes) {
 			hash: hash,
 		}
 		// Increase timeout if we did not have a good result
-		r.timeOut = r.timeOut * 2
+		r.timeouts.Increase(consensus.FirstReduction)
 		svm = r.signer.Compose(factory).(message.StepVotesMsg)
 	}
 
@@ -4,47 +4,56 @@ import (
 	"bytes"
 	"math"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/agreement"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/committee"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/msg"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 )
 
-const maxCommitteeSize = 64
-
 type (
 	// Handler is responsible for performing operations that need to know
 	// about specific event fields.
 	Handler struct {
 		*committee.Handler
+		act block.Activation
 	}
 )
 
 // NewHandler will return a Handler, injected with the passed committee
 // and an unmarshaller which uses the injected validation function.
-func NewHandler(keys key.Keys, p user.Provisioners) *Handler {
+func NewHandler(keys key.Keys, p user.Provisioners, act block.Activation) *Handler {
 	return &Handler{
 		Handler: committee.NewHandler(keys, p),
+		act:     act,
 	}
 }
 
+// maxCommitteeSize returns the maximum size of the committees of a round. It
+// needs to match the Agreement one, as the block certificates are verified
+// against committees of that size
+func (b *Handler) maxCommitteeSize(round uint64) int {
+	return agreement.MaxCommitteeSizeAt(b.act, round)
+}
+
 // AmMember checks if we are part of the committee.
 func (b *Handler) AmMember(round uint64, step uint8) bool {
-	return b.Handler.AmMember(round, step, maxCommitteeSize)
+	return b.Handler.AmMember(round, step, b.maxCommitteeSize(round))
 }
 
 // IsMember delegates the committee.Handler to check if a BLS public key belongs
 // to a committee for the specified round and step
 func (b *Handler) IsMember(pubKeyBLS []byte, round uint64, step uint8) bool {
-	return b.Handler.IsMember(pubKeyBLS, round, step, maxCommitteeSize)
+	return b.Handler.IsMember(pubKeyBLS, round, step, b.maxCommitteeSize(round))
 }
 
 // VotesFor delegates the committee.Handler to accumulate Votes for the
 // specified BLS public key identifying a Provisioner
 func (b *Handler) VotesFor(pubKeyBLS []byte, round uint64, step uint8) int {
-	return b.Handler.VotesFor(pubKeyBLS, round, step, maxCommitteeSize)
+	return b.Handler.VotesFor(pubKeyBLS, round, step, b.maxCommitteeSize(round))
 }
 
 // VerifySignature verifies the BLS signature of the Reduction event. Since the payload is nil, verifying the signature equates to verifying solely the Header
@@ -65,10 +74,10 @@ func (b *Handler) VerifySignature(red message.Reduction) error {
 
 // Quorum returns the amount of committee votes to reach a quorum
 func (b *Handler) Quorum(round uint64) int {
-	return int(math.Ceil(float64(b.CommitteeSize(round, maxCommitteeSize)) * 0.75))
+	return int(math.Ceil(float64(b.CommitteeSize(round, b.maxCommitteeSize(round))) * 0.75))
 }
 
 // Committee returns a VotingCommittee for a given round and step.
 func (b *Handler) Committee(round uint64, step uint8) user.VotingCommittee {
-	return b.Handler.Committee(round, step, maxCommitteeSize)
+	return b.Handler.Committee(round, step, b.maxCommitteeSize(round))
 }
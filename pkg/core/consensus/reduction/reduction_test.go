@@ -112,8 +112,9 @@ func collectEvents(t *testing.T, c *consensus.Coordinator, evs []message.Reducti
 
 func wireReduction(t *testing.T, bus *eventbus.EventBus, rpcBus *rpcbus.RPCBus) (*consensus.Coordinator, *firststep.Helper) {
 	hlp := firststep.NewHelper(bus, rpcBus, 10, 1*time.Second)
-	f1 := firststep.NewFactory(bus, rpcBus, hlp.Keys[0], 1*time.Second)
-	f2 := secondstep.NewFactory(bus, rpcBus, hlp.Keys[0], 1*time.Second)
+	timeouts := consensus.MockTimeouts(1 * time.Second)
+	f1 := firststep.NewFactory(bus, rpcBus, hlp.Keys[0], timeouts)
+	f2 := secondstep.NewFactory(bus, rpcBus, hlp.Keys[0], timeouts)
 	c := consensus.Start(bus, hlp.Keys[0], f1, f2)
 	// Starting the coordinator
 	ru := consensus.MockRoundUpdate(1, hlp.P, nil)
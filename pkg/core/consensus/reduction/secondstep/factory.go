@@ -1,8 +1,6 @@
 package secondstep
 
 import (
-	"time"
-
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/reduction"
@@ -12,31 +10,31 @@ import (
 
 // Factory creates a second step reduction Component
 type Factory struct {
-	Bus     eventbus.Broker
-	RBus    *rpcbus.RPCBus
-	Keys    key.Keys
-	timeout time.Duration
+	Bus      eventbus.Broker
+	RBus     *rpcbus.RPCBus
+	Keys     key.Keys
+	timeouts *consensus.Timeouts
 }
 
 // NewFactory creates a Factory
-func NewFactory(broker eventbus.Broker, rpcBus *rpcbus.RPCBus, keys key.Keys, timeout time.Duration) *Factory {
+func NewFactory(broker eventbus.Broker, rpcBus *rpcbus.RPCBus, keys key.Keys, timeouts *consensus.Timeouts) *Factory {
 	return &Factory{
 		broker,
 		rpcBus,
 		keys,
-		timeout,
+		timeouts,
 	}
 }
 
 // Instantiate a second step reduction Component
 // Implements consensus.ComponentFactory.
 func (f *Factory) Instantiate() consensus.Component {
-	return NewComponent(f.Bus, f.RBus, f.Keys, f.timeout)
+	return NewComponent(f.Bus, f.RBus, f.Keys, f.timeouts)
 }
 
 // CreateReducer is callback used by reduction.Helper to wire up the tests
-var CreateReducer reduction.FactoryFunc = func(eb *eventbus.EventBus, rpcBus *rpcbus.RPCBus, keys key.Keys, timeout time.Duration) reduction.Reducer {
-	f := NewFactory(eb, rpcBus, keys, timeout)
+var CreateReducer reduction.FactoryFunc = func(eb *eventbus.EventBus, rpcBus *rpcbus.RPCBus, keys key.Keys, timeouts *consensus.Timeouts) reduction.Reducer {
+	f := NewFactory(eb, rpcBus, keys, timeouts)
 	a := f.Instantiate()
 	return a.(*Reducer)
 }
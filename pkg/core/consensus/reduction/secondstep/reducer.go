@@ -3,12 +3,12 @@ package secondstep
 import (
 	"bytes"
 	"encoding/hex"
-	"time"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/reduction"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
@@ -36,18 +36,19 @@ type Reducer struct {
 
 	handler    *reduction.Handler
 	aggregator *aggregator
-	timeOut    time.Duration
+	timeouts   *consensus.Timeouts
 	timer      *reduction.Timer
 	round      uint64
+	activation block.Activation
 }
 
 // NewComponent returns an uninitialized reduction component.
-func NewComponent(broker eventbus.Broker, rpcBus *rpcbus.RPCBus, keys key.Keys, timeOut time.Duration) reduction.Reducer {
+func NewComponent(broker eventbus.Broker, rpcBus *rpcbus.RPCBus, keys key.Keys, timeouts *consensus.Timeouts) reduction.Reducer {
 	return &Reducer{
-		broker:  broker,
-		rpcBus:  rpcBus,
-		keys:    keys,
-		timeOut: timeOut,
+		broker:   broker,
+		rpcBus:   rpcBus,
+		keys:     keys,
+		timeouts: timeouts,
 	}
 }
 
@@ -57,9 +58,10 @@ func NewComponent(broker eventbus.Broker, rpcBus *rpcbus.RPCBus, keys key.Keys,
 func (r *Reducer) Initialize(eventPlayer consensus.EventPlayer, signer consensus.Signer, ru consensus.RoundUpdate) []consensus.TopicListener {
 	r.eventPlayer = eventPlayer
 	r.signer = signer
-	r.handler = reduction.NewHandler(r.keys, ru.P)
+	r.handler = reduction.NewHandler(r.keys, ru.P, ru.Activation)
 	r.timer = reduction.NewTimer(r.Halt)
 	r.round = ru.Round
+	r.activation = ru.Activation
 
 	stepVotesSubscriber := consensus.TopicListener{
 		Topic:    topics.StepVotes,
@@ -118,7 +120,7 @@ func (r *Reducer) Filter(hdr header.Header) bool {
 }
 
 func (r *Reducer) startReduction(sv message.StepVotesMsg) {
-	r.timer.Start(r.timeOut)
+	r.timer.Start(r.timeouts, consensus.SecondReduction)
 	r.aggregator = newAggregator(r.Halt, r.handler, &sv.StepVotes)
 }
 
@@ -152,12 +154,14 @@ func (r *Reducer) Halt(hash []byte, b ...*message.StepVotes) {
 
 	// Sending of agreement happens on it's own step
 	step := r.eventPlayer.Forward(r.ID())
-	if hash != nil && !bytes.Equal(hash, emptyHash[:]) && stepVotesAreValid(b) && r.handler.AmMember(r.round, step) {
-		lg.WithField("step", step).Debugln("sending agreement")
-		r.sendAgreement(step, hash, b)
+	if hash != nil && !bytes.Equal(hash, emptyHash[:]) && stepVotesAreValid(b) {
+		if r.handler.AmMember(r.round, step) {
+			lg.WithField("step", step).Debugln("sending agreement")
+			r.sendAgreement(step, hash, b)
+		}
 	} else {
-		// Increase timeout if we had no agreement
-		r.timeOut = r.timeOut * 2
+		// Increase timeout if the reduction did not reach a quorum
+		r.timeouts.Increase(consensus.SecondReduction)
 	}
 
 	restart := r.signer.Compose(restartFactory)
@@ -201,7 +205,7 @@ func (r *Reducer) sendAgreement(step uint8, hash []byte, svs []*message.StepVote
 	// XXX: the StepVotes are NOT signed (i.e. the message.SignAgreement is not used).
 	// This exposes the Agreement to some malleability attack. Double check
 	// this!!
-	ev := message.NewAgreement(hdr)
+	ev := message.NewAgreement(hdr, r.activation)
 	ev.SetSignature(sig)
 	ev.VotesPerStep = svs
 
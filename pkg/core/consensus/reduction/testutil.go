@@ -9,6 +9,7 @@ import (
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
@@ -41,7 +42,7 @@ func (m *mockSigner) SendInternally(topic topics.Topic, msg message.Message, id
 }
 
 // FactoryFunc is a shorthand for the reduction factories to create a Reducer
-type FactoryFunc func(*eventbus.EventBus, *rpcbus.RPCBus, key.Keys, time.Duration) Reducer
+type FactoryFunc func(*eventbus.EventBus, *rpcbus.RPCBus, key.Keys, *consensus.Timeouts) Reducer
 
 // Helper for reducing test boilerplate
 type Helper struct {
@@ -62,7 +63,7 @@ type Helper struct {
 func NewHelper(eb *eventbus.EventBus, rpcbus *rpcbus.RPCBus, provisioners int, factory FactoryFunc, timeOut time.Duration) *Helper {
 	p, keys := consensus.MockProvisioners(provisioners)
 	helperKeys := keys[0]
-	red := factory(eb, rpcbus, helperKeys, timeOut)
+	red := factory(eb, rpcbus, helperKeys, consensus.MockTimeouts(timeOut))
 	hlp := &Helper{
 		PubKeyBLS: helperKeys.BLSPubKeyBytes,
 		Bus:       eb,
@@ -73,7 +74,7 @@ func NewHelper(eb *eventbus.EventBus, rpcbus *rpcbus.RPCBus, provisioners int, f
 
 		signer:       &mockSigner{eb, helperKeys.BLSPubKeyBytes},
 		nr:           provisioners,
-		Handler:      NewHandler(helperKeys, *p),
+		Handler:      NewHandler(helperKeys, *p, block.Activation{}),
 		SimplePlayer: consensus.NewSimplePlayer(),
 	}
 
@@ -83,7 +84,7 @@ func NewHelper(eb *eventbus.EventBus, rpcbus *rpcbus.RPCBus, provisioners int, f
 // Verify StepVotes. The step must be specified otherwise verification would be dependent on the state of the Helper
 func (hlp *Helper) Verify(hash []byte, sv message.StepVotes, step uint8) error {
 	vc := hlp.P.CreateVotingCommittee(round, step, hlp.nr)
-	sub := vc.IntersectCluster(sv.BitSet)
+	sub := vc.IntersectClusterBitset(sv.BitSet)
 	apk, err := agreement.ReconstructApk(sub.Set)
 	if err != nil {
 		return err
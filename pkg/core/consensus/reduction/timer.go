@@ -2,8 +2,8 @@ package reduction
 
 import (
 	"sync"
-	"time"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	log "github.com/sirupsen/logrus"
 )
@@ -15,7 +15,7 @@ var emptyHash [32]byte
 type Timer struct {
 	requestHalt func([]byte, ...*message.StepVotes)
 	lock        sync.RWMutex
-	t           *time.Timer
+	t           consensus.Timer
 }
 
 // NewTimer instantiates a new Timer
@@ -25,11 +25,11 @@ func NewTimer(requestHalt func([]byte, ...*message.StepVotes)) *Timer {
 	}
 }
 
-// Start the timer
-func (t *Timer) Start(timeOut time.Duration) {
+// Start the timer with the current timeout of a phase
+func (t *Timer) Start(timeouts *consensus.Timeouts, p consensus.Phase) {
 	t.lock.Lock()
 	defer t.lock.Unlock()
-	t.t = time.AfterFunc(timeOut, t.Trigger)
+	t.t = timeouts.AfterFunc(p, t.Trigger)
 }
 
 // Stop the timer
@@ -0,0 +1,281 @@
+// Package replay feeds a consensus trace, recorded by a Coordinator (see
+// consensus.Coordinator.Record), back into a fresh Coordinator, and checks
+// that the events recorded in the trace are reproduced.
+package replay
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/agreement"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/generation"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/reduction/firststep"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/reduction/secondstep"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/selection"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
+)
+
+// FactoriesFunc creates the consensus components of the replayed Coordinator
+type FactoriesFunc func(eventBus eventbus.Broker, rpcBus *rpcbus.RPCBus, keys key.Keys, timeouts *consensus.Timeouts) []consensus.ComponentFactory
+
+// DefaultFactories creates the components of a provisioner, as the consensus
+// factory does. The score and candidate generation are left out, since the
+// scores and the candidates of the node reach the Coordinator through the
+// network, and are recorded in the trace
+func DefaultFactories(eventBus eventbus.Broker, rpcBus *rpcbus.RPCBus, keys key.Keys, timeouts *consensus.Timeouts) []consensus.ComponentFactory {
+	return []consensus.ComponentFactory{
+		selection.NewFactory(eventBus, timeouts),
+		firststep.NewFactory(eventBus, rpcBus, keys, timeouts),
+		secondstep.NewFactory(eventBus, rpcBus, keys, timeouts),
+		agreement.NewFactory(eventBus, keys, timeouts),
+		generation.NewFactory(),
+	}
+}
+
+// Divergence is returned by Replayer.Replay when the replayed Coordinator
+// does not reproduce the trace
+type Divergence struct {
+	// Index of the first record of the trace which was not reproduced. It
+	// equals the amount of records if the trace was reproduced but the
+	// replay produced more events
+	Index    int
+	Expected *consensus.TraceRecord
+	// Unexpected are the events produced by the replay and not found in
+	// the trace
+	Unexpected []consensus.TraceRecord
+}
+
+func (d *Divergence) Error() string {
+	if d.Expected == nil {
+		return fmt.Sprintf("replay produced %d events after the end of the trace", len(d.Unexpected))
+	}
+
+	return fmt.Sprintf("record %d not reproduced: %s (%d unexpected events)", d.Index, d.Expected, len(d.Unexpected))
+}
+
+// Replayer drives a fresh Coordinator with the inputs of a trace: the
+// RoundUpdates, the events received from the network and the expirations
+// of the phase timers. The timers of the Replayer never expire on their own.
+//
+// The candidate blocks are considered valid, as the Chain is not part of the
+// replay.
+type Replayer struct {
+	eventBus    *eventbus.EventBus
+	rpcBus      *rpcbus.RPCBus
+	timeouts    *consensus.Timeouts
+	coordinator *consensus.Coordinator
+	observed    *observer
+	wait        time.Duration
+	quitChan    chan struct{}
+}
+
+// New creates a Replayer for the node holding `keys`. `wait` is how long to
+// wait for an event to be reproduced before reporting a Divergence
+func New(keys key.Keys, factories FactoriesFunc, wait time.Duration) (*Replayer, error) {
+	r := &Replayer{
+		eventBus: eventbus.New(),
+		rpcBus:   rpcbus.New(),
+		// the timeouts do not matter, since the timers only expire when
+		// the trace says so
+		timeouts: consensus.NewTimeoutsWithClock(time.Second, time.Second, time.Second, manualClock{}),
+		observed: newObserver(),
+		wait:     wait,
+		quitChan: make(chan struct{}),
+	}
+
+	if err := r.serveCandidates(); err != nil {
+		return nil, err
+	}
+
+	r.coordinator = consensus.Start(r.eventBus, keys, factories(r.eventBus, r.rpcBus, keys, r.timeouts)...)
+	r.coordinator.Record(r.observed, r.timeouts)
+	return r, nil
+}
+
+// Replay the trace. `onRecord`, if not nil, is called for every record once
+// it has been reproduced. It returns a *Divergence if the trace is not
+// reproduced
+func (r *Replayer) Replay(trace *consensus.TraceReader, onRecord func(int, consensus.TraceRecord)) error {
+	i := 0
+	for ; ; i++ {
+		rec, err := trace.Next()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if rec.IsInput() && !r.feed(rec) {
+			return r.diverge(i, rec)
+		}
+
+		if !r.observed.expect(rec, r.wait) {
+			return r.diverge(i, rec)
+		}
+
+		if onRecord != nil {
+			onRecord(i, rec)
+		}
+	}
+
+	// leave time for any event produced after the end of the trace
+	time.Sleep(r.wait)
+	if unexpected := r.observed.flush(); len(unexpected) > 0 {
+		return &Divergence{Index: i, Unexpected: unexpected}
+	}
+
+	return nil
+}
+
+// Stop the Replayer
+func (r *Replayer) Stop() {
+	close(r.quitChan)
+	r.rpcBus.Close()
+}
+
+// feed an input record to the Coordinator
+func (r *Replayer) feed(rec consensus.TraceRecord) bool {
+	switch rec.Kind {
+	case consensus.TraceRoundUpdate:
+		r.eventBus.Publish(topics.RoundUpdate, message.New(topics.RoundUpdate, rec.RoundUpdate))
+	case consensus.TraceReceived:
+		_ = r.coordinator.CollectEvent(rec.Message)
+	case consensus.TraceTimer:
+		// the timer is started by a component, possibly after the last
+		// event it depends on was recorded
+		deadline := time.Now().Add(r.wait)
+		for !r.timeouts.Fire(rec.Phase) {
+			if time.Now().After(deadline) {
+				return false
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+	return true
+}
+
+func (r *Replayer) diverge(i int, rec consensus.TraceRecord) error {
+	return &Divergence{Index: i, Expected: &rec, Unexpected: r.observed.flush()}
+}
+
+// serveCandidates considers every candidate block valid
+func (r *Replayer) serveCandidates() error {
+	getCandidateChan := make(chan rpcbus.Request, 1)
+	if err := r.rpcBus.Register(topics.GetCandidate, getCandidateChan); err != nil {
+		return err
+	}
+
+	verifyChan := make(chan rpcbus.Request, 1)
+	if err := r.rpcBus.Register(topics.VerifyCandidateBlock, verifyChan); err != nil {
+		return err
+	}
+
+	go func() {
+		for {
+			select {
+			case req := <-getCandidateChan:
+				req.RespChan <- rpcbus.NewResponse(message.Candidate{}, nil)
+			case req := <-verifyChan:
+				req.RespChan <- rpcbus.NewResponse(nil, nil)
+			case <-r.quitChan:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// observer is the Tracer of the replayed Coordinator. It keeps the records
+// until they are matched with the trace. The events processed concurrently
+// by the components may be recorded in a different order than in the trace,
+// so a record of the trace can match any pending record
+type observer struct {
+	lock    sync.Mutex
+	records []consensus.TraceRecord
+	notify  chan struct{}
+}
+
+func newObserver() *observer {
+	return &observer{notify: make(chan struct{}, 1)}
+}
+
+// Trace implements consensus.Tracer
+func (o *observer) Trace(rec consensus.TraceRecord) {
+	o.lock.Lock()
+	o.records = append(o.records, rec)
+	o.lock.Unlock()
+
+	select {
+	case o.notify <- struct{}{}:
+	default:
+	}
+}
+
+// expect waits until a record equal to `rec` is observed, and consumes it
+func (o *observer) expect(rec consensus.TraceRecord, wait time.Duration) bool {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	for {
+		if o.match(rec) {
+			return true
+		}
+
+		select {
+		case <-o.notify:
+		case <-timer.C:
+			return o.match(rec)
+		}
+	}
+}
+
+func (o *observer) match(rec consensus.TraceRecord) bool {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	for i, observed := range o.records {
+		if observed.Equal(rec) {
+			o.records = append(o.records[:i], o.records[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// flush returns and forgets the records which were not matched
+func (o *observer) flush() []consensus.TraceRecord {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	records := o.records
+	o.records = nil
+	return records
+}
+
+// manualClock is a consensus.Clock whose timers never expire, so that they
+// only fire through consensus.Timeouts.Fire
+type manualClock struct{}
+
+// Now implements consensus.Clock
+func (manualClock) Now() time.Time {
+	return time.Now()
+}
+
+// AfterFunc implements consensus.Clock
+func (manualClock) AfterFunc(time.Duration, func()) consensus.Timer {
+	return manualTimer{}
+}
+
+type manualTimer struct{}
+
+// Stop implements consensus.Timer
+func (manualTimer) Stop() bool {
+	return true
+}
@@ -0,0 +1,92 @@
+package replay
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/simulator"
+	"github.com/stretchr/testify/assert"
+)
+
+// syncBuffer is a bytes.Buffer safe for concurrent use
+type syncBuffer struct {
+	lock sync.Mutex
+	buf  bytes.Buffer
+}
+
+func (s *syncBuffer) Write(p []byte) (int, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.buf.Write(p)
+}
+
+func (s *syncBuffer) Bytes() []byte {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return append([]byte{}, s.buf.Bytes()...)
+}
+
+// TestReplay records a node of the simulator and replays its trace
+func TestReplay(t *testing.T) {
+	assert := assert.New(t)
+	sim, err := simulator.New(simulator.Config{Nodes: 4, Seed: 7})
+	assert.NoError(err)
+
+	node := sim.Nodes()[0]
+	buf := new(syncBuffer)
+	w, err := consensus.NewTraceWriter(buf)
+	assert.NoError(err)
+	node.Coordinator.Record(w, node.Timeouts)
+
+	sim.Start()
+	assert.True(sim.RunUntil(sim.HeightReached(2), 10*time.Minute))
+	trace := buf.Bytes()
+	sim.Stop()
+
+	reader, err := consensus.NewTraceReader(bytes.NewReader(trace))
+	assert.NoError(err)
+
+	r, err := New(node.Keys, sim.Factories(0), time.Second)
+	assert.NoError(err)
+	defer r.Stop()
+
+	agreements := 0
+	assert.NoError(r.Replay(reader, func(_ int, rec consensus.TraceRecord) {
+		if rec.Kind == consensus.TraceAgreement {
+			agreements++
+		}
+	}))
+	assert.True(agreements >= 2)
+}
+
+// TestDivergence checks that a trace replayed with different keys diverges
+func TestDivergence(t *testing.T) {
+	assert := assert.New(t)
+	sim, err := simulator.New(simulator.Config{Nodes: 4, Seed: 8})
+	assert.NoError(err)
+
+	node := sim.Nodes()[0]
+	buf := new(syncBuffer)
+	w, err := consensus.NewTraceWriter(buf)
+	assert.NoError(err)
+	node.Coordinator.Record(w, node.Timeouts)
+
+	sim.Start()
+	assert.True(sim.RunUntil(sim.HeightReached(1), 10*time.Minute))
+	trace := buf.Bytes()
+	sim.Stop()
+
+	reader, err := consensus.NewTraceReader(bytes.NewReader(trace))
+	assert.NoError(err)
+
+	other := sim.Nodes()[1]
+	r, err := New(other.Keys, sim.Factories(1), 100*time.Millisecond)
+	assert.NoError(err)
+	defer r.Stop()
+
+	err = r.Replay(reader, nil)
+	assert.IsType(&Divergence{}, err)
+}
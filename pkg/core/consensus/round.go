@@ -9,11 +9,11 @@ import (
 
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/signer"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/encoding"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
-	"github.com/dusk-network/dusk-crypto/bls"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -113,6 +113,12 @@ func (s *roundStore) resume(id uint32) bool {
 
 // Dispatch an event to listeners for the designated Topic.
 func (s *roundStore) Dispatch(m message.Message) {
+	s.coordinator.trace(TraceRecord{
+		Kind:   TraceDispatched,
+		Topic:  m.Category(),
+		Header: m.Payload().(InternalPacket).State(),
+	})
+
 	subscribers := s.createSubscriberQueue(m.Category())
 	lg.WithFields(log.Fields{
 		"recipients": len(subscribers),
@@ -157,18 +163,35 @@ func (s *roundStore) DispatchFinalize() {
 	}
 }
 
+// Bus is the event bus the Coordinator is wired to. Besides the RoundUpdate,
+// the Coordinator collects the events of all the topics subscribed by the
+// components through the default listener. It is implemented by
+// eventbus.EventBus
+type Bus interface {
+	eventbus.Broker
+	eventbus.Multicaster
+}
+
 // Coordinator encapsulates the information about the Round and the Step of the coordinator. It also manages the roundStore,
 // which aim is to centralize the state of the coordinator Component while decoupling them from each other and the EventBus
 type Coordinator struct {
 	*SyncState
-	eventBus   *eventbus.EventBus
-	keys       key.Keys
+	eventBus   Bus
+	blsSigner  signer.Signer
 	factories  []ComponentFactory
 	eventqueue *Queue
 	roundQueue *Queue
 
 	pubkeyBuf bytes.Buffer
 
+	// wal records the signed votes to prevent double signing across restarts.
+	// It is nil if the WAL is disabled
+	wal *WAL
+
+	// tracer records the events of the Coordinator. It is nil if tracing is
+	// disabled
+	tracer Tracer
+
 	lock     sync.RWMutex
 	store    *roundStore
 	unsynced bool
@@ -177,21 +200,30 @@ type Coordinator struct {
 }
 
 // Start the coordinator by wiring the listener to the RoundUpdate
-func Start(eventBus *eventbus.EventBus, keys key.Keys, factories ...ComponentFactory) *Coordinator {
+func Start(eventBus Bus, keys key.Keys, factories ...ComponentFactory) *Coordinator {
+	return StartWithSigner(eventBus, signer.NewLocal(keys), nil, factories...)
+}
+
+// StartWithSigner starts the coordinator like Start, producing the signatures
+// through the given signer.Signer, which may hold the BLS secret key out of
+// process. Every vote is checked against the WAL before being signed. A nil
+// WAL disables the check
+func StartWithSigner(eventBus Bus, blsSigner signer.Signer, wal *WAL, factories ...ComponentFactory) *Coordinator {
 	pkBuf := new(bytes.Buffer)
 
-	if err := encoding.WriteVarBytes(pkBuf, keys.BLSPubKeyBytes); err != nil {
+	if err := encoding.WriteVarBytes(pkBuf, blsSigner.PubKeyBLS()); err != nil {
 		log.Panic(err)
 	}
 
 	c := &Coordinator{
 		SyncState:  NewState(),
 		eventBus:   eventBus,
-		keys:       keys,
+		blsSigner:  blsSigner,
 		factories:  factories,
 		eventqueue: NewQueue(),
 		roundQueue: NewQueue(),
 		pubkeyBuf:  *pkBuf,
+		wal:        wal,
 		unsynced:   true,
 		stopped:    true,
 	}
@@ -210,6 +242,43 @@ func Start(eventBus *eventbus.EventBus, keys key.Keys, factories ...ComponentFac
 	return c
 }
 
+// Record makes the Coordinator record its events with the Tracer: the
+// RoundUpdates, the received and dispatched events, the Agreements, and the
+// expirations of the phase timers started on the Timeouts. It must be called
+// before the first RoundUpdate
+func (c *Coordinator) Record(tracer Tracer, timeouts *Timeouts) {
+	c.lock.Lock()
+	c.tracer = tracer
+	c.lock.Unlock()
+
+	timeouts.setOnFire(func(p Phase) {
+		c.trace(TraceRecord{Kind: TraceTimer, Phase: p})
+	})
+
+	l := eventbus.NewCallbackListener(c.traceAgreement)
+	c.eventBus.Subscribe(topics.Certificate, l)
+}
+
+// trace a record, stamped with the current round and step
+func (c *Coordinator) trace(r TraceRecord) {
+	if c.tracer == nil {
+		return
+	}
+
+	r.Round, r.Step = c.Round(), c.Step()
+	c.tracer.Trace(r)
+}
+
+func (c *Coordinator) traceAgreement(m message.Message) error {
+	ag, ok := m.Payload().(message.Agreement)
+	if !ok {
+		return errors.New("certificate does not carry an Agreement")
+	}
+
+	c.trace(TraceRecord{Kind: TraceAgreement, Topic: topics.Agreement, Header: ag.State()})
+	return nil
+}
+
 //StopConsensus stop the consensus for this round, finalizes the Round, instantiate a new Store
 func (c *Coordinator) StopConsensus(m message.Message) error {
 	c.lock.Lock()
@@ -243,10 +312,26 @@ func (c *Coordinator) CollectRoundUpdate(m message.Message) error {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 	r := m.Payload().(RoundUpdate)
+	c.trace(TraceRecord{Kind: TraceRoundUpdate, RoundUpdate: r})
+
+	// the RoundUpdate follows the acceptance of the block at the previous
+	// height, so the votes up to that round can not be signed anymore
+	if c.wal != nil && r.Round > 0 {
+		if err := c.wal.Prune(r.Round - 1); err != nil {
+			lg.WithError(err).Warnln("could not prune the consensus WAL")
+		}
+	}
 
 	if !c.stopped {
 		c.stopConsensus()
 	}
+
+	// the agreements of a round skipped by catching up with the network
+	// are not going to be dispatched
+	if !c.unsynced && r.Round > c.Round()+1 {
+		c.roundQueue.Clear(r.Round - 1)
+	}
+
 	c.onNewRound(r, c.unsynced)
 	c.Update(r.Round)
 	c.unsynced = false
@@ -318,6 +403,13 @@ func (c *Coordinator) CollectEvent(m message.Message) error {
 		"step":  hdr.Step,
 	}).Traceln("collected event")
 
+	rec := TraceRecord{Kind: TraceReceived, Topic: m.Category(), Header: hdr}
+	if isGossipTopic(m.Category()) {
+		// the events from the network are the inputs of a replay
+		rec.Message = m
+	}
+	c.trace(rec)
+
 	// NOTE: RUnlock is not deferred here, for performance reasons.
 	// https://medium.com/i0exception/runtime-overhead-of-using-defer-in-go-7140d5c40e32
 	// TODO: once go 1.14 is out, re-examine the overhead of using `defer`.
@@ -343,6 +435,15 @@ func (c *Coordinator) CollectEvent(m message.Message) error {
 		// header.
 		if m.Category() == topics.Agreement {
 			c.roundQueue.PutEvent(hdr.Round, hdr.Step, m)
+
+			// The agreements of the next round are dispatched right away
+			// as well. Should they reach a quorum, the Agreement
+			// component lets the Chain catch up with the rest of the
+			// network, in case we missed the agreements of this round
+			if hdr.Round == c.Round()+1 {
+				c.store.Dispatch(m)
+			}
+
 			c.lock.RUnlock()
 			return nil
 		}
@@ -395,17 +496,15 @@ func (c *Coordinator) dispatchQueuedEvents() {
 // by adding it to the signature. Argument packet can be nil
 // XXX: adjust the signature verification on reduction (and agreement)
 func (c *Coordinator) Sign(h header.Header) ([]byte, error) {
-	preimage := new(bytes.Buffer)
-	if err := header.MarshalSignableVote(preimage, h); err != nil {
-		return nil, err
-	}
-
-	signedHash, err := bls.Sign(c.keys.BLSSecretKey, c.keys.BLSPubKey, preimage.Bytes())
-	if err != nil {
-		return nil, err
+	// the vote must be durably recorded before producing the signature
+	if c.wal != nil {
+		if err := c.wal.Append(h.Round, h.Step, h.BlockHash); err != nil {
+			lg.WithError(err).Errorln("refusing to sign vote")
+			return nil, err
+		}
 	}
 
-	return signedHash.Compress(), nil
+	return c.blsSigner.SignVote(h.Round, h.Step, h.BlockHash)
 }
 
 // Gossip concatenates the topic, the header and the payload,
@@ -437,7 +536,7 @@ func (c *Coordinator) Gossip(msg message.Message, id uint32) error {
 // It is a callback used by the consensus components to create the
 // appropriate Header for the Consensus
 func (c *Coordinator) Compose(pf PacketFactory) InternalPacket {
-	return pf.Create(c.keys.BLSPubKeyBytes, c.Round(), c.Step())
+	return pf.Create(c.blsSigner.PubKeyBLS(), c.Round(), c.Step())
 }
 
 // SendInternally publish a message for internal consumption (and therefore
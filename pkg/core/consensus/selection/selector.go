@@ -2,7 +2,6 @@ package selection
 
 import (
 	"sync"
-	"time"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
@@ -23,8 +22,8 @@ type Selector struct {
 	lock      sync.RWMutex
 	bestEvent message.Score
 
-	timer   *timer
-	timeout time.Duration
+	timer    *timer
+	timeouts *consensus.Timeouts
 
 	scoreID uint32
 
@@ -48,9 +47,9 @@ func (e emptyScoreFactory) Create(pubkey []byte, round uint64, step uint8) conse
 // NewComponent creates and launches the component which responsibility is to validate
 // and select the best score among the blind bidders. The component publishes under
 // the topic BestScoreTopic
-func NewComponent(publisher eventbus.Publisher, timeout time.Duration) *Selector {
+func NewComponent(publisher eventbus.Publisher, timeouts *consensus.Timeouts) *Selector {
 	return &Selector{
-		timeout:   timeout,
+		timeouts:  timeouts,
 		publisher: publisher,
 		bestEvent: message.EmptyScore(),
 	}
@@ -142,16 +141,12 @@ func (s *Selector) CollectGeneration(packet consensus.InternalPacket) error {
 func (s *Selector) startSelection() {
 	// Empty queue in a goroutine to avoid letting other listeners wait
 	go s.eventPlayer.Play(s.scoreID)
-	s.lock.RLock()
-	defer s.lock.RUnlock()
-	s.timer.start(s.timeout)
+	s.timer.start(s.timeouts)
 }
 
 // IncreaseTimeOut increases the timeout after a failed selection
 func (s *Selector) IncreaseTimeOut() {
-	s.lock.Lock()
-	defer s.lock.Unlock()
-	s.timeout = s.timeout * 2
+	s.timeouts.Increase(consensus.Selection)
 }
 
 //nolint:unparam
@@ -162,14 +157,15 @@ func (s *Selector) sendBestEvent() error {
 	bestEvent = s.bestEvent
 	s.lock.RUnlock()
 
-	// If we had no best event, we should send an empty hash
+	// If we had no best event, we should send an empty hash, and give the
+	// next selection more time
 	if bestEvent.(message.Score).IsEmpty() {
 		bestEvent = s.signer.Compose(emptyScoreFactory{})
+		s.IncreaseTimeOut()
 	}
 
 	msg := message.New(topics.BestScore, bestEvent)
 	_ = s.signer.SendInternally(topics.BestScore, msg, s.ID())
 	s.handler.LowerThreshold()
-	s.IncreaseTimeOut()
 	return nil
 }
@@ -64,7 +64,7 @@ type Helper struct {
 // NewHelper creates a Helper
 func NewHelper(eb *eventbus.EventBus) *Helper {
 	bidList := consensus.MockBidList(10)
-	factory := NewFactory(eb, 1000*time.Millisecond)
+	factory := NewFactory(eb, consensus.MockTimeouts(1000*time.Millisecond))
 	s := factory.Instantiate()
 	sel := s.(*Selector)
 	keys, _ := key.NewRandKeys()
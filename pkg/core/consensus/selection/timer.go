@@ -1,16 +1,16 @@
 package selection
 
 import (
-	"time"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
 )
 
 type timer struct {
 	s *Selector
-	t *time.Timer
+	t consensus.Timer
 }
 
-func (t *timer) start(timeOut time.Duration) {
-	t.t = time.AfterFunc(timeOut, t.trigger)
+func (t *timer) start(timeouts *consensus.Timeouts) {
+	t.t = timeouts.AfterFunc(consensus.Selection, t.trigger)
 }
 
 func (t *timer) stop() {
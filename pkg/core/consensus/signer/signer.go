@@ -0,0 +1,110 @@
+// Package signer abstracts the BLS signatures produced by a provisioner, so
+// that the BLS secret key can be held either by the node process or by a
+// remote signer running on a separate host.
+package signer
+
+import (
+	"bytes"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-crypto/bls"
+)
+
+// Signer produces the BLS signatures of a provisioner. The signatures are
+// returned compressed
+type Signer interface {
+	// PubKeyBLS returns the marshaled BLS public key of the provisioner
+	PubKeyBLS() []byte
+	// SignVote signs a Reduction or Agreement vote for a block hash
+	SignVote(round uint64, step uint8, blockHash []byte) ([]byte, error)
+	// SignSeed signs the seed of a round, for the score generation
+	SignSeed(round uint64, seed []byte) ([]byte, error)
+}
+
+// VoteLog records the votes and the seeds signed by a provisioner. Append
+// fails if a different block hash was already recorded for the same round and
+// step. AppendSeed fails if a seed was already recorded for a later round, or
+// a different seed for the same round. Implementations must be safe for
+// concurrent use. consensus.WAL implements VoteLog
+type VoteLog interface {
+	Append(round uint64, step uint8, blockHash []byte) error
+	AppendSeed(round uint64, seed []byte) error
+}
+
+// Local is the in-process Signer, holding the BLS secret key in memory
+type Local struct {
+	keys key.Keys
+}
+
+// NewLocal returns a Signer using the given consensus keys
+func NewLocal(keys key.Keys) *Local {
+	return &Local{keys}
+}
+
+// PubKeyBLS implements Signer
+func (l *Local) PubKeyBLS() []byte {
+	return l.keys.BLSPubKeyBytes
+}
+
+// SignVote implements Signer. The signed preimage is the one produced by
+// header.MarshalSignableVote
+func (l *Local) SignVote(round uint64, step uint8, blockHash []byte) ([]byte, error) {
+	preimage := new(bytes.Buffer)
+	h := header.Header{
+		Round:     round,
+		Step:      step,
+		BlockHash: blockHash,
+	}
+
+	if err := header.MarshalSignableVote(preimage, h); err != nil {
+		return nil, err
+	}
+
+	return l.sign(preimage.Bytes())
+}
+
+// SignSeed implements Signer
+func (l *Local) SignSeed(_ uint64, seed []byte) ([]byte, error) {
+	return l.sign(seed)
+}
+
+func (l *Local) sign(msg []byte) ([]byte, error) {
+	sig, err := bls.Sign(l.keys.BLSSecretKey, l.keys.BLSPubKey, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	return sig.Compress(), nil
+}
+
+// guarded is a Signer refusing to sign conflicting votes
+type guarded struct {
+	Signer
+	votes VoteLog
+}
+
+// Guard wraps a Signer so that every vote and seed is recorded in the VoteLog
+// before being signed. Votes and seeds conflicting with the VoteLog are refused
+// with the error returned by the VoteLog
+func Guard(s Signer, votes VoteLog) Signer {
+	return &guarded{Signer: s, votes: votes}
+}
+
+// SignVote implements Signer
+func (g *guarded) SignVote(round uint64, step uint8, blockHash []byte) ([]byte, error) {
+	if err := g.votes.Append(round, step, blockHash); err != nil {
+		return nil, err
+	}
+
+	return g.Signer.SignVote(round, step, blockHash)
+}
+
+// SignSeed implements Signer
+func (g *guarded) SignSeed(round uint64, seed []byte) ([]byte, error) {
+	if err := g.votes.AppendSeed(round, seed); err != nil {
+		return nil, err
+	}
+
+	return g.Signer.SignSeed(round, seed)
+}
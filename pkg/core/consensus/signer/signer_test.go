@@ -0,0 +1,86 @@
+package signer
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-crypto/bls"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalSignVote(t *testing.T) {
+	keys, _ := key.NewRandKeys()
+	s := NewLocal(keys)
+	hash := make([]byte, 32)
+
+	sig, err := s.SignVote(1, 2, hash)
+	assert.NoError(t, err)
+
+	signature, err := bls.UnmarshalSignature(sig)
+	assert.NoError(t, err)
+	apk := bls.NewApk(keys.BLSPubKey)
+	assert.NoError(t, header.VerifySignatures(1, 2, hash, apk, signature))
+	assert.Equal(t, keys.BLSPubKeyBytes, s.PubKeyBLS())
+}
+
+func TestGuardRefusesConflictingVotes(t *testing.T) {
+	keys, _ := key.NewRandKeys()
+	s := Guard(NewLocal(keys), &mockVoteLog{votes: make(map[uint64][]byte), seeds: make(map[uint64][]byte)})
+	hash, otherHash := make([]byte, 32), make([]byte, 32)
+	otherHash[0] = 1
+
+	_, err := s.SignVote(1, 2, hash)
+	assert.NoError(t, err)
+	_, err = s.SignVote(1, 2, hash)
+	assert.NoError(t, err)
+	_, err = s.SignVote(1, 2, otherHash)
+	assert.Error(t, err)
+
+	// one seed is signed per round, with rounds only increasing
+	seed, otherSeed := make([]byte, 33), make([]byte, 33)
+	otherSeed[0] = 1
+
+	_, err = s.SignSeed(2, seed)
+	assert.NoError(t, err)
+	_, err = s.SignSeed(2, otherSeed)
+	assert.Error(t, err)
+	_, err = s.SignSeed(1, otherSeed)
+	assert.Error(t, err)
+}
+
+// mockVoteLog keeps the votes in memory, indexed by round and step, and the
+// seeds indexed by round
+type mockVoteLog struct {
+	lock  sync.Mutex
+	votes map[uint64][]byte
+	seeds map[uint64][]byte
+}
+
+func (m *mockVoteLog) Append(round uint64, step uint8, blockHash []byte) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	k := round<<8 | uint64(step)
+	if signed, ok := m.votes[k]; ok && !bytes.Equal(signed, blockHash) {
+		return errors.New("double signing")
+	}
+	m.votes[k] = blockHash
+	return nil
+}
+
+func (m *mockVoteLog) AppendSeed(round uint64, seed []byte) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for r, signed := range m.seeds {
+		if r > round || (r == round && !bytes.Equal(signed, seed)) {
+			return errors.New("double signing")
+		}
+	}
+
+	m.seeds[round] = seed
+	return nil
+}
@@ -0,0 +1,274 @@
+package simulator
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/agreement"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/republisher"
+	log "github.com/sirupsen/logrus"
+)
+
+var _ consensus.Component = (*agreementComponent)(nil)
+
+// agreementFactory creates the agreement components of a node. They verify
+// and accumulate the Agreements on the goroutine dispatching them, which is
+// the one of the Simulator, rather than in a pool of workers, so that the
+// certificate is sent by the time the next event fires. The quorum, the
+// committees and the signatures are checked by the agreement handler, as the
+// agreement components of a provisioner do, and the Agreements received are
+// republished as well.
+//
+// The Agreements of the next round, which a component collects to catch up
+// with the network, are handed over to the component of that round. The
+// Coordinator dispatches them once more, from a goroutine, when the round
+// starts: as they are already counted, this does not change the outcome.
+type agreementFactory struct {
+	publisher   eventbus.Publisher
+	keys        key.Keys
+	timeouts    *consensus.Timeouts
+	republisher *republisher.Republisher
+
+	lock sync.Mutex
+	next *tally
+}
+
+func newAgreementFactory(broker eventbus.Broker, keys key.Keys, timeouts *consensus.Timeouts) *agreementFactory {
+	return &agreementFactory{
+		publisher:   broker,
+		keys:        keys,
+		timeouts:    timeouts,
+		republisher: republisher.New(broker, topics.Agreement),
+	}
+}
+
+// Instantiate implements consensus.ComponentFactory
+func (f *agreementFactory) Instantiate() consensus.Component {
+	return &agreementComponent{factory: f}
+}
+
+// handOver returns the tally of a round, which holds the Agreements collected
+// by the component of the previous round, and the tally of the next round
+func (f *agreementFactory) handOver(round uint64) (*tally, *tally) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	current := f.next
+	if current == nil || current.round != round {
+		current = newTally(round)
+	}
+
+	f.next = newTally(round + 1)
+	return current, f.next
+}
+
+type agreementComponent struct {
+	factory     *agreementFactory
+	eventPlayer consensus.EventPlayer
+	handler     agreement.Handler
+	id          uint32
+	round       uint64
+
+	current *tally
+	next    *tally
+
+	lock  sync.Mutex
+	timer consensus.Timer
+	done  bool
+}
+
+// Initialize implements consensus.Component
+func (a *agreementComponent) Initialize(eventPlayer consensus.EventPlayer, _ consensus.Signer, ru consensus.RoundUpdate) []consensus.TopicListener {
+	a.eventPlayer = eventPlayer
+	a.handler = agreement.NewHandler(a.factory.keys, ru.P, ru.Activation)
+	a.round = ru.Round
+	a.current, a.next = a.factory.handOver(ru.Round)
+
+	agreementSubscriber := consensus.TopicListener{
+		Topic:    topics.Agreement,
+		Listener: consensus.NewFilteringListener(a.CollectAgreementEvent, a.Filter, consensus.LowPriority, false),
+	}
+	a.id = agreementSubscriber.Listener.ID()
+	a.timer = a.factory.timeouts.AfterFunc(consensus.Agreement, a.expire)
+
+	// the Agreements handed over may already reach the quorum, when the
+	// node is catching up with the rest of the network
+	if votes := a.current.quorum(a.handler.Quorum(a.round)); votes != nil {
+		a.reached(votes, true)
+	}
+
+	return []consensus.TopicListener{agreementSubscriber}
+}
+
+// ID implements consensus.Component
+func (a *agreementComponent) ID() uint32 {
+	return a.id
+}
+
+// Filter out the Agreements of provisioners outside of the committee
+func (a *agreementComponent) Filter(hdr header.Header) bool {
+	return !a.handler.IsMember(hdr.PubKeyBLS, hdr.Round, hdr.Step)
+}
+
+// CollectAgreementEvent accumulates an Agreement of the current or the next
+// round, and sends the certificate once the quorum is reached
+func (a *agreementComponent) CollectAgreementEvent(packet consensus.InternalPacket) error {
+	ev := packet.(message.Agreement)
+	hdr := ev.State()
+
+	var t *tally
+	switch hdr.Round {
+	case a.round:
+		t = a.current
+	case a.round + 1:
+		t = a.next
+	default:
+		return nil
+	}
+
+	if t.has(ev) {
+		return nil
+	}
+
+	if err := a.handler.Verify(ev); err != nil {
+		log.WithField("process", "simulator").WithError(err).Debugln("agreement verification failed")
+		return nil
+	}
+
+	votes := t.add(ev, a.handler.VotesFor(hdr.PubKeyBLS, hdr.Round, hdr.Step), a.handler.Quorum(hdr.Round))
+	if votes != nil {
+		a.reached(votes, hdr.Round == a.round)
+	}
+
+	return nil
+}
+
+// reached sends the certificate of a quorum, unless one was already sent. As
+// for the agreement components of a provisioner, the timeouts only shrink
+// when the quorum is reached on the current round
+func (a *agreementComponent) reached(votes []message.Agreement, current bool) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if a.done {
+		return
+	}
+
+	a.done = true
+	a.timer.Stop()
+	if current {
+		a.factory.timeouts.Decrease()
+	}
+
+	a.factory.publisher.Publish(topics.Certificate, message.New(topics.Agreement, votes[0]))
+}
+
+func (a *agreementComponent) expire() {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if a.done {
+		return
+	}
+
+	log.WithFields(log.Fields{
+		"process": "simulator",
+		"round":   a.round,
+		"timeout": a.factory.timeouts.Get(consensus.Agreement),
+	}).Debugln("agreement not reached within the timeout")
+	a.factory.timeouts.Increase(consensus.Agreement)
+}
+
+// Finalize implements consensus.Component
+func (a *agreementComponent) Finalize() {
+	a.eventPlayer.Pause(a.id)
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.done = true
+	a.timer.Stop()
+}
+
+// tally collects the Agreements of a round, by step and sender
+type tally struct {
+	lock  sync.Mutex
+	round uint64
+	steps map[uint8]*stepTally
+}
+
+type stepTally struct {
+	senders map[string]struct{}
+	votes   []message.Agreement
+	count   int
+}
+
+func newTally(round uint64) *tally {
+	return &tally{
+		round: round,
+		steps: make(map[uint8]*stepTally),
+	}
+}
+
+// has returns whether the sender of the Agreement was already counted in
+// its step
+func (t *tally) has(ev message.Agreement) bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	hdr := ev.State()
+	s, ok := t.steps[hdr.Step]
+	if !ok {
+		return false
+	}
+
+	_, ok = s.senders[string(hdr.PubKeyBLS)]
+	return ok
+}
+
+// add an Agreement, counting its sender `weight` times. It returns the
+// Agreements of the step if it reaches the quorum, nil otherwise
+func (t *tally) add(ev message.Agreement, weight, quorum int) []message.Agreement {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	hdr := ev.State()
+	s, ok := t.steps[hdr.Step]
+	if !ok {
+		s = &stepTally{senders: make(map[string]struct{})}
+		t.steps[hdr.Step] = s
+	}
+
+	if _, ok := s.senders[string(hdr.PubKeyBLS)]; ok {
+		return nil
+	}
+
+	s.senders[string(hdr.PubKeyBLS)] = struct{}{}
+	s.votes = append(s.votes, ev)
+	s.count += weight
+	if s.count < quorum {
+		return nil
+	}
+
+	return s.votes
+}
+
+// quorum returns the Agreements of the first step reaching the quorum, nil
+// if there is none
+func (t *tally) quorum(quorum int) []message.Agreement {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	steps := make([]int, 0, len(t.steps))
+	for step := range t.steps {
+		steps = append(steps, int(step))
+	}
+	sort.Ints(steps)
+
+	for _, step := range steps {
+		if s := t.steps[uint8(step)]; s.count >= quorum {
+			return s.votes
+		}
+	}
+
+	return nil
+}
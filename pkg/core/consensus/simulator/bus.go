@@ -0,0 +1,78 @@
+package simulator
+
+import (
+	"sync"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
+	log "github.com/sirupsen/logrus"
+)
+
+var _ consensus.Bus = (*Bus)(nil)
+
+// Bus is the event bus of a simulated node. The listeners of a topic are
+// served on the publishing goroutine, as eventbus.EventBus does. The messages
+// for the default listeners, which is how the Coordinator collects the
+// consensus events, are forwarded by the simulated Clock rather than by a new
+// goroutine: Publish still returns before the Coordinator processes them, but
+// they are processed one at a time and in the order they were published
+type Bus struct {
+	*eventbus.EventBus
+	clock *Clock
+
+	lock             sync.RWMutex
+	defaultTopics    map[topics.Topic]struct{}
+	defaultListeners []eventbus.Listener
+}
+
+func newBus(clock *Clock) *Bus {
+	return &Bus{
+		EventBus:      eventbus.New(),
+		clock:         clock,
+		defaultTopics: make(map[topics.Topic]struct{}),
+	}
+}
+
+// Publish serves the listeners of the topic right away, and schedules the
+// forwarding of the message to the default listeners on the Clock
+func (b *Bus) Publish(topic topics.Topic, m message.Message) {
+	b.EventBus.Publish(topic, m)
+
+	b.lock.RLock()
+	_, ok := b.defaultTopics[topic]
+	b.lock.RUnlock()
+	if ok {
+		b.clock.AfterFunc(0, func() {
+			b.forward(m)
+		})
+	}
+}
+
+// AddDefaultTopic implements eventbus.Multicaster
+func (b *Bus) AddDefaultTopic(topic topics.Topic) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.defaultTopics[topic] = struct{}{}
+}
+
+// SubscribeDefault implements eventbus.Multicaster
+func (b *Bus) SubscribeDefault(listener eventbus.Listener) uint32 {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.defaultListeners = append(b.defaultListeners, listener)
+	return uint32(len(b.defaultListeners))
+}
+
+func (b *Bus) forward(m message.Message) {
+	b.lock.RLock()
+	listeners := b.defaultListeners
+	b.lock.RUnlock()
+
+	for _, listener := range listeners {
+		if err := listener.Notify(m); err != nil {
+			log.WithField("process", "simulator").WithError(err).Warnln("notifying default listener failed")
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package simulator
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+)
+
+var _ consensus.Clock = (*Clock)(nil)
+
+// Clock is a consensus.Clock whose time only advances when the Simulator
+// fires the next scheduled event. Events scheduled at the same time fire in
+// the order they were scheduled.
+type Clock struct {
+	lock   sync.Mutex
+	now    time.Time
+	seq    uint64
+	events eventHeap
+}
+
+// NewClock returns a Clock starting at `start`
+func NewClock(start time.Time) *Clock {
+	return &Clock{now: start}
+}
+
+// Now implements consensus.Clock
+func (c *Clock) Now() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.now
+}
+
+// AfterFunc implements consensus.Clock. `f` is called by the Simulator once
+// the Clock reaches the scheduled time
+func (c *Clock) AfterFunc(d time.Duration, f func()) consensus.Timer {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	e := &event{clock: c, at: c.now.Add(d), seq: c.seq, f: f}
+	c.seq++
+	heap.Push(&c.events, e)
+	return e
+}
+
+// fireNext advances the Clock to the next pending event, if it is scheduled
+// no later than `until`, and fires it. It returns false if there is no such
+// event, in which case the Clock is advanced to `until`
+func (c *Clock) fireNext(until time.Time) bool {
+	c.lock.Lock()
+	for len(c.events) > 0 && c.events[0].stopped {
+		heap.Pop(&c.events)
+	}
+
+	if len(c.events) == 0 || c.events[0].at.After(until) {
+		if c.now.Before(until) {
+			c.now = until
+		}
+		c.lock.Unlock()
+		return false
+	}
+
+	e := heap.Pop(&c.events).(*event)
+	e.fired = true
+	if e.at.After(c.now) {
+		c.now = e.at
+	}
+	c.lock.Unlock()
+
+	e.f()
+	return true
+}
+
+// event is a function scheduled on the Clock. It implements consensus.Timer
+type event struct {
+	clock   *Clock
+	at      time.Time
+	seq     uint64
+	f       func()
+	fired   bool
+	stopped bool
+}
+
+// Stop implements consensus.Timer
+func (e *event) Stop() bool {
+	e.clock.lock.Lock()
+	defer e.clock.lock.Unlock()
+	if e.fired || e.stopped {
+		return false
+	}
+
+	e.stopped = true
+	return true
+}
+
+// eventHeap orders the events by time and scheduling order
+type eventHeap []*event
+
+func (h eventHeap) Len() int { return len(h) }
+
+func (h eventHeap) Less(i, j int) bool {
+	if h[i].at.Equal(h[j].at) {
+		return h[i].seq < h[j].seq
+	}
+	return h[i].at.Before(h[j].at)
+}
+
+func (h eventHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *eventHeap) Push(x interface{}) {
+	*h = append(*h, x.(*event))
+}
+
+func (h *eventHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	e := old[n-1]
+	*h = old[:n-1]
+	return e
+}
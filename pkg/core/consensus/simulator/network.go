@@ -0,0 +1,142 @@
+package simulator
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	log "github.com/sirupsen/logrus"
+)
+
+// Network is the virtual network connecting the simulated nodes. Every
+// gossiped message is delivered to all the nodes, the sender included, after
+// a latency. Messages can be lost, and the nodes can be partitioned.
+//
+// The latency and the loss of a delivery are derived from the seed of the
+// Simulator, the sender, the recipient and the message itself, so that a
+// scenario always plays out the same way.
+type Network struct {
+	lock  sync.RWMutex
+	seed  int64
+	clock *Clock
+	nodes []*Node
+
+	latency time.Duration
+	jitter  time.Duration
+	loss    float64
+	// groups maps a node to its partition. A nil slice means that the
+	// network is not partitioned
+	groups []int
+}
+
+func newNetwork(seed int64, clock *Clock, latency time.Duration) *Network {
+	return &Network{
+		seed:    seed,
+		clock:   clock,
+		latency: latency,
+	}
+}
+
+// SetLatency sets the latency of the deliveries. Each delivery is delayed by
+// `latency` plus a deterministic amount up to `jitter`
+func (n *Network) SetLatency(latency, jitter time.Duration) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.latency = latency
+	n.jitter = jitter
+}
+
+// SetLoss sets the probability, between 0 and 1, for a message to be lost
+// between two different nodes
+func (n *Network) SetLoss(loss float64) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.loss = loss
+}
+
+// Partition splits the network into groups of node indexes. Messages are
+// only delivered within a group. Nodes not included in any group are
+// isolated
+func (n *Network) Partition(groups ...[]int) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.groups = make([]int, len(n.nodes))
+	for i := range n.groups {
+		// every node starts in a group of its own
+		n.groups[i] = -1 - i
+	}
+
+	for g, group := range groups {
+		for _, i := range group {
+			n.groups[i] = g
+		}
+	}
+}
+
+// Heal removes the partitions
+func (n *Network) Heal() {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.groups = nil
+}
+
+// broadcast schedules the delivery of a serialized message (topic included)
+// from a node to all the nodes it can reach
+func (n *Network) broadcast(from int, packet []byte) {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+
+	for to, node := range n.nodes {
+		if to != from && !n.reachable(from, to) {
+			continue
+		}
+
+		roll := n.roll(from, to, packet)
+		if to != from && float64(roll%1000000)/1000000 < n.loss {
+			continue
+		}
+
+		delay := n.latency
+		if n.jitter > 0 {
+			delay += time.Duration(roll>>20) % n.jitter
+		}
+
+		node, packet := node, packet
+		n.clock.AfterFunc(delay, func() {
+			deliver(node, packet)
+		})
+	}
+}
+
+func (n *Network) reachable(from, to int) bool {
+	return n.groups == nil || n.groups[from] == n.groups[to]
+}
+
+// roll returns a pseudo-random number for the delivery of a packet
+func (n *Network) roll(from, to int, packet []byte) uint64 {
+	h := fnv.New64a()
+	var buf [24]byte
+	binary.LittleEndian.PutUint64(buf[:8], uint64(n.seed))
+	binary.LittleEndian.PutUint64(buf[8:16], uint64(from))
+	binary.LittleEndian.PutUint64(buf[16:], uint64(to))
+	_, _ = h.Write(buf[:])
+	_, _ = h.Write(packet)
+	return h.Sum64()
+}
+
+// deliver a packet to a node, the same way the peer message router does
+func deliver(node *Node, packet []byte) {
+	b := bytes.NewBuffer(append([]byte{}, packet...))
+	// the simulated network activates every change from its genesis
+	msg, err := message.Unmarshal(b, block.Activation{})
+	if err != nil {
+		log.WithField("process", "simulator").WithError(err).Errorln("could not unmarshal gossiped message")
+		return
+	}
+
+	node.receive(msg)
+}
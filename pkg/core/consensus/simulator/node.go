@@ -0,0 +1,212 @@
+package simulator
+
+import (
+	"bytes"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
+)
+
+// Decision is a block hash agreed upon by a node
+type Decision struct {
+	Round uint64
+	Hash  []byte
+	// At is the simulated time of the Agreement
+	At time.Time
+}
+
+// Node is a simulated provisioner. Each Node has its own EventBus, RPCBus
+// and Coordinator, and takes the place of the Chain by starting a new round
+// every time the Agreement is reached. The events are forwarded to the
+// Coordinator by the simulated Clock (see Bus)
+type Node struct {
+	Index       int
+	Keys        key.Keys
+	EventBus    *Bus
+	RPCBus      *rpcbus.RPCBus
+	Timeouts    *consensus.Timeouts
+	Coordinator *consensus.Coordinator
+
+	sim      *Simulator
+	quitChan chan struct{}
+
+	lock      sync.RWMutex
+	decisions []Decision
+	// round is the latest round started by the Node
+	round uint64
+	// held are the Agreements received for rounds further than the next one
+	held []message.Message
+}
+
+func newNode(sim *Simulator, index int) (*Node, error) {
+	// deterministic keys make the committees reproducible
+	keys, err := key.NewKeysFromReader(rand.New(rand.NewSource(sim.cfg.Seed + int64(index))))
+	if err != nil {
+		return nil, err
+	}
+
+	n := &Node{
+		Index:    index,
+		Keys:     keys,
+		EventBus: newBus(sim.clock),
+		RPCBus:   rpcbus.New(),
+		Timeouts: consensus.NewTimeoutsWithClock(sim.cfg.Timeout, sim.cfg.Timeout, sim.cfg.MaxTimeout, sim.clock),
+		sim:      sim,
+		quitChan: make(chan struct{}),
+	}
+
+	if err := n.serveCandidates(); err != nil {
+		return nil, err
+	}
+
+	n.EventBus.Subscribe(topics.Gossip, eventbus.NewCallbackListener(n.gossip))
+	n.EventBus.Subscribe(topics.Certificate, eventbus.NewCallbackListener(n.collectCertificate))
+	return n, nil
+}
+
+// start the Coordinator of the Node
+func (n *Node) start() {
+	factories := n.sim.Factories(n.Index)(n.EventBus, n.RPCBus, n.Keys, n.Timeouts)
+	n.Coordinator = consensus.Start(n.EventBus, n.Keys, factories...)
+}
+
+func (n *Node) stop() {
+	n.EventBus.Publish(topics.StopConsensus, message.New(topics.StopConsensus, nil))
+	close(n.quitChan)
+	n.RPCBus.Close()
+}
+
+// Decisions returns the block hashes agreed upon by the Node, by round
+func (n *Node) Decisions() []Decision {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+	decisions := make([]Decision, len(n.decisions))
+	copy(decisions, n.decisions)
+	return decisions
+}
+
+// Height returns the last round agreed upon by the Node
+func (n *Node) Height() uint64 {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+	return uint64(len(n.decisions))
+}
+
+func (n *Node) gossip(m message.Message) error {
+	buf := m.Payload().(bytes.Buffer)
+	n.sim.network.broadcast(n.Index, buf.Bytes())
+	return nil
+}
+
+// collectCertificate records the Agreement and starts the next round after
+// the block time of the Simulator
+func (n *Node) collectCertificate(m message.Message) error {
+	hdr := m.Payload().(message.Agreement).State()
+
+	n.lock.Lock()
+	if hdr.Round != uint64(len(n.decisions))+1 {
+		n.lock.Unlock()
+		return nil
+	}
+
+	n.decisions = append(n.decisions, Decision{
+		Round: hdr.Round,
+		Hash:  hdr.BlockHash,
+		At:    n.sim.clock.Now(),
+	})
+	n.lock.Unlock()
+
+	n.sim.clock.AfterFunc(n.sim.cfg.BlockTime, func() {
+		n.startRound(hdr.Round+1, hdr.BlockHash)
+	})
+	return nil
+}
+
+func (n *Node) startRound(round uint64, prevHash []byte) {
+	n.lock.Lock()
+	n.round = round
+	n.lock.Unlock()
+
+	ru := n.sim.roundUpdate(round, prevHash)
+	msg := message.New(topics.RoundUpdate, ru)
+	n.EventBus.Publish(topics.RoundUpdate, msg)
+
+	for _, m := range n.release(round) {
+		n.EventBus.Publish(m.Category(), m)
+	}
+}
+
+// receive a message from the network. The Coordinator queues the Agreements
+// of the future rounds, and dispatches them from a goroutine once it gets to
+// their round, which the Simulator can not keep in step with the Clock.
+// Agreements further than the next round are therefore held until the Node
+// gets to the previous round: the Coordinator then dispatches them right
+// away, to catch up with the network, and the agreement component counts
+// them once and for all (see agreementFactory)
+func (n *Node) receive(m message.Message) {
+	if m.Category() == topics.Agreement {
+		round := m.Payload().(message.Agreement).State().Round
+		n.lock.Lock()
+		if round > n.round+1 {
+			n.held = append(n.held, m)
+			n.lock.Unlock()
+			return
+		}
+		n.lock.Unlock()
+	}
+
+	n.EventBus.Publish(m.Category(), m)
+}
+
+// release the held Agreements up to the round following `round`, in the order
+// they were received
+func (n *Node) release(round uint64) []message.Message {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	var released, held []message.Message
+	for _, m := range n.held {
+		if m.Payload().(message.Agreement).State().Round > round+1 {
+			held = append(held, m)
+			continue
+		}
+		released = append(released, m)
+	}
+
+	n.held = held
+	return released
+}
+
+// serveCandidates answers the candidate requests of the reduction as the
+// candidate broker and the Chain would, considering every candidate valid
+func (n *Node) serveCandidates() error {
+	getCandidateChan := make(chan rpcbus.Request, 1)
+	if err := n.RPCBus.Register(topics.GetCandidate, getCandidateChan); err != nil {
+		return err
+	}
+
+	verifyChan := make(chan rpcbus.Request, 1)
+	if err := n.RPCBus.Register(topics.VerifyCandidateBlock, verifyChan); err != nil {
+		return err
+	}
+
+	go func() {
+		for {
+			select {
+			case r := <-getCandidateChan:
+				r.RespChan <- rpcbus.NewResponse(message.Candidate{}, nil)
+			case r := <-verifyChan:
+				r.RespChan <- rpcbus.NewResponse(nil, nil)
+			case <-n.quitChan:
+				return
+			}
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,111 @@
+package simulator
+
+import (
+	"sync"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+)
+
+var _ consensus.Component = (*proposer)(nil)
+
+var emptyHash [32]byte
+
+// ProposerFunc returns the hash of the candidate block that a node selects
+// at a round. A nil hash makes the node select the empty block hash, as if no
+// valid score was received
+type ProposerFunc func(round uint64, node int) []byte
+
+type proposerFactory struct {
+	sim      *Simulator
+	node     int
+	timeouts *consensus.Timeouts
+}
+
+// Instantiate implements consensus.ComponentFactory
+func (f *proposerFactory) Instantiate() consensus.Component {
+	return &proposer{factory: f}
+}
+
+// proposer replaces the score generation and the selection. Blind bids and
+// their zero-knowledge proofs are out of the scope of the simulation, so the
+// proposer simply selects the hash returned by the ProposerFunc of the
+// Simulator once the Selection timeout expires
+type proposer struct {
+	factory     *proposerFactory
+	eventPlayer consensus.EventPlayer
+	signer      consensus.Signer
+	round       uint64
+	id          uint32
+
+	lock  sync.Mutex
+	timer consensus.Timer
+}
+
+type bestScoreFactory struct {
+	hash []byte
+}
+
+// Create implements consensus.PacketFactory
+func (b bestScoreFactory) Create(pubkey []byte, round uint64, step uint8) consensus.InternalPacket {
+	hdr := header.Header{
+		Round:     round,
+		Step:      step,
+		PubKeyBLS: pubkey,
+		BlockHash: b.hash,
+	}
+	return message.EmptyScoreProposal(hdr)
+}
+
+// Initialize implements consensus.Component
+func (p *proposer) Initialize(eventPlayer consensus.EventPlayer, signer consensus.Signer, ru consensus.RoundUpdate) []consensus.TopicListener {
+	p.eventPlayer = eventPlayer
+	p.signer = signer
+	p.round = ru.Round
+
+	generationSubscriber := consensus.TopicListener{
+		Topic:    topics.Generation,
+		Listener: consensus.NewSimpleListener(p.CollectGeneration, consensus.HighPriority, false),
+	}
+	p.id = generationSubscriber.ID()
+
+	return []consensus.TopicListener{generationSubscriber}
+}
+
+// ID implements consensus.Component
+func (p *proposer) ID() uint32 {
+	return p.id
+}
+
+// Finalize implements consensus.Component
+func (p *proposer) Finalize() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.timer != nil {
+		p.timer.Stop()
+	}
+}
+
+// CollectGeneration starts the selection step
+func (p *proposer) CollectGeneration(consensus.InternalPacket) error {
+	_ = p.eventPlayer.Forward(p.ID())
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.timer = p.factory.timeouts.AfterFunc(consensus.Selection, p.sendBestScore)
+	return nil
+}
+
+func (p *proposer) sendBestScore() {
+	hash := p.factory.sim.proposal(p.round, p.factory.node)
+	if hash == nil {
+		hash = emptyHash[:]
+		p.factory.timeouts.Increase(consensus.Selection)
+	}
+
+	bestScore := p.signer.Compose(bestScoreFactory{hash})
+	msg := message.New(topics.BestScore, bestScore)
+	_ = p.signer.SendInternally(topics.BestScore, msg, p.ID())
+}
@@ -0,0 +1,237 @@
+// Package simulator runs several consensus nodes in a single process, on a
+// virtual network and a simulated clock, so that consensus scenarios can be
+// scripted and reproduced from `go test`.
+package simulator
+
+import (
+	"encoding/binary"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/generation"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/reduction/firststep"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/reduction/secondstep"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
+	"github.com/dusk-network/dusk-crypto/hash"
+)
+
+// Config of a Simulator. Zero values are replaced by the defaults
+type Config struct {
+	// Nodes is the amount of provisioners
+	Nodes int
+	// Seed makes the keys, the proposals and the network deterministic
+	Seed int64
+	// Stake is the stake of every provisioner
+	Stake uint64
+
+	// Timeout is the initial (and minimum) timeout of the consensus phases
+	Timeout time.Duration
+	// MaxTimeout is the maximum timeout of the consensus phases
+	MaxTimeout time.Duration
+	// Latency is the initial latency of the network
+	Latency time.Duration
+	// BlockTime is the simulated time between the Agreement and the start
+	// of the next round
+	BlockTime time.Duration
+}
+
+func (c *Config) setDefaults() {
+	if c.Nodes == 0 {
+		c.Nodes = 4
+	}
+	if c.Stake == 0 {
+		c.Stake = 500
+	}
+	if c.Timeout == 0 {
+		c.Timeout = 5 * time.Second
+	}
+	if c.MaxTimeout == 0 {
+		c.MaxTimeout = 32 * c.Timeout
+	}
+	if c.Latency == 0 {
+		c.Latency = 100 * time.Millisecond
+	}
+	if c.BlockTime == 0 {
+		c.BlockTime = time.Second
+	}
+}
+
+// Simulator drives a set of consensus nodes on a simulated clock. Time
+// advances by firing the next timer, network delivery or event forwarded to
+// a Coordinator, one at a time and in a deterministic order
+type Simulator struct {
+	cfg     Config
+	clock   *Clock
+	network *Network
+	nodes   []*Node
+
+	lock     sync.RWMutex
+	proposer ProposerFunc
+}
+
+// New creates a Simulator. The Coordinators of the nodes are created, but no
+// round is started until Start is called
+func New(cfg Config) (*Simulator, error) {
+	cfg.setDefaults()
+	if cfg.Nodes < 1 {
+		return nil, errors.New("the simulator needs at least one node")
+	}
+
+	s := &Simulator{
+		cfg:   cfg,
+		clock: NewClock(time.Unix(0, 0).UTC()),
+	}
+	s.proposer = s.defaultProposal
+	s.network = newNetwork(cfg.Seed, s.clock, cfg.Latency)
+
+	for i := 0; i < cfg.Nodes; i++ {
+		n, err := newNode(s, i)
+		if err != nil {
+			return nil, err
+		}
+		s.nodes = append(s.nodes, n)
+	}
+
+	s.network.nodes = s.nodes
+	for _, n := range s.nodes {
+		n.start()
+	}
+	return s, nil
+}
+
+// Start the first round on all the nodes
+func (s *Simulator) Start() {
+	for _, n := range s.nodes {
+		n.startRound(1, make([]byte, 32))
+	}
+}
+
+// Stop the nodes. The Simulator can not be used after calling Stop
+func (s *Simulator) Stop() {
+	for _, n := range s.nodes {
+		n.stop()
+	}
+}
+
+// Nodes returns the simulated nodes
+func (s *Simulator) Nodes() []*Node {
+	return s.nodes
+}
+
+// Network returns the virtual network, to script latency, loss and
+// partitions
+func (s *Simulator) Network() *Network {
+	return s.network
+}
+
+// Now returns the simulated time
+func (s *Simulator) Now() time.Time {
+	return s.clock.Now()
+}
+
+// SetProposer replaces the function choosing the candidate block hash of
+// each node at each round. By default, all the nodes select the same hash
+func (s *Simulator) SetProposer(p ProposerFunc) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.proposer = p
+}
+
+// Factories returns the function creating the consensus components of a
+// node, where the selection is replaced by the simulated proposer, and the
+// agreement by a component accumulating the Agreements as they are
+// dispatched. It matches replay.FactoriesFunc, so that the trace of a node
+// can be replayed
+func (s *Simulator) Factories(index int) func(eventbus.Broker, *rpcbus.RPCBus, key.Keys, *consensus.Timeouts) []consensus.ComponentFactory {
+	return func(eventBus eventbus.Broker, rpcBus *rpcbus.RPCBus, keys key.Keys, timeouts *consensus.Timeouts) []consensus.ComponentFactory {
+		return []consensus.ComponentFactory{
+			&proposerFactory{sim: s, node: index, timeouts: timeouts},
+			firststep.NewFactory(eventBus, rpcBus, keys, timeouts),
+			secondstep.NewFactory(eventBus, rpcBus, keys, timeouts),
+			newAgreementFactory(eventBus, keys, timeouts),
+			generation.NewFactory(),
+		}
+	}
+}
+
+// Run advances the simulated clock by `d`, firing all the events scheduled
+// in the meantime
+func (s *Simulator) Run(d time.Duration) {
+	until := s.clock.Now().Add(d)
+	for s.clock.fireNext(until) {
+	}
+}
+
+// RunUntil advances the simulated clock until `cond` is satisfied, for at
+// most `max`. It returns whether `cond` was satisfied
+func (s *Simulator) RunUntil(cond func() bool, max time.Duration) bool {
+	until := s.clock.Now().Add(max)
+	for !cond() {
+		if !s.clock.fireNext(until) {
+			return cond()
+		}
+	}
+	return true
+}
+
+// HeightReached returns a condition, for RunUntil, satisfied once all the
+// nodes agreed on the given round
+func (s *Simulator) HeightReached(round uint64) func() bool {
+	return func() bool {
+		for _, n := range s.nodes {
+			if n.Height() < round {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+func (s *Simulator) proposal(round uint64, node int) []byte {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.proposer(round, node)
+}
+
+// defaultProposal is the same for all the nodes and derived from the seed
+func (s *Simulator) defaultProposal(round uint64, _ int) []byte {
+	h, _ := hash.Sha3256(s.seedBytes(round))
+	return h
+}
+
+func (s *Simulator) seedBytes(round uint64) []byte {
+	b := make([]byte, 16)
+	binary.LittleEndian.PutUint64(b[:8], uint64(s.cfg.Seed))
+	binary.LittleEndian.PutUint64(b[8:], round)
+	return b
+}
+
+// roundUpdate creates the RoundUpdate of a round. All the nodes share the
+// same provisioner set, holding the same stake
+func (s *Simulator) roundUpdate(round uint64, prevHash []byte) consensus.RoundUpdate {
+	p := user.NewProvisioners()
+	for _, n := range s.nodes {
+		pk := n.Keys.BLSPubKeyBytes
+		p.Members[string(pk)] = &user.Member{
+			PublicKeyBLS: pk,
+			Stakes:       []user.Stake{{Amount: s.cfg.Stake, EndHeight: ^uint64(0)}},
+		}
+		p.Set.Insert(pk)
+	}
+	// as the chain does, share the committees among the components
+	p.ResetCommittees()
+
+	seed, _ := hash.Sha3256(append([]byte("seed"), s.seedBytes(round)...))
+	return consensus.RoundUpdate{
+		Round: round,
+		P:     *p,
+		// the BLS signature of a seed is 33 bytes long
+		Seed: append(seed, 0),
+		Hash: prevHash,
+	}
+}
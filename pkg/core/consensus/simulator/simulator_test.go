@@ -0,0 +1,73 @@
+package simulator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAgreement checks that the nodes agree on the proposed hashes
+func TestAgreement(t *testing.T) {
+	assert := assert.New(t)
+	sim, err := New(Config{Nodes: 4, Seed: 1})
+	require.NoError(t, err)
+	defer sim.Stop()
+
+	sim.Start()
+	require.True(t, sim.RunUntil(sim.HeightReached(3), 10*time.Minute))
+
+	for _, n := range sim.Nodes() {
+		decisions := n.Decisions()
+		require.True(t, len(decisions) >= 3)
+		for i, d := range decisions[:3] {
+			assert.Equal(uint64(i+1), d.Round)
+			assert.Equal(sim.defaultProposal(d.Round, n.Index), d.Hash)
+		}
+	}
+}
+
+// TestDeterminism checks that two simulations with the same seed agree on the
+// same hashes at the same simulated times
+func TestDeterminism(t *testing.T) {
+	run := func() [][]Decision {
+		sim, err := New(Config{Nodes: 4, Seed: 3})
+		require.NoError(t, err)
+		defer sim.Stop()
+
+		sim.Network().SetLatency(50*time.Millisecond, 200*time.Millisecond)
+		sim.Start()
+		require.True(t, sim.RunUntil(sim.HeightReached(3), 10*time.Minute))
+
+		decisions := make([][]Decision, 0, len(sim.Nodes()))
+		for _, n := range sim.Nodes() {
+			decisions = append(decisions, n.Decisions()[:3])
+		}
+		return decisions
+	}
+
+	assert.Equal(t, run(), run())
+}
+
+// TestPartition checks that a network split in halves stalls the consensus,
+// and that the nodes recover once the partition heals
+func TestPartition(t *testing.T) {
+	assert := assert.New(t)
+	sim, err := New(Config{Nodes: 4, Seed: 2})
+	require.NoError(t, err)
+	defer sim.Stop()
+
+	sim.Start()
+	require.True(t, sim.RunUntil(sim.HeightReached(1), 10*time.Minute))
+
+	// no half holds a quorum
+	sim.Network().Partition([]int{0, 1}, []int{2, 3})
+	sim.Run(5 * time.Minute)
+	for _, n := range sim.Nodes() {
+		assert.Equal(uint64(1), n.Height())
+	}
+
+	sim.Network().Heal()
+	assert.True(sim.RunUntil(sim.HeightReached(2), time.Hour))
+}
@@ -1,6 +1,9 @@
 package consensus
 
-import "sync"
+import (
+	"sync"
+	"time"
+)
 
 // State indicates the status of the EventPlayer
 type State uint8
@@ -64,3 +67,9 @@ func (s *SimplePlayer) State() State {
 	defer s.lock.RUnlock()
 	return s.state
 }
+
+// MockTimeouts creates Timeouts starting at `timeout`, which is also their
+// lower bound
+func MockTimeouts(timeout time.Duration) *Timeouts {
+	return NewTimeouts(timeout, timeout, 64*timeout)
+}
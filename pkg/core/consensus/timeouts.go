@@ -0,0 +1,251 @@
+package consensus
+
+import (
+	"sync"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
+	log "github.com/sirupsen/logrus"
+)
+
+// Phase identifies a consensus phase bound by a timeout
+type Phase uint8
+
+// The consensus phases bound by a timeout
+const (
+	Selection Phase = iota
+	FirstReduction
+	SecondReduction
+	Agreement
+
+	phaseAmount
+)
+
+var phaseNames = [phaseAmount]string{
+	"selection",
+	"firstreduction",
+	"secondreduction",
+	"agreement",
+}
+
+func (p Phase) String() string {
+	if p >= phaseAmount {
+		return "unknown"
+	}
+	return phaseNames[p]
+}
+
+// PhaseTimeout is the current timeout of a consensus phase
+type PhaseTimeout struct {
+	Phase   Phase
+	Timeout time.Duration
+}
+
+// Timeouts keeps track of the timeouts of the consensus phases across rounds.
+// The timeout of a phase doubles every time the phase ends empty or times
+// out, while all the timeouts halve after every successful round. The
+// timeouts never leave the [min, max] bounds.
+// Timeouts is shared by the components of all the rounds and is safe for
+// concurrent use. The phase timers are started on the Clock of the Timeouts.
+type Timeouts struct {
+	lock     sync.RWMutex
+	min, max time.Duration
+	current  [phaseAmount]time.Duration
+	clock    Clock
+
+	// pending is the last timer started for each phase
+	pending [phaseAmount]*phaseTimer
+	// onFire is called whenever a phase timer fires, before its function
+	onFire func(Phase)
+}
+
+// NewTimeouts returns Timeouts starting at `initial` for every phase. The
+// bounds are swapped if `min` is greater than `max`
+func NewTimeouts(initial, min, max time.Duration) *Timeouts {
+	return NewTimeoutsWithClock(initial, min, max, SystemClock{})
+}
+
+// NewTimeoutsWithClock returns Timeouts like NewTimeouts, starting the phase
+// timers on the given Clock
+func NewTimeoutsWithClock(initial, min, max time.Duration, clock Clock) *Timeouts {
+	if min > max {
+		min, max = max, min
+	}
+
+	t := &Timeouts{min: min, max: max, clock: clock}
+	for i := range t.current {
+		t.current[i] = t.clamp(initial)
+	}
+	return t
+}
+
+// Get the current timeout of a phase
+func (t *Timeouts) Get(p Phase) time.Duration {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	return t.current[p]
+}
+
+// AfterFunc starts a timer calling `f` once the current timeout of a phase
+// has elapsed
+func (t *Timeouts) AfterFunc(p Phase, f func()) Timer {
+	pt := &phaseTimer{timeouts: t, phase: p, f: f}
+	timeout := t.Get(p)
+
+	pt.lock.Lock()
+	pt.timer = t.clock.AfterFunc(timeout, func() {
+		pt.fire()
+	})
+	pt.lock.Unlock()
+
+	t.lock.Lock()
+	t.pending[p] = pt
+	t.lock.Unlock()
+	return pt
+}
+
+// Fire the last timer started for a phase right away, unless it already
+// fired or was stopped. It returns whether the timer fired. It is used to
+// replay the timer expirations recorded in a trace
+func (t *Timeouts) Fire(p Phase) bool {
+	t.lock.RLock()
+	pt := t.pending[p]
+	t.lock.RUnlock()
+
+	if pt == nil {
+		return false
+	}
+
+	return pt.fire()
+}
+
+func (t *Timeouts) setOnFire(onFire func(Phase)) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.onFire = onFire
+}
+
+func (t *Timeouts) getOnFire() func(Phase) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	return t.onFire
+}
+
+// Increase doubles the timeout of a phase after it ended empty or timed out
+func (t *Timeouts) Increase(p Phase) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.set(p, t.clamp(t.current[p]*2))
+}
+
+// Decrease halves the timeouts of all the phases after a successful round
+func (t *Timeouts) Decrease() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	for p := range t.current {
+		t.set(Phase(p), t.clamp(t.current[p]/2))
+	}
+}
+
+// Snapshot returns the current timeouts of all the phases
+func (t *Timeouts) Snapshot() []PhaseTimeout {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	s := make([]PhaseTimeout, len(t.current))
+	for p, timeout := range t.current {
+		s[p] = PhaseTimeout{Phase: Phase(p), Timeout: timeout}
+	}
+	return s
+}
+
+func (t *Timeouts) set(p Phase, timeout time.Duration) {
+	if t.current[p] == timeout {
+		return
+	}
+
+	lg.WithFields(log.Fields{
+		"phase": p.String(),
+		"from":  t.current[p],
+		"to":    timeout,
+	}).Infoln("consensus timeout adjusted")
+	t.current[p] = timeout
+}
+
+func (t *Timeouts) clamp(timeout time.Duration) time.Duration {
+	if timeout < t.min {
+		return t.min
+	}
+
+	if timeout > t.max {
+		return t.max
+	}
+
+	return timeout
+}
+
+// phaseTimer is the Timer returned by Timeouts.AfterFunc. It fires at most
+// once, either when the timer of the Clock expires or through Timeouts.Fire
+type phaseTimer struct {
+	timeouts *Timeouts
+	phase    Phase
+	f        func()
+
+	lock  sync.Mutex
+	timer Timer
+	done  bool
+}
+
+// Stop implements Timer
+func (pt *phaseTimer) Stop() bool {
+	if !pt.finish() {
+		return false
+	}
+
+	pt.timer.Stop()
+	return true
+}
+
+func (pt *phaseTimer) fire() bool {
+	if !pt.finish() {
+		return false
+	}
+
+	// the Clock timer is useless if the timer is fired through Timeouts.Fire
+	pt.timer.Stop()
+	if onFire := pt.timeouts.getOnFire(); onFire != nil {
+		onFire(pt.phase)
+	}
+
+	pt.f()
+	return true
+}
+
+// finish marks the timer as fired or stopped. It returns false if it already
+// was
+func (pt *phaseTimer) finish() bool {
+	pt.lock.Lock()
+	defer pt.lock.Unlock()
+	if pt.done {
+		return false
+	}
+
+	pt.done = true
+	return true
+}
+
+// ServeTimeouts registers the Timeouts on the topics.GetConsensusTimeouts
+// RPCBus topic and answers the requests with a Snapshot in a goroutine
+func ServeTimeouts(rpcBus *rpcbus.RPCBus, t *Timeouts) error {
+	reqChan := make(chan rpcbus.Request, 1)
+	if err := rpcBus.Register(topics.GetConsensusTimeouts, reqChan); err != nil {
+		return err
+	}
+
+	go func() {
+		for r := range reqChan {
+			r.RespChan <- rpcbus.NewResponse(t.Snapshot(), nil)
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,55 @@
+package consensus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeoutsBounds(t *testing.T) {
+	timeouts := NewTimeouts(5*time.Second, 1*time.Second, 20*time.Second)
+
+	// consecutive failures double the timeout of the failing phase only
+	timeouts.Increase(FirstReduction)
+	assert.Equal(t, 10*time.Second, timeouts.Get(FirstReduction))
+	timeouts.Increase(FirstReduction)
+	assert.Equal(t, 20*time.Second, timeouts.Get(FirstReduction))
+	timeouts.Increase(FirstReduction)
+	assert.Equal(t, 20*time.Second, timeouts.Get(FirstReduction))
+	assert.Equal(t, 5*time.Second, timeouts.Get(Selection))
+
+	// successful rounds halve all of them, down to the lower bound
+	timeouts.Decrease()
+	assert.Equal(t, 10*time.Second, timeouts.Get(FirstReduction))
+	assert.Equal(t, 2500*time.Millisecond, timeouts.Get(Selection))
+	timeouts.Decrease()
+	timeouts.Decrease()
+	assert.Equal(t, 2500*time.Millisecond, timeouts.Get(FirstReduction))
+	assert.Equal(t, 1*time.Second, timeouts.Get(Selection))
+}
+
+func TestTimeoutsInitialClamped(t *testing.T) {
+	timeouts := NewTimeouts(5*time.Second, 10*time.Second, 1*time.Minute)
+	for _, pt := range timeouts.Snapshot() {
+		assert.Equal(t, 10*time.Second, pt.Timeout)
+	}
+}
+
+func TestServeTimeouts(t *testing.T) {
+	rpcBus := rpcbus.New()
+	timeouts := NewTimeouts(5*time.Second, 1*time.Second, 20*time.Second)
+	timeouts.Increase(Agreement)
+	assert.NoError(t, ServeTimeouts(rpcBus, timeouts))
+
+	resp, err := rpcBus.Call(topics.GetConsensusTimeouts, rpcbus.EmptyRequest(), time.Second)
+	assert.NoError(t, err)
+
+	snapshot := resp.([]PhaseTimeout)
+	assert.Len(t, snapshot, 4)
+	assert.Equal(t, Agreement, snapshot[3].Phase)
+	assert.Equal(t, "agreement", snapshot[3].Phase.String())
+	assert.Equal(t, 10*time.Second, snapshot[3].Timeout)
+}
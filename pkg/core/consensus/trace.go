@@ -0,0 +1,511 @@
+package consensus
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/encoding"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+)
+
+// traceMagic starts every trace file. The last byte is the format version
+var traceMagic = []byte{'D', 'U', 'S', 'K', 'T', 'R', 'C', 2}
+
+// maxTraceRecordSize bounds the size of a record, well above the one of a
+// RoundUpdate listing every provisioner and bid. Bigger records are not
+// traced, and a corrupted length prefix is not trusted to allocate a record
+const maxTraceRecordSize = 64 << 20
+
+// traceTimeFormat is the format of the time suffixed to the name of a
+// rotated trace
+const traceTimeFormat = "20060102T150405.000000000Z"
+
+// TraceKind is the kind of a TraceRecord
+type TraceKind uint8
+
+// The kinds of TraceRecord
+const (
+	// TraceRoundUpdate is a RoundUpdate received by the Coordinator
+	TraceRoundUpdate TraceKind = iota
+	// TraceReceived is an event received by the Coordinator. The events
+	// gossiped by the network are recorded in full
+	TraceReceived
+	// TraceDispatched is an event dispatched to the consensus components
+	TraceDispatched
+	// TraceTimer is the expiration of the timer of a consensus phase
+	TraceTimer
+	// TraceAgreement is the Agreement reached at the end of a round
+	TraceAgreement
+)
+
+var traceKindNames = [...]string{"roundupdate", "received", "dispatched", "timer", "agreement"}
+
+func (k TraceKind) String() string {
+	if int(k) >= len(traceKindNames) {
+		return "unknown"
+	}
+	return traceKindNames[k]
+}
+
+// TraceRecord is an event recorded by the Coordinator. Round and Step are the
+// state of the Coordinator at the time of the recording
+type TraceRecord struct {
+	Kind  TraceKind
+	Round uint64
+	Step  uint8
+
+	// Topic and Header describe the event of a TraceReceived,
+	// TraceDispatched or TraceAgreement record
+	Topic  topics.Topic
+	Header header.Header
+	// Message is the event of a TraceReceived record gossiped by the
+	// network, and nil otherwise
+	Message message.Message
+
+	// Phase is the phase of a TraceTimer record
+	Phase Phase
+
+	// RoundUpdate is the payload of a TraceRoundUpdate record
+	RoundUpdate RoundUpdate
+}
+
+// IsInput returns whether the record is an input of the Coordinator, as
+// opposed to an event produced by the consensus components
+func (r TraceRecord) IsInput() bool {
+	switch r.Kind {
+	case TraceRoundUpdate, TraceTimer:
+		return true
+	case TraceReceived:
+		return r.Message != nil
+	}
+	return false
+}
+
+// Equal compares the content of the records, ignoring the signatures
+// carried by the events. The state of the Coordinator is not compared, as the
+// components process the events concurrently with the step changes
+func (r TraceRecord) Equal(other TraceRecord) bool {
+	if r.Kind != other.Kind {
+		return false
+	}
+
+	switch r.Kind {
+	case TraceRoundUpdate:
+		return r.RoundUpdate.Round == other.RoundUpdate.Round &&
+			bytes.Equal(r.RoundUpdate.Hash, other.RoundUpdate.Hash)
+	case TraceTimer:
+		return r.Phase == other.Phase
+	}
+
+	return r.Topic == other.Topic &&
+		r.Header.Round == other.Header.Round &&
+		r.Header.Step == other.Header.Step &&
+		bytes.Equal(r.Header.PubKeyBLS, other.Header.PubKeyBLS) &&
+		bytes.Equal(r.Header.BlockHash, other.Header.BlockHash)
+}
+
+func (r TraceRecord) String() string {
+	s := fmt.Sprintf("%d/%d %s", r.Round, r.Step, r.Kind)
+	switch r.Kind {
+	case TraceRoundUpdate:
+		return fmt.Sprintf("%s round %d", s, r.RoundUpdate.Round)
+	case TraceTimer:
+		return fmt.Sprintf("%s %s", s, r.Phase)
+	}
+
+	return fmt.Sprintf("%s %s %d/%d hash %x sender %x", s, r.Topic, r.Header.Round, r.Header.Step,
+		r.Header.BlockHash, shortKey(r.Header.PubKeyBLS))
+}
+
+func shortKey(pk []byte) []byte {
+	if len(pk) > 8 {
+		return pk[:8]
+	}
+	return pk
+}
+
+// Tracer records the events of a Coordinator. Implementations must be safe
+// for concurrent use
+type Tracer interface {
+	Trace(TraceRecord)
+}
+
+// isGossipTopic returns whether the events of a topic come from the network
+func isGossipTopic(topic topics.Topic) bool {
+	switch topic {
+	case topics.Score, topics.Reduction, topics.Agreement:
+		return true
+	}
+	return false
+}
+
+// TraceWriter is a Tracer writing a compact binary trace. Each record is
+// written as soon as it is traced, so that the trace survives a crash.
+// Records which can not be encoded are skipped
+type TraceWriter struct {
+	lock   sync.Mutex
+	w      io.Writer
+	closer io.Closer
+	err    error
+}
+
+// CreateTrace creates the trace file at `path`. An existing trace is kept,
+// renamed after the time it was last written to, so that the trace leading to
+// a restart can still be replayed
+func CreateTrace(path string) (*TraceWriter, error) {
+	if err := rotateTrace(path); err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := NewTraceWriter(f)
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+
+	t.closer = f
+	return t, nil
+}
+
+// rotateTrace renames the trace at `path`, if any, to `path`.<time>
+func rotateTrace(path string) error {
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(path, path+"."+info.ModTime().UTC().Format(traceTimeFormat))
+}
+
+// NewTraceWriter writes a trace to `w`
+func NewTraceWriter(w io.Writer) (*TraceWriter, error) {
+	if _, err := w.Write(traceMagic); err != nil {
+		return nil, err
+	}
+
+	return &TraceWriter{w: w}, nil
+}
+
+// Trace implements Tracer
+func (t *TraceWriter) Trace(r TraceRecord) {
+	buf := new(bytes.Buffer)
+	// leave room for the length prefix
+	buf.Write(make([]byte, 4))
+	if err := marshalTraceRecord(buf, r); err != nil {
+		lg.WithError(err).WithField("record", r.String()).Warnln("could not trace record")
+		return
+	}
+
+	b := buf.Bytes()
+	if len(b)-4 > maxTraceRecordSize {
+		lg.WithField("record", r.String()).WithField("size", len(b)-4).Warnln("record too big to be traced")
+		return
+	}
+
+	binary.LittleEndian.PutUint32(b[:4], uint32(len(b)-4))
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.err != nil {
+		return
+	}
+
+	if _, t.err = t.w.Write(b); t.err != nil {
+		lg.WithError(t.err).Errorln("could not write the consensus trace, tracing stopped")
+	}
+}
+
+// Close the underlying file, if the TraceWriter was created by CreateTrace
+func (t *TraceWriter) Close() error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.closer == nil {
+		return nil
+	}
+	return t.closer.Close()
+}
+
+// TraceReader reads the records of a trace
+type TraceReader struct {
+	r io.Reader
+
+	// activation is the one of the last RoundUpdate read, which selects the
+	// encoding of the following messages
+	activation block.Activation
+}
+
+// NewTraceReader reads a trace from `r`
+func NewTraceReader(r io.Reader) (*TraceReader, error) {
+	magic := make([]byte, len(traceMagic))
+	if _, err := io.ReadFull(r, magic); err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(magic, traceMagic) {
+		return nil, errors.New("not a consensus trace, or unsupported version")
+	}
+
+	return &TraceReader{r: r}, nil
+}
+
+// Next returns the next record of the trace. It returns io.EOF at the end of
+// the trace, including when the last record was truncated by a crash
+func (t *TraceReader) Next() (TraceRecord, error) {
+	var size [4]byte
+	if _, err := io.ReadFull(t.r, size[:]); err != nil {
+		return TraceRecord{}, eof(err)
+	}
+
+	n := binary.LittleEndian.Uint32(size[:])
+	if n > maxTraceRecordSize {
+		return TraceRecord{}, fmt.Errorf("trace record of %d bytes exceeds the maximum of %d bytes", n, maxTraceRecordSize)
+	}
+
+	b := make([]byte, n)
+	if _, err := io.ReadFull(t.r, b); err != nil {
+		return TraceRecord{}, eof(err)
+	}
+
+	r, err := unmarshalTraceRecord(bytes.NewBuffer(b), t.activation)
+	if err == nil && r.Kind == TraceRoundUpdate {
+		t.activation = r.RoundUpdate.Activation
+	}
+	return r, err
+}
+
+func eof(err error) error {
+	if err == io.ErrUnexpectedEOF {
+		return io.EOF
+	}
+	return err
+}
+
+func marshalTraceRecord(buf *bytes.Buffer, r TraceRecord) error {
+	if err := encoding.WriteUint8(buf, uint8(r.Kind)); err != nil {
+		return err
+	}
+
+	if err := encoding.WriteUint64LE(buf, r.Round); err != nil {
+		return err
+	}
+
+	if err := encoding.WriteUint8(buf, r.Step); err != nil {
+		return err
+	}
+
+	switch r.Kind {
+	case TraceRoundUpdate:
+		return marshalRoundUpdate(buf, r.RoundUpdate)
+	case TraceTimer:
+		return encoding.WriteUint8(buf, uint8(r.Phase))
+	}
+
+	if err := encoding.WriteUint8(buf, uint8(r.Topic)); err != nil {
+		return err
+	}
+
+	if err := marshalTraceHeader(buf, r.Header); err != nil {
+		return err
+	}
+
+	var serialized []byte
+	if r.Message != nil {
+		m, err := message.Marshal(r.Message)
+		if err != nil {
+			return err
+		}
+		serialized = m.Bytes()
+	}
+
+	return encoding.WriteVarBytes(buf, serialized)
+}
+
+func unmarshalTraceRecord(buf *bytes.Buffer, act block.Activation) (TraceRecord, error) {
+	var r TraceRecord
+	var kind uint8
+	if err := encoding.ReadUint8(buf, &kind); err != nil {
+		return r, err
+	}
+	r.Kind = TraceKind(kind)
+
+	if err := encoding.ReadUint64LE(buf, &r.Round); err != nil {
+		return r, err
+	}
+
+	if err := encoding.ReadUint8(buf, &r.Step); err != nil {
+		return r, err
+	}
+
+	switch r.Kind {
+	case TraceRoundUpdate:
+		ru, err := unmarshalRoundUpdate(buf)
+		r.RoundUpdate = ru
+		return r, err
+	case TraceTimer:
+		var phase uint8
+		err := encoding.ReadUint8(buf, &phase)
+		r.Phase = Phase(phase)
+		return r, err
+	case TraceReceived, TraceDispatched, TraceAgreement:
+	default:
+		return r, fmt.Errorf("unknown trace record kind %d", kind)
+	}
+
+	var topic uint8
+	if err := encoding.ReadUint8(buf, &topic); err != nil {
+		return r, err
+	}
+	r.Topic = topics.Topic(topic)
+
+	if err := unmarshalTraceHeader(buf, &r.Header); err != nil {
+		return r, err
+	}
+
+	var serialized []byte
+	if err := encoding.ReadVarBytes(buf, &serialized); err != nil {
+		return r, err
+	}
+
+	if len(serialized) > 0 {
+		m, err := message.Unmarshal(bytes.NewBuffer(serialized), act)
+		if err != nil {
+			return r, err
+		}
+		r.Message = m
+	}
+
+	return r, nil
+}
+
+// marshalTraceHeader encodes a Header. Unlike header.Marshal, it accepts the
+// incomplete Headers of the internal events
+func marshalTraceHeader(buf *bytes.Buffer, h header.Header) error {
+	if err := encoding.WriteVarBytes(buf, h.PubKeyBLS); err != nil {
+		return err
+	}
+
+	if err := encoding.WriteUint64LE(buf, h.Round); err != nil {
+		return err
+	}
+
+	if err := encoding.WriteUint8(buf, h.Step); err != nil {
+		return err
+	}
+
+	return encoding.WriteVarBytes(buf, h.BlockHash)
+}
+
+func unmarshalTraceHeader(buf *bytes.Buffer, h *header.Header) error {
+	if err := encoding.ReadVarBytes(buf, &h.PubKeyBLS); err != nil {
+		return err
+	}
+
+	if err := encoding.ReadUint64LE(buf, &h.Round); err != nil {
+		return err
+	}
+
+	if err := encoding.ReadUint8(buf, &h.Step); err != nil {
+		return err
+	}
+
+	return encoding.ReadVarBytes(buf, &h.BlockHash)
+}
+
+func marshalRoundUpdate(buf *bytes.Buffer, ru RoundUpdate) error {
+	if err := encoding.WriteUint64LE(buf, ru.Round); err != nil {
+		return err
+	}
+
+	if err := user.MarshalProvisioners(buf, &ru.P); err != nil {
+		return err
+	}
+
+	if err := encoding.WriteVarInt(buf, uint64(len(ru.BidList))); err != nil {
+		return err
+	}
+
+	for _, bid := range ru.BidList {
+		if err := encoding.Write256(buf, bid.X[:]); err != nil {
+			return err
+		}
+
+		if err := encoding.Write256(buf, bid.M[:]); err != nil {
+			return err
+		}
+
+		if err := encoding.WriteUint64LE(buf, bid.EndHeight); err != nil {
+			return err
+		}
+	}
+
+	if err := encoding.WriteVarBytes(buf, ru.Seed); err != nil {
+		return err
+	}
+
+	if err := encoding.WriteVarBytes(buf, ru.Hash); err != nil {
+		return err
+	}
+
+	return encoding.WriteUint64LE(buf, ru.Activation.BitsetHeight)
+}
+
+func unmarshalRoundUpdate(buf *bytes.Buffer) (RoundUpdate, error) {
+	var ru RoundUpdate
+	if err := encoding.ReadUint64LE(buf, &ru.Round); err != nil {
+		return ru, err
+	}
+
+	p, err := user.UnmarshalProvisioners(buf)
+	if err != nil {
+		return ru, err
+	}
+	ru.P = p
+
+	bids, err := encoding.ReadVarInt(buf)
+	if err != nil {
+		return ru, err
+	}
+
+	ru.BidList = make(user.BidList, bids)
+	for i := range ru.BidList {
+		if err := encoding.Read256(buf, ru.BidList[i].X[:]); err != nil {
+			return ru, err
+		}
+
+		if err := encoding.Read256(buf, ru.BidList[i].M[:]); err != nil {
+			return ru, err
+		}
+
+		if err := encoding.ReadUint64LE(buf, &ru.BidList[i].EndHeight); err != nil {
+			return ru, err
+		}
+	}
+
+	if err := encoding.ReadVarBytes(buf, &ru.Seed); err != nil {
+		return ru, err
+	}
+
+	if err := encoding.ReadVarBytes(buf, &ru.Hash); err != nil {
+		return ru, err
+	}
+
+	return ru, encoding.ReadUint64LE(buf, &ru.Activation.BitsetHeight)
+}
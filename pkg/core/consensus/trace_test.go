@@ -0,0 +1,122 @@
+package consensus
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	crypto "github.com/dusk-network/dusk-crypto/hash"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTraceRoundtrip(t *testing.T) {
+	assert := assert.New(t)
+	p, keys := MockProvisioners(3)
+	ru := MockRoundUpdate(2, p, nil)
+	hash, _ := crypto.RandEntropy(32)
+	red := message.MockReduction(hash, 2, 1, keys)
+
+	records := []TraceRecord{
+		{Kind: TraceRoundUpdate, Round: 1, Step: 3, RoundUpdate: ru},
+		{Kind: TraceReceived, Round: 2, Step: 1, Topic: topics.Reduction, Header: red.State(), Message: message.New(topics.Reduction, red)},
+		{Kind: TraceDispatched, Round: 2, Step: 1, Topic: topics.Generation, Header: EmptyPacket().State()},
+		{Kind: TraceTimer, Round: 2, Step: 2, Phase: FirstReduction},
+	}
+
+	buf := new(bytes.Buffer)
+	w, err := NewTraceWriter(buf)
+	assert.NoError(err)
+	for _, r := range records {
+		w.Trace(r)
+	}
+
+	// simulate a crash in the middle of a write
+	complete := buf.Len()
+	w.Trace(records[1])
+	buf.Truncate(complete + 10)
+
+	reader, err := NewTraceReader(buf)
+	assert.NoError(err)
+	decoded := make([]TraceRecord, len(records))
+	for i, expected := range records {
+		decoded[i], err = reader.Next()
+		assert.NoError(err)
+		assert.True(expected.Equal(decoded[i]), decoded[i].String())
+		assert.Equal(expected.Round, decoded[i].Round)
+		assert.Equal(expected.Step, decoded[i].Step)
+		assert.Equal(expected.IsInput(), decoded[i].IsInput())
+	}
+
+	_, err = reader.Next()
+	assert.Equal(io.EOF, err)
+
+	// the RoundUpdate is recorded in full
+	r := decoded[0].RoundUpdate
+	assert.Equal(len(ru.P.Members), len(r.P.Members))
+	assert.Equal(ru.BidList, r.BidList)
+	assert.Equal(ru.Seed, r.Seed)
+}
+
+// Test that the trace of a previous run is renamed rather than overwritten.
+func TestCreateTraceRotates(t *testing.T) {
+	assert := assert.New(t)
+	dir, err := ioutil.TempDir("", "trace")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "consensus.trace")
+	record := TraceRecord{Kind: TraceTimer, Round: 2, Step: 2, Phase: FirstReduction}
+
+	w, err := CreateTrace(path)
+	assert.NoError(err)
+	w.Trace(record)
+	assert.NoError(w.Close())
+
+	w, err = CreateTrace(path)
+	assert.NoError(err)
+	assert.NoError(w.Close())
+
+	rotated, err := filepath.Glob(path + ".*")
+	assert.NoError(err)
+	if !assert.Len(rotated, 1) {
+		return
+	}
+
+	// the previous trace holds the record, the new one is empty
+	for file, expected := range map[string][]TraceRecord{rotated[0]: {record}, path: nil} {
+		f, err := os.Open(file)
+		assert.NoError(err)
+		reader, err := NewTraceReader(f)
+		assert.NoError(err)
+		for _, r := range expected {
+			decoded, err := reader.Next()
+			assert.NoError(err)
+			assert.True(r.Equal(decoded))
+		}
+
+		_, err = reader.Next()
+		assert.Equal(io.EOF, err)
+		_ = f.Close()
+	}
+}
+
+// Test that a record length above the maximum is refused before the record
+// is read.
+func TestTraceRecordTooBig(t *testing.T) {
+	buf := bytes.NewBuffer(append([]byte{}, traceMagic...))
+	var size [4]byte
+	binary.LittleEndian.PutUint32(size[:], maxTraceRecordSize+1)
+	buf.Write(size[:])
+
+	reader, err := NewTraceReader(buf)
+	assert.NoError(t, err)
+	_, err = reader.Next()
+	assert.Error(t, err)
+	assert.NotEqual(t, io.EOF, err)
+}
@@ -0,0 +1,47 @@
+package user
+
+import "sync"
+
+// committeeCache holds the VotingCommittees extracted from a provisioner set,
+// by round, step and size. It is safe for concurrent use.
+type committeeCache struct {
+	lock       sync.RWMutex
+	committees map[committeeKey]VotingCommittee
+}
+
+type committeeKey struct {
+	round uint64
+	step  uint8
+	size  int
+}
+
+func newCommitteeCache() *committeeCache {
+	return &committeeCache{
+		committees: make(map[committeeKey]VotingCommittee),
+	}
+}
+
+func (c *committeeCache) get(round uint64, step uint8, size int) (VotingCommittee, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	committee, found := c.committees[committeeKey{round, step, size}]
+	return committee, found
+}
+
+func (c *committeeCache) put(round uint64, step uint8, size int, committee VotingCommittee) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.committees[committeeKey{round, step, size}] = committee
+}
+
+// ResetCommittees attaches a new, empty committee cache to the Provisioners.
+// The copies of the Provisioners made from then on share the cache, so that
+// a committee is extracted only once by all of them, while older copies keep
+// the previous cache. Since the cache does not track changes to the set, it
+// must be reset whenever the set changes, which is when a new RoundUpdate is
+// sent.
+//
+// The cached committees are shared, and must not be modified.
+func (p *Provisioners) ResetCommittees() {
+	p.committees = newCommitteeCache()
+}
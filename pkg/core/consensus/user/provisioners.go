@@ -15,12 +15,20 @@ type (
 	Member struct {
 		PublicKeyBLS []byte
 		Stakes       []Stake
+		// RewardAddress is the public key of the wallet receiving the
+		// rewards of the provisioner. It is empty if the provisioner did
+		// not stake with one
+		RewardAddress []byte
 	}
 
 	// Provisioners is a map of Members, and makes up the current set of provisioners.
 	Provisioners struct {
 		Set     sortedset.Set
 		Members map[string]*Member
+
+		// committees caches the voting committees. It is nil unless
+		// ResetCommittees is called
+		committees *committeeCache
 	}
 
 	// Stake of the Provisioner
@@ -163,7 +171,7 @@ func marshalMember(r *bytes.Buffer, member Member) error {
 		}
 	}
 
-	return nil
+	return encoding.WriteVarBytes(r, member.RewardAddress)
 }
 
 func marshalStake(r *bytes.Buffer, stake Stake) error {
@@ -230,6 +238,10 @@ func unmarshalMember(r *bytes.Buffer) (*Member, error) {
 		}
 	}
 
+	if err := encoding.ReadVarBytes(r, &member.RewardAddress); err != nil {
+		return nil, err
+	}
+
 	return member, nil
 }
 
@@ -61,9 +61,26 @@ func generateSortitionScore(hash []byte, W *big.Int) uint64 {
 }
 
 // CreateVotingCommittee will run the deterministic sortition function, which determines
-// who will be in the committee for a given step and round.
-// FIXME: running this with weird setup causes infinite looping (to reproduce, hardcode `3` on MockProvisioners when calling agreement.NewHelper in the agreement tests)
+// who will be in the committee for a given step and round. If the Provisioners
+// carry a committee cache (see ResetCommittees), the committee is extracted
+// only once per round, step and size.
 func (p Provisioners) CreateVotingCommittee(round uint64, step uint8, size int) VotingCommittee {
+	if p.committees == nil {
+		return p.extractVotingCommittee(round, step, size)
+	}
+
+	if committee, found := p.committees.get(round, step, size); found {
+		return committee
+	}
+
+	committee := p.extractVotingCommittee(round, step, size)
+	p.committees.put(round, step, size, committee)
+	return committee
+}
+
+// extractVotingCommittee runs the sortition.
+// FIXME: running this with weird setup causes infinite looping (to reproduce, hardcode `3` on MockProvisioners when calling agreement.NewHelper in the agreement tests)
+func (p Provisioners) extractVotingCommittee(round uint64, step uint8, size int) VotingCommittee {
 	votingCommittee := newCommittee()
 	W := new(big.Int).SetUint64(p.TotalWeight())
 	// Deep copy the Members map, to avoid mutating the original set.
@@ -88,6 +105,7 @@ func (p Provisioners) CreateVotingCommittee(round uint64, step uint8, size int)
 		}
 	}
 
+	index := p.newStakeIndex()
 	for i := 0; votingCommittee.Size() < size; i++ {
 		if W.Uint64() == 0 {
 			// We ran out of staked DUSK, so we return the result prematurely
@@ -100,12 +118,13 @@ func (p Provisioners) CreateVotingCommittee(round uint64, step uint8, size int)
 		}
 
 		score := generateSortitionScore(hash, W)
-		blsPk := p.extractCommitteeMember(score)
-		votingCommittee.Insert(blsPk)
+		idx := index.search(score)
+		m := index.members[idx]
+		votingCommittee.Insert(m.PublicKeyBLS)
 
 		// Subtract up to one DUSK from the extracted committee member.
-		m := p.GetMember(blsPk)
 		subtracted := m.SubtractFromStake(1 * wallet.DUSK)
+		index.subtract(idx, subtracted)
 
 		// Also subtract the subtracted amount from the total weight, to ensure
 		// consistency.
@@ -115,35 +134,84 @@ func (p Provisioners) CreateVotingCommittee(round uint64, step uint8, size int)
 	return *votingCommittee
 }
 
-// extractCommitteeMember walks through the committee set, while deducting
-// each node's stake from the passed score until we reach zero. The public key
-// of the node that the function ends on will be returned as a hexadecimal string.
-func (p Provisioners) extractCommitteeMember(score uint64) []byte {
-	var m *Member
-	var e error
-	for i := 0; ; i++ {
-		if m, e = p.MemberAt(i); e != nil {
-			// handling the eventuality of an out of bound error
-			m, e = p.MemberAt(0)
-			if e != nil {
-				log.Panic(e)
-			}
+// stakeIndex holds the cumulative stakes of the provisioners, in the order of
+// the Set, as a Fenwick tree. It lets the sortition find the member on which
+// a score lands, and update the stake of the member, in logarithmic time,
+// instead of walking through the whole set for every extraction.
+type stakeIndex struct {
+	members []*Member
+	tree    []uint64
+}
+
+func (p Provisioners) newStakeIndex() *stakeIndex {
+	index := &stakeIndex{
+		members: make([]*Member, len(p.Set)),
+		tree:    make([]uint64, len(p.Set)+1),
+	}
+
+	for i, bigI := range p.Set {
+		m, found := p.Members[string(bigI.Bytes())]
+		if !found {
+			// A public key of the set is not among the members. We can't
+			// repair our committee on the fly, so we have to panic.
+			log.Panicf("public key %v not found among provisioner set", bigI.Bytes())
 		}
 
-		stake, err := p.GetStake(m.PublicKeyBLS)
-		if err != nil {
-			// If we get an error from GetStake, it means we either got a public key of a
-			// provisioner who is no longer in the set, or we got a malformed public key.
-			// We can't repair our committee on the fly, so we have to panic.
-			log.Panic(err)
+		index.members[i] = m
+		for _, stake := range m.Stakes {
+			index.tree[i+1] += stake.Amount
+		}
+	}
+
+	// build the tree in linear time, by pushing every partial sum to its parent
+	for i := 1; i < len(index.tree); i++ {
+		if parent := i + (i & -i); parent < len(index.tree) {
+			index.tree[parent] += index.tree[i]
 		}
+	}
+
+	return index
+}
 
-		if stake >= score {
-			return m.PublicKeyBLS
+// search returns the index of the member on which the score lands, that is
+// the first member whose cumulative stake is not lower than the score. As in
+// a walk through the set which deducts each stake from the score, a score
+// exceeding the total stake lands on the first member.
+func (s *stakeIndex) search(score uint64) int {
+	pos := 0
+	for step := highestPowerOfTwo(len(s.tree) - 1); step > 0; step >>= 1 {
+		if next := pos + step; next < len(s.tree) && s.tree[next] < score {
+			pos = next
+			score -= s.tree[next]
 		}
+	}
+
+	if pos >= len(s.members) {
+		return 0
+	}
 
-		score -= stake
+	return pos
+}
+
+// subtract an amount from the stake of the member at index i
+func (s *stakeIndex) subtract(i int, amount uint64) {
+	for i++; i < len(s.tree); i += i & -i {
+		s.tree[i] -= amount
+	}
+}
+
+// highestPowerOfTwo returns the highest power of two not greater than n
+func highestPowerOfTwo(n int) int {
+	if n == 0 {
+		return 0
+	}
+
+	p := 1
+	for p<<1 <= n {
+		p <<= 1
 	}
+
+	return p
 }
 
 // GenerateCommittees pre-generates an `amount` of VotingCommittee of a specified `size` from a given `step`
@@ -177,7 +245,8 @@ func copyMembers(members map[string]*Member) map[string]*Member {
 	m := make(map[string]*Member)
 	for k, v := range members {
 		member := &Member{
-			PublicKeyBLS: v.PublicKeyBLS,
+			PublicKeyBLS:  v.PublicKeyBLS,
+			RewardAddress: v.RewardAddress,
 		}
 
 		member.Stakes = append(member.Stakes, v.Stakes...)
@@ -0,0 +1,119 @@
+package user
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/wallet"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test that the stake index lands on the same members as a walk through the
+// set, also after the stakes are subtracted.
+func TestStakeIndexSearch(t *testing.T) {
+	p := randomProvisioners(rand.New(rand.NewSource(1)), 100)
+	index := p.newStakeIndex()
+	total := p.TotalWeight()
+
+	rng := rand.New(rand.NewSource(2))
+	for i := 0; i < 1000; i++ {
+		score := rng.Uint64() % (total + 1)
+		idx := index.search(score)
+		assert.Equal(t, p.walk(score), idx)
+
+		subtracted := index.members[idx].SubtractFromStake(1 * wallet.DUSK)
+		index.subtract(idx, subtracted)
+		total -= subtracted
+	}
+
+	// the scores landing on the boundaries of a stake
+	var cumulative uint64
+	for i, bigI := range p.Set {
+		stake, _ := p.GetStake(bigI.Bytes())
+		cumulative += stake
+		assert.Equal(t, p.walk(cumulative), index.search(cumulative), "member %d", i)
+		assert.Equal(t, p.walk(cumulative+1), index.search(cumulative+1), "member %d", i)
+	}
+
+	assert.Equal(t, 0, index.search(0))
+	assert.Equal(t, 0, index.search(total+1))
+}
+
+// Test that the sortition extracts the same committees as the sortition
+// walking through the set.
+func TestCreateVotingCommitteeWalk(t *testing.T) {
+	p := randomProvisioners(rand.New(rand.NewSource(1)), 100)
+	for step := uint8(1); step < 10; step++ {
+		committee := p.CreateVotingCommittee(10, step, 64)
+		assert.True(t, committee.Equal(p.createVotingCommitteeWalk(10, step, 64)))
+	}
+}
+
+func BenchmarkCreateVotingCommitteeWalk(b *testing.B) {
+	p := randomProvisioners(rand.New(rand.NewSource(1)), 1000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.createVotingCommitteeWalk(10, uint8(i), 64)
+	}
+}
+
+func BenchmarkCreateVotingCommitteeIndex(b *testing.B) {
+	p := randomProvisioners(rand.New(rand.NewSource(1)), 1000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.extractVotingCommittee(10, uint8(i), 64)
+	}
+}
+
+// createVotingCommitteeWalk is the sortition extracting every member by
+// walking through the set
+func (p Provisioners) createVotingCommitteeWalk(round uint64, step uint8, size int) VotingCommittee {
+	votingCommittee := newCommittee()
+	W := new(big.Int).SetUint64(p.TotalWeight())
+	p.Members = copyMembers(p.Members)
+	for i := 0; votingCommittee.Size() < size && W.Uint64() > 0; i++ {
+		hash, _ := createSortitionHash(round, step, i)
+		m, _ := p.MemberAt(p.walk(generateSortitionScore(hash, W)))
+		votingCommittee.Insert(m.PublicKeyBLS)
+		subtractFromTotalWeight(W, m.SubtractFromStake(1*wallet.DUSK))
+	}
+
+	return *votingCommittee
+}
+
+// walk through the set, deducting each stake from the score, and return the
+// index of the member on which the score lands
+func (p Provisioners) walk(score uint64) int {
+	for i := range p.Set {
+		m, _ := p.MemberAt(i)
+		stake, _ := p.GetStake(m.PublicKeyBLS)
+		if stake >= score {
+			return i
+		}
+
+		score -= stake
+	}
+
+	return 0
+}
+
+// randomProvisioners creates a set of provisioners staking between 1 and
+// 1000 DUSK
+func randomProvisioners(rng *rand.Rand, amount int) Provisioners {
+	p := NewProvisioners()
+	for i := 0; i < amount; i++ {
+		pk := make([]byte, 129)
+		_, _ = rng.Read(pk)
+		// avoid leading zeroes, which would not survive the sorted set
+		pk[0] |= 1
+
+		p.Members[string(pk)] = &Member{
+			PublicKeyBLS: pk,
+			Stakes:       []Stake{{Amount: uint64(1+rng.Intn(1000)) * wallet.DUSK, EndHeight: 1000}},
+		}
+		p.Set.Insert(pk)
+	}
+
+	return *p
+}
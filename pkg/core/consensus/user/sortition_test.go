@@ -94,3 +94,48 @@ func TestRemoveStake(t *testing.T) {
 	// Now, extract a committee for round 1 step 1
 	assert.NotPanics(t, func() { p.CreateVotingCommittee(1, 1, 10) })
 }
+
+// Test that the committees are cached once the cache is reset, and that the
+// cached committees equal the extracted ones.
+func TestCommitteeCache(t *testing.T) {
+	p, _ := consensus.MockProvisioners(10)
+	expected := p.CreateVotingCommittee(1, 1, 10)
+
+	p.ResetCommittees()
+	cached := p.CreateVotingCommittee(1, 1, 10)
+	assert.True(t, expected.Equal(&cached))
+
+	// copies of the provisioners share the cache, so the committee is
+	// returned even if the stakes changed
+	copied := *p
+	m, err := copied.MemberAt(0)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	m.AddStake(user.Stake{500000, 0, 10000})
+	committee := copied.CreateVotingCommittee(1, 1, 10)
+	assert.True(t, expected.Equal(&committee))
+
+	// once reset, the committee is extracted again, and the member now
+	// holding most of the stake is extracted more than once
+	p.ResetCommittees()
+	committee = p.CreateVotingCommittee(1, 1, 10)
+	assert.True(t, committee.OccurrencesOf(m.PublicKeyBLS) > 1)
+}
+
+func BenchmarkCreateVotingCommittee(b *testing.B) {
+	p, _ := consensus.MockProvisioners(1000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.CreateVotingCommittee(1, uint8(i%8), 64)
+	}
+}
+
+func BenchmarkCreateVotingCommitteeCached(b *testing.B) {
+	p, _ := consensus.MockProvisioners(1000)
+	p.ResetCommittees()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.CreateVotingCommittee(1, uint8(i%8), 64)
+	}
+}
@@ -0,0 +1,270 @@
+package consensus
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+)
+
+// walRecordSize is the size of a WAL record: round (8 bytes), step (1 byte)
+// and block hash (32 bytes)
+const walRecordSize = 8 + 1 + 32
+
+// walSeedFlag marks the round of a record holding a signed seed instead of a
+// vote. The record carries the digest of the seed in place of the block hash.
+const walSeedFlag = uint64(1) << 63
+
+// ErrDoubleSign is returned by WAL.Append when a different block hash has
+// already been signed for the same round and step
+type ErrDoubleSign struct {
+	Round  uint64
+	Step   uint8
+	Signed []byte
+	Hash   []byte
+}
+
+func (e ErrDoubleSign) Error() string {
+	return fmt.Sprintf("already signed hash %s at round %d step %d, refusing to sign %s",
+		hex.EncodeToString(e.Signed), e.Round, e.Step, hex.EncodeToString(e.Hash))
+}
+
+// ErrSeedDoubleSign is returned by WAL.AppendSeed when a seed has already
+// been signed for a later round, or a different seed for the same round
+type ErrSeedDoubleSign struct {
+	Round  uint64
+	Signed uint64
+}
+
+func (e ErrSeedDoubleSign) Error() string {
+	return fmt.Sprintf("already signed a seed at round %d, refusing to sign a seed at round %d", e.Signed, e.Round)
+}
+
+type walKey struct {
+	round uint64
+	step  uint8
+}
+
+// WAL is a write-ahead log of the votes signed by the node. Every vote is
+// durably recorded before the signature is produced, so that a node which
+// crashes and restarts mid-round never signs a different block hash for a
+// round and step it already voted on.
+//
+// The file is a sequence of fixed size records (see walRecordSize). A record
+// truncated by a crash is ignored, as the corresponding vote was never
+// signed.
+//
+// Seeds are signed once per round, with rounds only increasing, so only the
+// latest signed seed is kept.
+type WAL struct {
+	lock  sync.Mutex
+	path  string
+	file  *os.File
+	votes map[walKey][]byte
+
+	seedRound  uint64
+	seedDigest []byte
+}
+
+// OpenWAL loads the WAL stored at `path`, creating it if it does not exist
+func OpenWAL(path string) (*WAL, error) {
+	w := &WAL{
+		path:  path,
+		votes: make(map[walKey][]byte),
+	}
+
+	if err := w.load(); err != nil {
+		return nil, err
+	}
+
+	// rewriting the file drops a possibly truncated trailing record
+	if err := w.rewrite(); err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// Append records the vote for a block hash at a round and step. It is
+// idempotent, and fails with ErrDoubleSign if a different hash was already
+// recorded for the same round and step
+func (w *WAL) Append(round uint64, step uint8, hash []byte) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	k := walKey{round, step}
+	if signed, ok := w.votes[k]; ok {
+		if bytes.Equal(signed, hash) {
+			return nil
+		}
+
+		return ErrDoubleSign{Round: round, Step: step, Signed: signed, Hash: hash}
+	}
+
+	if _, err := w.file.Write(marshalWALRecord(round, step, hash)); err != nil {
+		return err
+	}
+
+	if err := w.file.Sync(); err != nil {
+		return err
+	}
+
+	w.votes[k] = hash
+	return nil
+}
+
+// AppendSeed records the seed signed at a round. It is idempotent, and fails
+// with ErrSeedDoubleSign if a seed was already recorded for a later round, or a
+// different seed for the same round
+func (w *WAL) AppendSeed(round uint64, seed []byte) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if round&walSeedFlag != 0 {
+		return fmt.Errorf("round %d out of range", round)
+	}
+
+	digest := sha256.Sum256(seed)
+	if w.seedDigest != nil {
+		if round == w.seedRound && bytes.Equal(w.seedDigest, digest[:]) {
+			return nil
+		}
+
+		if round <= w.seedRound {
+			return ErrSeedDoubleSign{Round: round, Signed: w.seedRound}
+		}
+	}
+
+	if _, err := w.file.Write(marshalWALRecord(round|walSeedFlag, 0, digest[:])); err != nil {
+		return err
+	}
+
+	if err := w.file.Sync(); err != nil {
+		return err
+	}
+
+	w.seedRound, w.seedDigest = round, digest[:]
+	return nil
+}
+
+// Prune forgets the votes for the rounds up to `round` included. It is
+// supposed to be called once the block at height `round` is accepted. The
+// latest signed seed is never forgotten.
+func (w *WAL) Prune(round uint64) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	pruned := false
+	for k := range w.votes {
+		if k.round <= round {
+			delete(w.votes, k)
+			pruned = true
+		}
+	}
+
+	if !pruned {
+		return nil
+	}
+
+	return w.rewrite()
+}
+
+// LastRound returns the highest round a vote is recorded for, or 0 if no vote
+// is recorded
+func (w *WAL) LastRound() uint64 {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	var last uint64
+	for k := range w.votes {
+		if k.round > last {
+			last = k.round
+		}
+	}
+	return last
+}
+
+// Close the WAL file
+func (w *WAL) Close() error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return w.file.Close()
+}
+
+func (w *WAL) load() error {
+	data, err := ioutil.ReadFile(w.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	for len(data) >= walRecordSize {
+		round := binary.LittleEndian.Uint64(data[:8])
+		step := data[8]
+		hash := make([]byte, 32)
+		copy(hash, data[9:walRecordSize])
+		data = data[walRecordSize:]
+
+		if round&walSeedFlag != 0 {
+			round &^= walSeedFlag
+			if w.seedDigest == nil || round >= w.seedRound {
+				w.seedRound, w.seedDigest = round, hash
+			}
+
+			continue
+		}
+
+		w.votes[walKey{round, step}] = hash
+	}
+
+	return nil
+}
+
+// rewrite replaces the WAL file with the votes currently in memory. As for
+// the mempool journal, the file is written to a temporary location and then
+// renamed, so that a crash does not corrupt it
+func (w *WAL) rewrite() error {
+	buf := new(bytes.Buffer)
+	for k, hash := range w.votes {
+		_, _ = buf.Write(marshalWALRecord(k.round, k.step, hash))
+	}
+
+	if w.seedDigest != nil {
+		_, _ = buf.Write(marshalWALRecord(w.seedRound|walSeedFlag, 0, w.seedDigest))
+	}
+
+	tmpPath := w.path + ".tmp"
+	if err := ioutil.WriteFile(tmpPath, buf.Bytes(), 0600); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmpPath, w.path); err != nil {
+		return err
+	}
+
+	if w.file != nil {
+		_ = w.file.Close()
+	}
+
+	f, err := os.OpenFile(w.path, os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+
+	w.file = f
+	return nil
+}
+
+func marshalWALRecord(round uint64, step uint8, hash []byte) []byte {
+	record := make([]byte, walRecordSize)
+	binary.LittleEndian.PutUint64(record[:8], round)
+	record[8] = step
+	copy(record[9:], hash)
+	return record
+}
@@ -0,0 +1,119 @@
+package consensus
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWALDoubleSign(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "consensus_wal")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "consensus.wal")
+	w, err := OpenWAL(path)
+	assert.NoError(err)
+
+	hash, otherHash := make([]byte, 32), make([]byte, 32)
+	otherHash[0] = 1
+
+	assert.NoError(w.Append(1, 2, hash))
+	// signing the same vote again is fine
+	assert.NoError(w.Append(1, 2, hash))
+	// a different step is a different vote
+	assert.NoError(w.Append(1, 3, otherHash))
+	assert.IsType(ErrDoubleSign{}, w.Append(1, 2, otherHash))
+	assert.NoError(w.Close())
+
+	// the votes survive a restart
+	w, err = OpenWAL(path)
+	assert.NoError(err)
+	assert.IsType(ErrDoubleSign{}, w.Append(1, 2, otherHash))
+	assert.IsType(ErrDoubleSign{}, w.Append(1, 3, hash))
+
+	// pruning forgets the votes of the accepted rounds
+	assert.NoError(w.Append(2, 2, hash))
+	assert.NoError(w.Prune(1))
+	assert.NoError(w.Append(1, 2, otherHash))
+	assert.IsType(ErrDoubleSign{}, w.Append(2, 2, otherHash))
+	assert.NoError(w.Close())
+}
+
+func TestWALTruncatedRecord(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "consensus_wal")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "consensus.wal")
+	w, err := OpenWAL(path)
+	assert.NoError(err)
+	assert.NoError(w.Append(1, 2, make([]byte, 32)))
+	assert.NoError(w.Close())
+
+	// simulate a crash in the middle of a write
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	assert.NoError(err)
+	_, err = f.Write(marshalWALRecord(1, 3, make([]byte, 32))[:walRecordSize/2])
+	assert.NoError(err)
+	assert.NoError(f.Close())
+
+	w, err = OpenWAL(path)
+	assert.NoError(err)
+	otherHash := make([]byte, 32)
+	otherHash[0] = 1
+	assert.IsType(ErrDoubleSign{}, w.Append(1, 2, otherHash))
+	// the truncated vote was never signed
+	assert.NoError(w.Append(1, 3, otherHash))
+	assert.NoError(w.Close())
+
+	info, err := os.Stat(path)
+	assert.NoError(err)
+	assert.Equal(int64(2*walRecordSize), info.Size())
+}
+
+func TestWALSeeds(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "consensus_wal")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "consensus.wal")
+	w, err := OpenWAL(path)
+	assert.NoError(err)
+
+	seed, otherSeed := make([]byte, 33), make([]byte, 33)
+	otherSeed[0] = 1
+
+	assert.NoError(w.AppendSeed(2, seed))
+	// signing the same seed again is fine
+	assert.NoError(w.AppendSeed(2, seed))
+	assert.IsType(ErrSeedDoubleSign{}, w.AppendSeed(2, otherSeed))
+	// rounds only increase
+	assert.IsType(ErrSeedDoubleSign{}, w.AppendSeed(1, otherSeed))
+	assert.NoError(w.AppendSeed(3, otherSeed))
+	assert.NoError(w.Close())
+
+	// the latest seed survives a restart and a prune
+	w, err = OpenWAL(path)
+	assert.NoError(err)
+	assert.NoError(w.Append(3, 2, make([]byte, 32)))
+	assert.NoError(w.Prune(3))
+	assert.IsType(ErrSeedDoubleSign{}, w.AppendSeed(2, seed))
+	assert.IsType(ErrSeedDoubleSign{}, w.AppendSeed(3, seed))
+	assert.NoError(w.AppendSeed(3, otherSeed))
+	assert.NoError(w.Close())
+
+	w, err = OpenWAL(path)
+	assert.NoError(err)
+	assert.IsType(ErrSeedDoubleSign{}, w.AppendSeed(3, seed))
+	assert.NoError(w.Close())
+}
@@ -0,0 +1,43 @@
+package block
+
+// Activation holds the heights from which the format of the blocks and of the
+// consensus messages changes on a network. They are part of the parameters of
+// the network, as the nodes need to agree on them from its genesis. The zero
+// value activates every change from the genesis.
+type Activation struct {
+	// BitsetHeight is the first height whose committees can have more than
+	// 64 members. From this height on, the committees are encoded as
+	// variable length bitsets, both in the Certificate of the blocks and in
+	// the StepVotes of the Agreement messages, while the earlier heights keep
+	// the uint64 bitmaps understood by the older nodes
+	BitsetHeight uint64
+	// RewardSplitHeight is the first height whose coinbase splits the block
+	// reward and the fees between the generator and the provisioners. The
+	// earlier heights reward the generator only, with a fixed amount. It is
+	// not lower than BitsetHeight
+	RewardSplitHeight uint64
+}
+
+// Bitset returns whether the committees of a height are encoded as variable
+// length bitsets
+func (a Activation) Bitset(height uint64) bool {
+	return height >= a.BitsetHeight
+}
+
+// RewardSplit returns whether the coinbase of a height splits the rewards
+// with the provisioners
+func (a Activation) RewardSplit(height uint64) bool {
+	return height >= a.RewardSplitHeight && a.Bitset(height)
+}
+
+// VersionAt returns the version of the blocks of a height
+func (a Activation) VersionAt(height uint64) uint8 {
+	switch {
+	case a.RewardSplit(height):
+		return CurrentVersion
+	case a.Bitset(height):
+		return BitsetVersion
+	default:
+		return LegacyVersion
+	}
+}
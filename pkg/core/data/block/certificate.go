@@ -2,15 +2,17 @@ package block
 
 import (
 	"bytes"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/sortedset"
 )
 
 // Certificate defines a block certificate made as a result from the consensus.
 type Certificate struct {
 	StepOneBatchedSig []byte // Batched BLS signature of the block reduction phase (33 bytes)
 	StepTwoBatchedSig []byte
-	Step              uint8  // Step the agreement terminated at (1 byte)
-	StepOneCommittee  uint64 // Binary representation of the committee members who voted in favor of this block (8 bytes)
-	StepTwoCommittee  uint64
+	Step              uint8            // Step the agreement terminated at (1 byte)
+	StepOneCommittee  sortedset.Bitset // Binary representation of the committee members who voted in favor of this block (8 bytes up to block version 0, variable afterwards)
+	StepTwoCommittee  sortedset.Bitset
 }
 
 // EmptyCertificate returns an empty Certificate instance
@@ -19,8 +21,8 @@ func EmptyCertificate() *Certificate {
 		StepOneBatchedSig: make([]byte, 33),
 		StepTwoBatchedSig: make([]byte, 33),
 		Step:              0,
-		StepOneCommittee:  0,
-		StepTwoCommittee:  0,
+		StepOneCommittee:  sortedset.Bitset{},
+		StepTwoCommittee:  sortedset.Bitset{},
 	}
 }
 
@@ -42,11 +44,11 @@ func (c *Certificate) Equals(other *Certificate) bool {
 		return false
 	}
 
-	if c.StepOneCommittee != other.StepOneCommittee {
+	if !c.StepOneCommittee.Equal(other.StepOneCommittee) {
 		return false
 	}
 
-	if c.StepTwoCommittee != other.StepTwoCommittee {
+	if !c.StepTwoCommittee.Equal(other.StepTwoCommittee) {
 		return false
 	}
 
@@ -12,6 +12,18 @@ const (
 	HeaderHashSize = 32
 	// HeightSize size of a block height field in bytes
 	HeightSize = 8
+
+	// LegacyVersion is the version of the blocks whose Certificate
+	// represents the committees as uint64 bitmaps, which limits them to 64
+	// members
+	LegacyVersion uint8 = 0
+	// BitsetVersion is the version of the blocks whose Certificate represents
+	// the committees as variable length bitsets
+	BitsetVersion uint8 = 1
+	// CurrentVersion is the latest version of the blocks. Their coinbase
+	// splits the block reward and the fees between the generator and the
+	// provisioners
+	CurrentVersion uint8 = 2
 )
 
 // Header defines a block header on a Dusk block.
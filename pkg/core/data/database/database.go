@@ -2,6 +2,8 @@ package database
 
 import (
 	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
 	"encoding/binary"
 	"errors"
 	"fmt"
@@ -16,9 +18,16 @@ import (
 	"github.com/syndtr/goleveldb/leveldb/util"
 )
 
-// DB encapsulates a leveldb.DB storage
+// DB encapsulates a leveldb.DB storage. The inputs, the tx records and the
+// key images are encrypted, once the DB is unlocked with the wallet password
 type DB struct {
 	storage *leveldb.DB
+
+	// key encrypts the records. It is nil until the DB is unlocked
+	key *recordKey
+	// unlockedWith is the blinded password which unlocked the DB, so that
+	// unlocking it again with the same password skips the key derivation
+	unlockedWith []byte
 }
 
 var (
@@ -26,6 +35,10 @@ var (
 	walletHeightPrefix = []byte{0x01}
 	txRecordPrefix     = []byte{0x02}
 	keyImagePrefix     = []byte{0x03}
+	recordKeyPrefix    = []byte{0x04}
+	// pendingKeyPrefix holds the record key sealed with a new password,
+	// until the password change is committed
+	pendingKeyPrefix = []byte{0x05}
 
 	writeOptions = &opt.WriteOptions{NoWriteMerge: false, Sync: true}
 )
@@ -39,13 +52,263 @@ func New(path string) (*DB, error) {
 	return &DB{storage: db}, nil
 }
 
+// Unlock the records of the DB with the wallet password. A DB without a
+// record key is given a new one, sealed with the password. The records it
+// holds, written in plaintext by the previous versions of the wallet, are
+// encrypted in the same batch.
+// If a password change was staged but not committed, the new password
+// unlocks the DB as well, and the change is committed
+func (db *DB) Unlock(password []byte) error {
+	if db.key != nil && hmac.Equal(db.key.blind(password), db.unlockedWith) {
+		return nil
+	}
+
+	sealedKey, err := db.storage.Get(recordKeyPrefix, nil)
+	if err == leveldb.ErrNotFound {
+		return db.migrate(password)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	key, err := openKey(sealedKey, password)
+	if err == ErrWrongPassword {
+		return db.CommitPassword(password)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	// The current password wins over a password change left half-way
+	if err := db.DiscardPassword(); err != nil {
+		return err
+	}
+
+	rk, err := newRecordKey(key)
+	if err != nil {
+		return err
+	}
+
+	db.setKey(rk, password)
+	return nil
+}
+
+// ChangePassword seals the record key of the DB with a new password. The
+// records are left untouched
+func (db *DB) ChangePassword(oldPassword, newPassword []byte) error {
+	sealedKey, err := db.storage.Get(recordKeyPrefix, nil)
+	if err != nil {
+		return err
+	}
+
+	key, err := openKey(sealedKey, oldPassword)
+	if err != nil {
+		return err
+	}
+
+	rk, err := newRecordKey(key)
+	if err != nil {
+		return err
+	}
+
+	resealedKey, err := sealKey(key, newPassword)
+	if err != nil {
+		return err
+	}
+
+	if err := db.storage.Put(recordKeyPrefix, resealedKey, writeOptions); err != nil {
+		return err
+	}
+
+	db.setKey(rk, newPassword)
+	return nil
+}
+
+// StagePassword seals the record key of the DB with a new password, aside of
+// the current one. Until CommitPassword or DiscardPassword are called, both
+// passwords unlock the DB. This way, the DB key and the seed file can be
+// resealed one after the other without being locked out if the node stops
+// in between
+func (db *DB) StagePassword(oldPassword, newPassword []byte) error {
+	sealedKey, err := db.storage.Get(recordKeyPrefix, nil)
+	if err != nil {
+		return err
+	}
+
+	key, err := openKey(sealedKey, oldPassword)
+	if err != nil {
+		return err
+	}
+
+	resealedKey, err := sealKey(key, newPassword)
+	if err != nil {
+		return err
+	}
+
+	return db.storage.Put(pendingKeyPrefix, resealedKey, writeOptions)
+}
+
+// CommitPassword replaces the record key sealed with the current password by
+// the one staged with `newPassword`. It returns ErrWrongPassword if there is
+// no staged key, or if it is sealed with another password
+func (db *DB) CommitPassword(newPassword []byte) error {
+	sealedKey, err := db.storage.Get(pendingKeyPrefix, nil)
+	if err == leveldb.ErrNotFound {
+		return ErrWrongPassword
+	}
+
+	if err != nil {
+		return err
+	}
+
+	key, err := openKey(sealedKey, newPassword)
+	if err != nil {
+		return err
+	}
+
+	rk, err := newRecordKey(key)
+	if err != nil {
+		return err
+	}
+
+	b := new(leveldb.Batch)
+	b.Put(recordKeyPrefix, sealedKey)
+	b.Delete(pendingKeyPrefix)
+	if err := db.storage.Write(b, writeOptions); err != nil {
+		return err
+	}
+
+	db.setKey(rk, newPassword)
+	return nil
+}
+
+// DiscardPassword drops the record key staged with a new password, if any
+func (db *DB) DiscardPassword() error {
+	staged, err := db.storage.Has(pendingKeyPrefix, nil)
+	if err != nil || !staged {
+		return err
+	}
+
+	return db.storage.Delete(pendingKeyPrefix, writeOptions)
+}
+
+func (db *DB) setKey(rk *recordKey, password []byte) {
+	db.key = rk
+	db.unlockedWith = rk.blind(password)
+}
+
+// migrate creates a new record key, and encrypts the plaintext records with
+// it
+func (db *DB) migrate(password []byte) error {
+	key := make([]byte, keySize)
+	if _, err := rand.Read(key); err != nil {
+		return err
+	}
+
+	rk, err := newRecordKey(key)
+	if err != nil {
+		return err
+	}
+
+	sealedKey, err := sealKey(key, password)
+	if err != nil {
+		return err
+	}
+
+	b := new(leveldb.Batch)
+	b.Put(recordKeyPrefix, sealedKey)
+
+	// The inputs are stored at their public key, the key images at the key
+	// image, and the tx records are stored in the key itself
+	for _, prefix := range [][]byte{inputPrefix, keyImagePrefix, txRecordPrefix} {
+		if err := db.migratePrefix(b, rk, prefix); err != nil {
+			return err
+		}
+	}
+
+	if err := db.storage.Write(b, writeOptions); err != nil {
+		return err
+	}
+
+	db.setKey(rk, password)
+	return nil
+}
+
+func (db *DB) migratePrefix(b *leveldb.Batch, rk *recordKey, prefix []byte) error {
+	iter := db.storage.NewIterator(util.BytesPrefix(prefix), nil)
+	defer iter.Release()
+
+	for iter.Next() {
+		id := make([]byte, len(iter.Key())-len(prefix))
+		copy(id, iter.Key()[len(prefix):])
+
+		value := make([]byte, len(iter.Value()))
+		copy(value, iter.Value())
+		if bytes.Equal(prefix, txRecordPrefix) {
+			value = id
+		}
+
+		key := prefixedKey(prefix, rk.blind(id))
+		encrypted, err := rk.encrypt(value, key)
+		if err != nil {
+			return err
+		}
+
+		b.Delete(iter.Key())
+		b.Put(key, encrypted)
+	}
+
+	return iter.Error()
+}
+
+// prefixedKey returns the storage key of a record, identified by `id`
+func prefixedKey(prefix, id []byte) []byte {
+	key := make([]byte, 0, len(prefix)+len(id))
+	key = append(key, prefix...)
+	return append(key, id...)
+}
+
+// recordKey returns the storage key of the record identified by a secret
+func (db *DB) recordKey(prefix, secret []byte) ([]byte, error) {
+	if db.key == nil {
+		return nil, ErrLocked
+	}
+
+	return prefixedKey(prefix, db.key.blind(secret)), nil
+}
+
+// putRecord encrypts and stores a record
+func (db *DB) putRecord(storageKey, value []byte) error {
+	if db.key == nil {
+		return ErrLocked
+	}
+
+	encrypted, err := db.key.encrypt(value, storageKey)
+	if err != nil {
+		return err
+	}
+
+	return db.Put(storageKey, encrypted)
+}
+
+// decryptRecord decrypts a record stored at the storage key
+func (db *DB) decryptRecord(storageKey, value []byte) ([]byte, error) {
+	if db.key == nil {
+		return nil, ErrLocked
+	}
+
+	return db.key.decrypt(value, storageKey)
+}
+
 // Put inserts a key and a value in the storage
 func (db *DB) Put(key, value []byte) error {
 	return db.storage.Put(key, value, nil)
 }
 
 // PutInput inserts a UTXO input (i.e. a Ristretto point) in the DB
-func (db *DB) PutInput(encryptionKey []byte, pubkey ristretto.Point, amount, mask, privKey ristretto.Scalar, unlockHeight uint64) error {
+func (db *DB) PutInput(pubkey ristretto.Point, amount, mask, privKey ristretto.Scalar, unlockHeight uint64) error {
 
 	buf := &bytes.Buffer{}
 	idb := &inputDB{
@@ -59,20 +322,25 @@ func (db *DB) PutInput(encryptionKey []byte, pubkey ristretto.Point, amount, mas
 		return err
 	}
 
-	encryptedBytes, err := encrypt(buf.Bytes(), encryptionKey)
+	key, err := db.recordKey(inputPrefix, pubkey.Bytes())
 	if err != nil {
 		return err
 	}
 
-	key := append(inputPrefix, pubkey.Bytes()...)
-
-	return db.Put(key, encryptedBytes)
+	return db.putRecord(key, buf.Bytes())
 }
 
 // RemoveInput removes a UTXO input from the DB
 func (db *DB) RemoveInput(pubkey []byte, keyImage []byte) error {
-	inputKey := append(inputPrefix, pubkey...)
-	keyImageKey := append(keyImagePrefix, keyImage...)
+	inputKey, err := db.recordKey(inputPrefix, pubkey)
+	if err != nil {
+		return err
+	}
+
+	keyImageKey, err := db.recordKey(keyImagePrefix, keyImage)
+	if err != nil {
+		return err
+	}
 
 	b := new(leveldb.Batch)
 	b.Delete(inputKey)
@@ -81,9 +349,32 @@ func (db *DB) RemoveInput(pubkey []byte, keyImage []byte) error {
 	return db.storage.Write(b, writeOptions)
 }
 
-// FetchInputs fetches transaction inputs amounting to the specified amount.
-// Inputs are decrypted using a decryption key (view key)
-func (db *DB) FetchInputs(decryptionKey []byte, amount int64) ([]*transactions.Input, int64, error) {
+// InputRecord is an unspent output of the wallet, as stored in the DB.
+// The PrivKey of the records of a watch-only wallet is the offset of the one
+// time private key (see key.Key.OneTimeKeyOffset)
+type InputRecord struct {
+	Amount, Mask, PrivKey ristretto.Scalar
+}
+
+// FetchInputs fetches transaction inputs amounting to the specified amount
+func (db *DB) FetchInputs(amount int64) ([]*transactions.Input, int64, error) {
+	records, changeAmount, err := db.FetchInputRecords(amount)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// convert the records to transaction inputs
+	var tInputs []*transactions.Input
+	for _, r := range records {
+		tInputs = append(tInputs, transactions.NewInput(r.Amount, r.Mask, r.PrivKey))
+	}
+
+	return tInputs, changeAmount, nil
+}
+
+// FetchInputRecords fetches the records of the unlocked inputs amounting to
+// the specified amount. It returns the change amount along with them
+func (db *DB) FetchInputRecords(amount int64) ([]InputRecord, int64, error) {
 
 	var inputs []*inputDB
 
@@ -92,12 +383,7 @@ func (db *DB) FetchInputs(decryptionKey []byte, amount int64) ([]*transactions.I
 	iter := db.storage.NewIterator(util.BytesPrefix(inputPrefix), nil)
 	defer iter.Release()
 	for iter.Next() {
-		val := iter.Value()
-
-		encryptedBytes := make([]byte, len(val))
-		copy(encryptedBytes[:], val)
-
-		decryptedBytes, err := decrypt(encryptedBytes, decryptionKey)
+		decryptedBytes, err := db.decryptRecord(iter.Key(), iter.Value())
 		if err != nil {
 			return nil, 0, err
 		}
@@ -135,17 +421,16 @@ func (db *DB) FetchInputs(decryptionKey []byte, amount int64) ([]*transactions.I
 		changeAmount = -totalAmount
 	}
 
-	// convert inputDb to transaction input
-	var tInputs []*transactions.Input
-	for _, input := range inputs {
-		tInputs = append(tInputs, transactions.NewInput(input.amount, input.mask, input.privKey))
+	records := make([]InputRecord, len(inputs))
+	for i, input := range inputs {
+		records[i] = InputRecord{Amount: input.amount, Mask: input.mask, PrivKey: input.privKey}
 	}
 
-	return tInputs, changeAmount, nil
+	return records, changeAmount, nil
 }
 
 // FetchBalance calculates the balance
-func (db *DB) FetchBalance(decryptionKey []byte) (uint64, uint64, error) {
+func (db *DB) FetchBalance() (uint64, uint64, error) {
 	var unlockedBalance ristretto.Scalar
 	unlockedBalance.SetZero()
 	var lockedBalance ristretto.Scalar
@@ -154,12 +439,7 @@ func (db *DB) FetchBalance(decryptionKey []byte) (uint64, uint64, error) {
 	iter := db.storage.NewIterator(util.BytesPrefix(inputPrefix), nil)
 	defer iter.Release()
 	for iter.Next() {
-		val := iter.Value()
-
-		encryptedBytes := make([]byte, len(val))
-		copy(encryptedBytes[:], val)
-
-		decryptedBytes, err := decrypt(encryptedBytes, decryptionKey)
+		decryptedBytes, err := db.decryptRecord(iter.Key(), iter.Value())
 		if err != nil {
 			return 0, 0, err
 		}
@@ -190,16 +470,11 @@ func (db *DB) FetchBalance(decryptionKey []byte) (uint64, uint64, error) {
 // UpdateLockedInputs will set the lockheight for an input to 0 if the
 // given `height` is greater or equal than the input lockheight,
 // signifying that this input is unlocked.
-func (db *DB) UpdateLockedInputs(decryptionKey []byte, height uint64) error {
+func (db *DB) UpdateLockedInputs(height uint64) error {
 	iter := db.storage.NewIterator(util.BytesPrefix(inputPrefix), nil)
 	defer iter.Release()
 	for iter.Next() {
-		val := iter.Value()
-
-		encryptedBytes := make([]byte, len(val))
-		copy(encryptedBytes[:], val)
-
-		decryptedBytes, err := decrypt(encryptedBytes, decryptionKey)
+		decryptedBytes, err := db.decryptRecord(iter.Key(), iter.Value())
 		if err != nil {
 			return err
 		}
@@ -219,12 +494,9 @@ func (db *DB) UpdateLockedInputs(decryptionKey []byte, height uint64) error {
 				return err
 			}
 
-			encryptedBytes, err := encrypt(buf.Bytes(), decryptionKey)
-			if err != nil {
-				return err
-			}
-
-			if err := db.Put(iter.Key(), encryptedBytes); err != nil {
+			key := make([]byte, len(iter.Key()))
+			copy(key, iter.Key())
+			if err := db.putRecord(key, buf.Bytes()); err != nil {
 				return err
 			}
 		}
@@ -233,6 +505,47 @@ func (db *DB) UpdateLockedInputs(decryptionKey []byte, height uint64) error {
 	return iter.Error()
 }
 
+// LowerUnlockHeight brings forward the unlock height of the input with the
+// public key `pubkey` to `unlockHeight`, if it unlocks later. Inputs which are
+// spent or unlocked already are left alone
+func (db *DB) LowerUnlockHeight(pubkey []byte, unlockHeight uint64) error {
+	key, err := db.recordKey(inputPrefix, pubkey)
+	if err != nil {
+		return err
+	}
+
+	value, err := db.storage.Get(key, nil)
+	if err == leveldb.ErrNotFound {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	decryptedBytes, err := db.decryptRecord(key, value)
+	if err != nil {
+		return err
+	}
+
+	idb := &inputDB{}
+	if err := idb.Decode(bytes.NewBuffer(decryptedBytes)); err != nil {
+		return err
+	}
+
+	if idb.unlockHeight <= unlockHeight {
+		return nil
+	}
+
+	idb.unlockHeight = unlockHeight
+	buf := new(bytes.Buffer)
+	if err := idb.Encode(buf); err != nil {
+		return err
+	}
+
+	return db.putRecord(key, buf.Bytes())
+}
+
 // GetWalletHeight returns the height of the blockchain known to the wallet
 func (db *DB) GetWalletHeight() (uint64, error) {
 	heightBytes, err := db.storage.Get(walletHeightPrefix, nil)
@@ -273,11 +586,10 @@ func (db *DB) FetchTxRecords() ([]txrecords.TxRecord, error) {
 	defer iter.Release()
 
 	for iter.Next() {
-		// record is the key without the prefix
-		val := iter.Key()[1:]
-
-		bs := make([]byte, len(val))
-		copy(bs[:], val)
+		bs, err := db.decryptRecord(iter.Key(), iter.Value())
+		if err != nil {
+			return nil, err
+		}
 
 		txRecord := txrecords.TxRecord{}
 
@@ -296,8 +608,8 @@ func (db *DB) FetchTxRecords() ([]txrecords.TxRecord, error) {
 func (db *DB) PutTxRecord(tx transactions.Transaction, direction txrecords.Direction, privView *key.PrivateView) error {
 	// Schema
 	//
-	// key: txRecordPrefix + record
-	// value: 0
+	// key: txRecordPrefix + blinded record
+	// value: encrypted record
 	buf := new(bytes.Buffer)
 	height, err := db.GetWalletHeight()
 	if err != nil {
@@ -309,33 +621,50 @@ func (db *DB) PutTxRecord(tx transactions.Transaction, direction txrecords.Direc
 		return err
 	}
 
-	key := make([]byte, 0)
-	key = append(key, txRecordPrefix...)
-	key = append(key, buf.Bytes()...)
+	key, err := db.recordKey(txRecordPrefix, buf.Bytes())
+	if err != nil {
+		return err
+	}
 
-	value := make([]byte, 1)
-	return db.Put(key, value)
+	return db.putRecord(key, buf.Bytes())
 }
 
 // PutKeyImage saves the transaction's key image used to prevent double
 // spending
 func (db *DB) PutKeyImage(keyImage []byte, outputKey []byte) error {
-	key := append(keyImagePrefix, keyImage...)
-	return db.Put(key, outputKey)
+	key, err := db.recordKey(keyImagePrefix, keyImage)
+	if err != nil {
+		return err
+	}
+
+	return db.putRecord(key, outputKey)
 }
 
 // GetPubKey returns the public key retrieved through the specifie key image
 func (db *DB) GetPubKey(keyImage []byte) ([]byte, error) {
-	key := append(keyImagePrefix, keyImage...)
-	return db.Get(key)
+	key, err := db.recordKey(keyImagePrefix, keyImage)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := db.Get(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return db.decryptRecord(key, value)
 }
 
-// Clear all information from the database.
+// Clear all information from the database, except for the sealed record keys.
 func (db *DB) Clear() error {
 	iter := db.storage.NewIterator(nil, nil)
 	defer iter.Release()
 
 	for iter.Next() {
+		if bytes.Equal(iter.Key(), recordKeyPrefix) || bytes.Equal(iter.Key(), pendingKeyPrefix) {
+			continue
+		}
+
 		if err := db.Delete(iter.Key()); err != nil {
 			return err
 		}
@@ -3,6 +3,7 @@ package database
 import (
 	"bytes"
 	"encoding/hex"
+	"math/big"
 	"math/rand"
 	"os"
 	"testing"
@@ -61,19 +62,25 @@ func TestUnlockInputs(t *testing.T) {
 	// Make sure to delete this dir after test
 	defer os.RemoveAll(path)
 
+	assert.NoError(t, db.Unlock([]byte("pass")))
+
 	input := randInput()
+	input.amount.SetBigInt(big.NewInt(500))
 	// This input unlocks at height 1000
 	input.unlockHeight = 1000
 
 	// Put it in the DB
 	var pubKey ristretto.Point
 	pubKey.Rand()
-	assert.NoError(t, db.PutInput([]byte{0}, pubKey, input.amount, input.mask, input.privKey, input.unlockHeight))
+	assert.NoError(t, db.PutInput(pubKey, input.amount, input.mask, input.privKey, input.unlockHeight))
 
 	// Fetch it and ensure the unlock height is set
-	key := append(inputPrefix, pubKey.Bytes()...)
+	key, err := db.recordKey(inputPrefix, pubKey.Bytes())
+	assert.NoError(t, err)
 	value, err := db.Get(key)
 	assert.NoError(t, err)
+	value, err = db.decryptRecord(key, value)
+	assert.NoError(t, err)
 
 	decoded := &inputDB{}
 	decoded.Decode(bytes.NewBuffer(value))
@@ -81,15 +88,24 @@ func TestUnlockInputs(t *testing.T) {
 	assert.Equal(t, uint64(1000), decoded.unlockHeight)
 
 	// Now run UpdateLockedInputs
-	assert.NoError(t, db.UpdateLockedInputs([]byte{0}, 1000))
+	assert.NoError(t, db.UpdateLockedInputs(1000))
 
 	value, err = db.Get(key)
 	assert.NoError(t, err)
+	value, err = db.decryptRecord(key, value)
+	assert.NoError(t, err)
 
 	decoded = &inputDB{}
 	decoded.Decode(bytes.NewBuffer(value))
 
 	assert.Equal(t, uint64(0), decoded.unlockHeight)
+
+	// The unlocked input can now be spent
+	records, changeAmount, err := db.FetchInputRecords(200)
+	assert.NoError(t, err)
+	assert.Len(t, records, 1)
+	assert.Equal(t, input.privKey, records[0].PrivKey)
+	assert.Equal(t, int64(300), changeAmount)
 }
 
 func TestPutFetchTxRecord(t *testing.T) {
@@ -100,6 +116,7 @@ func TestPutFetchTxRecord(t *testing.T) {
 	}
 
 	db.UpdateWalletHeight(20)
+	assert.NoError(t, db.Unlock([]byte("pass")))
 
 	// Make sure to delete this dir after test
 	defer os.RemoveAll(path)
@@ -172,6 +189,191 @@ func TestClear(t *testing.T) {
 	assert.Error(t, err)
 }
 
+// Test that the raw LevelDB keys and values do not leak the secrets of the
+// wallet.
+func TestEncryptedRecords(t *testing.T) {
+	db, err := New(path)
+	assert.NoError(t, err)
+	defer os.RemoveAll(path)
+
+	// The records can not be written before unlocking the DB
+	var pubKey ristretto.Point
+	pubKey.Rand()
+	input := randInput()
+	assert.Equal(t, ErrLocked, db.PutInput(pubKey, input.amount, input.mask, input.privKey, 0))
+
+	assert.NoError(t, db.UpdateWalletHeight(20))
+	assert.NoError(t, db.Unlock([]byte("pass")))
+	secrets := putRecords(t, db, pubKey, input)
+
+	assertNoLeak(t, db, secrets)
+
+	// The records can be read back
+	unlocked, _, err := db.FetchBalance()
+	assert.NoError(t, err)
+	assert.Equal(t, input.amount.BigInt().Uint64(), unlocked)
+
+	outputKey, err := db.GetPubKey(secrets[3])
+	assert.NoError(t, err)
+	assert.Equal(t, pubKey.Bytes(), outputKey)
+
+	records, err := db.FetchTxRecords()
+	assert.NoError(t, err)
+	assert.Len(t, records, 1)
+}
+
+// Test that the DB can only be unlocked with the password it was first
+// unlocked with.
+func TestUnlockWrongPassword(t *testing.T) {
+	db, err := New(path)
+	assert.NoError(t, err)
+	defer os.RemoveAll(path)
+
+	assert.NoError(t, db.Unlock([]byte("pass")))
+	var pubKey ristretto.Point
+	pubKey.Rand()
+	input := randInput()
+	assert.NoError(t, db.PutInput(pubKey, input.amount, input.mask, input.privKey, 0))
+
+	// Close and re-open database
+	assert.NoError(t, db.Close())
+	db, err = New(path)
+	assert.NoError(t, err)
+
+	assert.Equal(t, ErrWrongPassword, db.Unlock([]byte("wrongPass")))
+	_, _, err = db.FetchBalance()
+	assert.Equal(t, ErrLocked, err)
+
+	assert.NoError(t, db.Unlock([]byte("pass")))
+	unlocked, _, err := db.FetchBalance()
+	assert.NoError(t, err)
+	assert.Equal(t, input.amount.BigInt().Uint64(), unlocked)
+
+	// Clearing the DB keeps the key
+	assert.NoError(t, db.Clear())
+	assert.NoError(t, db.Close())
+	db, err = New(path)
+	assert.NoError(t, err)
+	assert.Equal(t, ErrWrongPassword, db.Unlock([]byte("wrongPass")))
+}
+
+// Test that a staged password change survives a restart, and that it is
+// committed by the new password or discarded by the old one.
+func TestStagePassword(t *testing.T) {
+	db, err := New(path)
+	assert.NoError(t, err)
+	defer os.RemoveAll(path)
+
+	assert.NoError(t, db.Unlock([]byte("pass")))
+	assert.Equal(t, ErrWrongPassword, db.StagePassword([]byte("wrongPass"), []byte("newPass")))
+	assert.NoError(t, db.StagePassword([]byte("pass"), []byte("newPass")))
+
+	reopen := func() {
+		assert.NoError(t, db.Close())
+		db, err = New(path)
+		assert.NoError(t, err)
+	}
+
+	// The old password discards the staged change
+	reopen()
+	assert.NoError(t, db.Unlock([]byte("pass")))
+	reopen()
+	assert.Equal(t, ErrWrongPassword, db.Unlock([]byte("newPass")))
+
+	// The new password commits it
+	assert.NoError(t, db.StagePassword([]byte("pass"), []byte("newPass")))
+	reopen()
+	assert.NoError(t, db.Unlock([]byte("newPass")))
+	reopen()
+	assert.Equal(t, ErrWrongPassword, db.Unlock([]byte("pass")))
+	assert.NoError(t, db.Unlock([]byte("newPass")))
+	assert.NoError(t, db.Close())
+}
+
+// Test that the plaintext records of a DB written by a previous version of
+// the wallet are encrypted on the first unlock.
+func TestMigratePlaintextRecords(t *testing.T) {
+	db, err := New(path)
+	assert.NoError(t, err)
+	defer os.RemoveAll(path)
+
+	// Write the records as the previous versions did
+	var pubKey ristretto.Point
+	pubKey.Rand()
+	input := randInput()
+	buf := new(bytes.Buffer)
+	assert.NoError(t, input.Encode(buf))
+	assert.NoError(t, db.Put(append([]byte{}, append(inputPrefix, pubKey.Bytes()...)...), buf.Bytes()))
+
+	keyImage := make([]byte, 32)
+	rand.Read(keyImage)
+	assert.NoError(t, db.Put(append([]byte{}, append(keyImagePrefix, keyImage...)...), pubKey.Bytes()))
+
+	assert.NoError(t, db.UpdateWalletHeight(20))
+	tx, privView := randTxForRecord(transactions.StandardType)
+	record := new(bytes.Buffer)
+	assert.NoError(t, txrecords.Encode(record, txrecords.New(tx, 20, txrecords.In, privView)))
+	assert.NoError(t, db.Put(append([]byte{}, append(txRecordPrefix, record.Bytes()...)...), []byte{0}))
+
+	assert.NoError(t, db.Unlock([]byte("pass")))
+
+	assertNoLeak(t, db, [][]byte{input.amount.Bytes(), input.mask.Bytes(), input.privKey.Bytes(), keyImage, pubKey.Bytes(), record.Bytes()})
+
+	// The records are still there
+	unlocked, _, err := db.FetchBalance()
+	assert.NoError(t, err)
+	assert.Equal(t, input.amount.BigInt().Uint64(), unlocked)
+
+	outputKey, err := db.GetPubKey(keyImage)
+	assert.NoError(t, err)
+	assert.Equal(t, pubKey.Bytes(), outputKey)
+
+	records, err := db.FetchTxRecords()
+	assert.NoError(t, err)
+	assert.Len(t, records, 1)
+
+	// The migration happens only once
+	assert.NoError(t, db.Close())
+	db, err = New(path)
+	assert.NoError(t, err)
+	assert.NoError(t, db.Unlock([]byte("pass")))
+	unlocked, _, err = db.FetchBalance()
+	assert.NoError(t, err)
+	assert.Equal(t, input.amount.BigInt().Uint64(), unlocked)
+}
+
+// putRecords writes an input, a key image and a tx record, and returns the
+// secrets they carry
+func putRecords(t *testing.T, db *DB, pubKey ristretto.Point, input *inputDB) [][]byte {
+	assert.NoError(t, db.PutInput(pubKey, input.amount, input.mask, input.privKey, 0))
+
+	keyImage := make([]byte, 32)
+	rand.Read(keyImage)
+	assert.NoError(t, db.PutKeyImage(keyImage, pubKey.Bytes()))
+
+	tx, privView := randTxForRecord(transactions.StandardType)
+	assert.NoError(t, db.PutTxRecord(tx, txrecords.In, privView))
+	recipient := tx.StandardTx().Outputs[0].PubKey.P.Bytes()
+
+	return [][]byte{input.amount.Bytes(), input.mask.Bytes(), input.privKey.Bytes(), keyImage, pubKey.Bytes(), recipient, []byte(hex.EncodeToString(recipient))}
+}
+
+// assertNoLeak checks that none of the secrets appear in the raw keys and
+// values of the DB
+func assertNoLeak(t *testing.T, db *DB, secrets [][]byte) {
+	iter := db.storage.NewIterator(nil, nil)
+	defer iter.Release()
+
+	for iter.Next() {
+		for _, secret := range secrets {
+			assert.False(t, bytes.Contains(iter.Key(), secret))
+			assert.False(t, bytes.Contains(iter.Value(), secret))
+		}
+	}
+
+	assert.NoError(t, iter.Error())
+}
+
 func randInput() *inputDB {
 	var amount, mask, privKey ristretto.Scalar
 	amount.Rand()
@@ -1,9 +1,153 @@
 package database
 
-func encrypt(data []byte, passphrase []byte) ([]byte, error) {
-	return data, nil
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"errors"
+	"io"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/sha3"
+)
+
+// keyVersion is the version of the scheme sealing the record key with the
+// wallet password
+const keyVersion byte = 1
+
+const (
+	keySize  = 32
+	saltSize = 16
+
+	// Argon2id parameters of keyVersion
+	kdfTime    = 1
+	kdfMemory  = 64 * 1024
+	kdfThreads = 4
+)
+
+var (
+	// ErrWrongPassword is returned when the wallet password does not unlock
+	// the database
+	ErrWrongPassword = errors.New("wrong wallet password")
+
+	// ErrLocked is returned when accessing the records of a database which
+	// was not unlocked
+	ErrLocked = errors.New("wallet database is locked")
+)
+
+// recordKey encrypts the records of the wallet with AES-256-GCM, and blinds
+// the secrets used as storage keys with HMAC-SHA3-256. Both keys are derived
+// from a random key, sealed in the database with the wallet password
+type recordKey struct {
+	aead     cipher.AEAD
+	blindKey []byte
+}
+
+func newRecordKey(key []byte) (*recordKey, error) {
+	aead, err := newAEAD(mac(key, []byte("encryption")))
+	if err != nil {
+		return nil, err
+	}
+
+	return &recordKey{aead: aead, blindKey: mac(key, []byte("blinding"))}, nil
+}
+
+// encrypt a record, binding it to the storage key it is stored at
+func (k *recordKey) encrypt(data, storageKey []byte) ([]byte, error) {
+	return seal(k.aead, data, storageKey)
+}
+
+// decrypt a record stored at the storage key
+func (k *recordKey) decrypt(data, storageKey []byte) ([]byte, error) {
+	return open(k.aead, data, storageKey)
+}
+
+// blind returns the keyed hash of data, stored in place of it
+func (k *recordKey) blind(data []byte) []byte {
+	return mac(k.blindKey, data)
+}
+
+// sealKey encrypts the record key with a key derived from the password and a
+// random salt. The result is prefixed by the version and the salt
+func sealKey(key, password []byte) ([]byte, error) {
+	salt := make([]byte, saltSize)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, err
+	}
+
+	aead, err := newAEAD(passwordKey(password, salt))
+	if err != nil {
+		return nil, err
+	}
+
+	header := append([]byte{keyVersion}, salt...)
+	sealed, err := seal(aead, key, header)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(header, sealed...), nil
+}
+
+// openKey decrypts the record key sealed with the password
+func openKey(sealedKey, password []byte) ([]byte, error) {
+	if len(sealedKey) < 1+saltSize {
+		return nil, errors.New("sealed wallet database key is too short")
+	}
+
+	if sealedKey[0] != keyVersion {
+		return nil, errors.New("unsupported wallet database key version")
+	}
+
+	header, sealed := sealedKey[:1+saltSize], sealedKey[1+saltSize:]
+	aead, err := newAEAD(passwordKey(password, header[1:]))
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := open(aead, sealed, header)
+	if err != nil {
+		return nil, ErrWrongPassword
+	}
+
+	return key, nil
+}
+
+func passwordKey(password, salt []byte) []byte {
+	return argon2.IDKey(password, salt, kdfTime, kdfMemory, kdfThreads, keySize)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	c, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(c)
+}
+
+// seal data with a random nonce, prepended to the ciphertext
+func seal(aead cipher.AEAD, data, additionalData []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, data, additionalData), nil
+}
+
+func open(aead cipher.AEAD, data, additionalData []byte) ([]byte, error) {
+	nonceSize := aead.NonceSize()
+	if len(data) < nonceSize {
+		return nil, errors.New("encrypted record is too short")
+	}
+
+	return aead.Open(nil, data[:nonceSize], data[nonceSize:], additionalData)
 }
 
-func decrypt(data []byte, passphrase []byte) ([]byte, error) {
-	return data, nil
+func mac(key, data []byte) []byte {
+	h := hmac.New(sha3.New256, key)
+	_, _ = h.Write(data)
+	return h.Sum(nil)
 }
@@ -2,6 +2,7 @@ package key
 
 import (
 	"errors"
+	"fmt"
 
 	ristretto "github.com/bwesterb/go-ristretto"
 )
@@ -23,6 +24,53 @@ func NewKeyPair(seed []byte) *Key {
 	}
 }
 
+// ViewKeySize is the size of a marshaled view key: the PublicSpend followed
+// by the PrivateView
+const ViewKeySize = 64
+
+// NewViewKey returns a watch-only key. It detects the outputs sent to the
+// public key, and decrypts their amounts, but can not spend them
+func NewViewKey(pubSpend *PublicSpend, privView *PrivateView) *Key {
+	return &Key{
+		&PrivateKey{privView: privView},
+		&PublicKey{pubSpend, privView.PublicView()},
+	}
+}
+
+// ViewKeyFromBytes unmarshals a watch-only key from the bytes returned by
+// Key.ViewKey
+func ViewKeyFromBytes(b []byte) (*Key, error) {
+	if len(b) != ViewKeySize {
+		return nil, fmt.Errorf("view key is %d bytes long instead of %d", len(b), ViewKeySize)
+	}
+
+	var publicSpendBytes, privateViewBytes [32]byte
+	copy(publicSpendBytes[:], b[:32])
+	copy(privateViewBytes[:], b[32:])
+
+	pubSpend, err := pubSpendFromBytes(publicSpendBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	var s ristretto.Scalar
+	s.SetBytes(&privateViewBytes)
+	privView := PrivateView(s)
+	return NewViewKey(pubSpend, &privView), nil
+}
+
+// ViewKey returns the PublicSpend followed by the PrivateView, from which a
+// watch-only key is created
+func (k Key) ViewKey() []byte {
+	return concatSlice(k.PublicKey().PubSpend.Bytes(), k.privKey.privView.Bytes())
+}
+
+// CanSpend returns false for the watch-only keys, which do not hold the
+// private spend key
+func (k Key) CanSpend() bool {
+	return k.privKey.privSpend != nil
+}
+
 // PublicKey returns the corresponding public key pair
 func (k Key) PublicKey() *PublicKey {
 	if k.pubKey != nil {
@@ -59,8 +107,61 @@ func (k Key) PrivateSpend() (*PrivateSpend, error) {
 // DidReceiveTx takes P the stealthAddress/ one time pubkey
 // and the tx pubkey R
 // checks whether the tx was intended for the key assosciated
+// It returns the one time private key of the output, hence it always fails
+// for a watch-only key. Use IsRecipient instead
 func (k *Key) DidReceiveTx(R ristretto.Point, stealth StealthAddress, index uint32) (*ristretto.Scalar, bool) {
+	if !k.CanSpend() {
+		return nil, false
+	}
+
+	fprime, ok := k.recipientScalar(R, stealth, index)
+	if !ok {
+		return nil, false
+	}
+
+	x := fprime.Add(fprime, k.privKey.privSpend.scalar())
+	return x, true
+}
+
+// IsRecipient checks whether the tx was intended for the key assosciated,
+// without deriving the one time private key. It only needs the private view
+// key
+func (k *Key) IsRecipient(R ristretto.Point, stealth StealthAddress, index uint32) bool {
+	_, ok := k.recipientScalar(R, stealth, index)
+	return ok
+}
+
+// OneTimeKeyOffset returns f' = H(aR || index), if the output was intended
+// for the key. The one time private key of the output is f' + b, hence a
+// watch-only key hands the offset to the spending key, which completes it with
+// OneTimeKey
+func (k *Key) OneTimeKeyOffset(R ristretto.Point, stealth StealthAddress, index uint32) (*ristretto.Scalar, bool) {
+	return k.recipientScalar(R, stealth, index)
+}
+
+// OneTimeKey returns the one time private key f' + b of an output, from the
+// offset returned by OneTimeKeyOffset
+func (k *Key) OneTimeKey(offset ristretto.Scalar) (*ristretto.Scalar, error) {
+	if !k.CanSpend() {
+		return nil, errors.New("private spend key is nil")
+	}
+
+	var x ristretto.Scalar
+	x.Add(&offset, k.privKey.privSpend.scalar())
+	return &x, nil
+}
+
+// OneTimePubKey returns the one time public key P = f'G + B of an output, from
+// the offset returned by OneTimeKeyOffset
+func (k *Key) OneTimePubKey(offset ristretto.Scalar) ristretto.Point {
+	var F, P ristretto.Point
+	F.ScalarMultBase(&offset)
+	P.Add(&F, k.PublicKey().PubSpend.point())
+	return P
+}
 
+// recipientScalar returns f' = H(aR || index), if P = f'G + B
+func (k *Key) recipientScalar(R ristretto.Point, stealth StealthAddress, index uint32) (*ristretto.Scalar, bool) {
 	pubKey := k.PublicKey()
 
 	var Dprime ristretto.Point
@@ -76,8 +177,7 @@ func (k *Key) DidReceiveTx(R ristretto.Point, stealth StealthAddress, index uint
 	Pprime := Fprime.Add(pubKey.PubSpend.point(), &Fprime)
 
 	if stealth.P.Equals(Pprime) {
-		x := fprime.Add(&fprime, k.privKey.privSpend.scalar())
-		return x, true
+		return &fprime, true
 	}
 	return nil, false
 }
@@ -38,3 +38,66 @@ func TestDidReceive(t *testing.T) {
 	assert.True(t, expectedPubKey0.Equals(&pubKey0.P))
 	assert.True(t, expectedPubKey1.Equals(&pubKey1.P))
 }
+
+func TestViewKey(t *testing.T) {
+	k := NewKeyPair([]byte("this is the seed"))
+
+	viewKey, err := ViewKeyFromBytes(k.ViewKey())
+	assert.NoError(t, err)
+	assert.False(t, viewKey.CanSpend())
+	assert.True(t, k.CanSpend())
+	assert.Equal(t, k.PublicKey().Bytes(), viewKey.PublicKey().Bytes())
+
+	_, err = viewKey.PrivateSpend()
+	assert.Error(t, err)
+
+	var r ristretto.Scalar
+	r.Rand()
+
+	var R ristretto.Point
+	R.ScalarMultBase(&r)
+
+	pubKey0 := k.PublicKey().StealthAddress(r, 0)
+	assert.True(t, viewKey.IsRecipient(R, *pubKey0, 0))
+	assert.False(t, viewKey.IsRecipient(R, *pubKey0, 1))
+
+	// The one time private key can not be derived without the private spend
+	// key
+	_, ok := viewKey.DidReceiveTx(R, *pubKey0, 0)
+	assert.False(t, ok)
+
+	_, err = ViewKeyFromBytes(k.ViewKey()[1:])
+	assert.Error(t, err)
+}
+
+// Test that the one time key of an output is completed from the offset found
+// by a view key.
+func TestOneTimeKeyOffset(t *testing.T) {
+	k := NewKeyPair([]byte("this is the seed"))
+	viewKey, err := ViewKeyFromBytes(k.ViewKey())
+	assert.NoError(t, err)
+
+	var r ristretto.Scalar
+	r.Rand()
+
+	var R ristretto.Point
+	R.ScalarMultBase(&r)
+
+	pubKey0 := k.PublicKey().StealthAddress(r, 0)
+	offset, ok := viewKey.OneTimeKeyOffset(R, *pubKey0, 0)
+	assert.True(t, ok)
+	_, ok = viewKey.OneTimeKeyOffset(R, *pubKey0, 1)
+	assert.False(t, ok)
+
+	P := viewKey.OneTimePubKey(*offset)
+	assert.True(t, P.Equals(&pubKey0.P))
+
+	_, err = viewKey.OneTimeKey(*offset)
+	assert.Error(t, err)
+
+	privKey, err := k.OneTimeKey(*offset)
+	assert.NoError(t, err)
+	expected, ok := k.DidReceiveTx(R, *pubKey0, 0)
+	assert.True(t, ok)
+	assert.Equal(t, expected.Bytes(), privKey.Bytes())
+}
@@ -5,6 +5,7 @@ import (
 
 	"encoding/binary"
 	"errors"
+	"fmt"
 
 	ristretto "github.com/bwesterb/go-ristretto"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/base58"
@@ -75,6 +76,42 @@ func (pa PublicAddress) ToKey(netPrefix byte) (*PublicKey, error) {
 	}, nil
 }
 
+// PublicKeySize is the size of a marshaled PublicKey: the PublicSpend
+// followed by the PublicView
+const PublicKeySize = 64
+
+// PublicKeyFromBytes unmarshals a PublicKey from the bytes returned by
+// PublicKey.Bytes
+func PublicKeyFromBytes(b []byte) (*PublicKey, error) {
+	if len(b) != PublicKeySize {
+		return nil, fmt.Errorf("public key is %d bytes long instead of %d", len(b), PublicKeySize)
+	}
+
+	var publicSpendBytes, publicViewBytes [32]byte
+	copy(publicSpendBytes[:], b[:32])
+	copy(publicViewBytes[:], b[32:])
+
+	pubSpend, err := pubSpendFromBytes(publicSpendBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	pubView, err := pubViewFromBytes(publicViewBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PublicKey{
+		pubSpend,
+		pubView,
+	}, nil
+}
+
+// Bytes returns the PublicSpend followed by the PublicView
+func (k *PublicKey) Bytes() []byte {
+	return concatSlice(k.PubSpend.Bytes(), k.PubView.Bytes())
+}
+
 // StealthAddress Returns P, R
 // P = H(r* PubView || Index)G + Pubspend = (H(r * PubView || Index) + privSpend)G
 func (k *PublicKey) StealthAddress(r ristretto.Scalar, index uint32) *StealthAddress {
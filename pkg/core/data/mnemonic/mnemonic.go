@@ -0,0 +1,179 @@
+// Package mnemonic encodes the wallet seeds into a list of words, which can
+// be written down as a backup of the wallet.
+//
+// The encoding follows BIP-0039: the seed is followed by the first
+// len(seed)*8/32 bits of its SHA-256 digest, and each group of 11 bits is
+// mapped to a word of the English word list. Unlike BIP-0039, the 64 bytes
+// seeds of the wallet are accepted, and encoded into 48 words. The words are
+// decoded back into the seed itself, without any key stretching.
+package mnemonic
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	bitsPerWord = 11
+
+	minSeedSize = 16
+	maxSeedSize = 64
+)
+
+var (
+	// ErrInvalidSeed is returned when encoding a seed of unsupported length
+	ErrInvalidSeed = fmt.Errorf("seed length must be a multiple of 4 bytes, between %d and %d bytes", minSeedSize, maxSeedSize)
+
+	// ErrWordCount is returned when decoding a mnemonic with a wrong number
+	// of words
+	ErrWordCount = errors.New("mnemonic must contain a multiple of 3 words, between 12 and 48")
+
+	// ErrChecksum is returned when the words of a mnemonic do not match its
+	// checksum. A word is likely misspelled into another, or out of order
+	ErrChecksum = errors.New("mnemonic checksum mismatch, check the spelling and the order of the words")
+)
+
+// UnknownWordError is returned when decoding a mnemonic with a word which is
+// not in the word list
+type UnknownWordError struct {
+	// Position of the word in the mnemonic, starting from 1
+	Position int
+	Word     string
+	// Suggestion is the closest word of the list, if any
+	Suggestion string
+}
+
+func (e *UnknownWordError) Error() string {
+	if e.Suggestion != "" {
+		return fmt.Sprintf("word %d %q is not in the word list, did you mean %q?", e.Position, e.Word, e.Suggestion)
+	}
+
+	return fmt.Sprintf("word %d %q is not in the word list", e.Position, e.Word)
+}
+
+var wordIndex = make(map[string]int, len(words))
+
+func init() {
+	for i, w := range words {
+		wordIndex[w] = i
+	}
+}
+
+// FromSeed encodes a seed into a space separated list of words
+func FromSeed(seed []byte) (string, error) {
+	if len(seed)%4 != 0 || len(seed) < minSeedSize || len(seed) > maxSeedSize {
+		return "", ErrInvalidSeed
+	}
+
+	digest := sha256.Sum256(seed)
+	data := append(append([]byte{}, seed...), digest[:]...)
+
+	entropyBits := len(seed) * 8
+	numWords := (entropyBits + entropyBits/32) / bitsPerWord
+	phrase := make([]string, numWords)
+	for i := range phrase {
+		index := 0
+		for j := 0; j < bitsPerWord; j++ {
+			index = index<<1 | bit(data, i*bitsPerWord+j)
+		}
+
+		phrase[i] = words[index]
+	}
+
+	return strings.Join(phrase, " "), nil
+}
+
+// ToSeed decodes a list of words into the seed it encodes. The words are
+// case insensitive, and can be separated by any whitespace
+func ToSeed(mnemonic string) ([]byte, error) {
+	phrase := strings.Fields(strings.ToLower(mnemonic))
+	if len(phrase)%3 != 0 || len(phrase) < 12 || len(phrase) > 48 {
+		return nil, ErrWordCount
+	}
+
+	totalBits := len(phrase) * bitsPerWord
+	data := make([]byte, (totalBits+7)/8)
+	for i, w := range phrase {
+		index, ok := wordIndex[w]
+		if !ok {
+			return nil, &UnknownWordError{Position: i + 1, Word: w, Suggestion: suggest(w)}
+		}
+
+		for j := 0; j < bitsPerWord; j++ {
+			if index&(1<<uint(bitsPerWord-1-j)) != 0 {
+				setBit(data, i*bitsPerWord+j)
+			}
+		}
+	}
+
+	checksumBits := totalBits / 33
+	entropyBits := totalBits - checksumBits
+	seed := data[:entropyBits/8]
+
+	digest := sha256.Sum256(seed)
+	for i := 0; i < checksumBits; i++ {
+		if bit(data, entropyBits+i) != bit(digest[:], i) {
+			return nil, ErrChecksum
+		}
+	}
+
+	return seed, nil
+}
+
+func bit(data []byte, i int) int {
+	return int(data[i/8]>>uint(7-i%8)) & 1
+}
+
+func setBit(data []byte, i int) {
+	data[i/8] |= 1 << uint(7-i%8)
+}
+
+// suggest returns the word of the list closest to `w`, within two edits
+func suggest(w string) string {
+	best, bestDistance := "", 3
+	for _, candidate := range words {
+		if d := distance(w, candidate); d < bestDistance {
+			best, bestDistance = candidate, d
+		}
+	}
+
+	return best
+}
+
+// distance is the Levenshtein distance between two words
+func distance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+
+		prev, cur = cur, prev
+	}
+
+	return prev[len(b)]
+}
+
+func min(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+
+	return m
+}
@@ -0,0 +1,89 @@
+package mnemonic
+
+import (
+	"bytes"
+	"crypto/rand"
+	"hash/crc32"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test that the word list is the one of BIP-0039.
+func TestWordList(t *testing.T) {
+	assert.Len(t, words, 2048)
+	assert.Equal(t, uint32(0xc1dbd296), crc32.ChecksumIEEE([]byte(english)))
+}
+
+// Test the encoding against the vectors of BIP-0039.
+func TestVectors(t *testing.T) {
+	phrase, err := FromSeed(make([]byte, 16))
+	assert.NoError(t, err)
+	assert.Equal(t, strings.Repeat("abandon ", 11)+"about", phrase)
+
+	phrase, err = FromSeed(make([]byte, 32))
+	assert.NoError(t, err)
+	assert.Equal(t, strings.Repeat("abandon ", 23)+"art", phrase)
+
+	seed, err := ToSeed("legal winner thank year wave sausage worth useful legal winner thank yellow")
+	assert.NoError(t, err)
+	assert.Equal(t, bytes.Repeat([]byte{0x7f}, 16), seed)
+}
+
+func TestEncodeDecodeSeed(t *testing.T) {
+	for size := minSeedSize; size <= maxSeedSize; size += 4 {
+		seed := make([]byte, size)
+		_, _ = rand.Read(seed)
+
+		phrase, err := FromSeed(seed)
+		assert.NoError(t, err)
+		assert.Len(t, strings.Fields(phrase), size*3/4)
+
+		decoded, err := ToSeed(phrase)
+		assert.NoError(t, err)
+		assert.Equal(t, seed, decoded)
+	}
+
+	// Wallet seeds are encoded into 48 words
+	phrase, err := FromSeed(make([]byte, 64))
+	assert.NoError(t, err)
+	assert.Len(t, strings.Fields(phrase), 48)
+
+	_, err = FromSeed(make([]byte, 65))
+	assert.Equal(t, ErrInvalidSeed, err)
+}
+
+// Test that the words are case insensitive and separated by any whitespace.
+func TestDecodeFormatting(t *testing.T) {
+	seed, err := ToSeed("  Abandon abandon\tabandon abandon\nabandon abandon abandon abandon abandon abandon abandon ABOUT ")
+	assert.NoError(t, err)
+	assert.Equal(t, make([]byte, 16), seed)
+}
+
+func TestDecodeTypos(t *testing.T) {
+	seed := make([]byte, 64)
+	for i := range seed {
+		seed[i] = byte(i)
+	}
+
+	phrase, err := FromSeed(seed)
+	assert.NoError(t, err)
+	list := strings.Fields(phrase)
+
+	// Missing word
+	_, err = ToSeed(strings.Join(list[1:], " "))
+	assert.Equal(t, ErrWordCount, err)
+
+	// Misspelled word
+	misspelled := append([]string{}, list...)
+	misspelled[4] = "abandn"
+	_, err = ToSeed(strings.Join(misspelled, " "))
+	assert.Equal(t, &UnknownWordError{Position: 5, Word: "abandn", Suggestion: "abandon"}, err)
+
+	// Swapped words
+	swapped := append([]string{}, list...)
+	swapped[0], swapped[1] = swapped[1], swapped[0]
+	_, err = ToSeed(strings.Join(swapped, " "))
+	assert.Equal(t, ErrChecksum, err)
+}
@@ -11,7 +11,14 @@ import (
 	"github.com/bwesterb/go-ristretto"
 )
 
-// Coinbase transaction
+// Coinbase transaction. The first reward goes to the block generator, and the
+// following ones to the provisioners who voted in the certificate of the
+// previous block.
+//
+// The stealth address of the generator is derived from the secret nonce of the
+// transaction, as for any other tx. Instead, the stealth addresses of the
+// provisioners are derived from a nonce which anybody can compute (see
+// ProvisionerNonce), so that the distribution of the rewards can be verified.
 type Coinbase struct {
 	//// Encoded fields
 	TxType
@@ -63,6 +70,50 @@ func (c *Coinbase) AddReward(pubKey key.PublicKey, amount ristretto.Scalar) erro
 	return nil
 }
 
+// AddProvisionerReward adds the reward of a provisioner to the coinbase
+// outputs. It must be called after the reward of the generator is added
+func (c *Coinbase) AddProvisionerReward(pubKey key.PublicKey, amount ristretto.Scalar) error {
+	if len(c.Rewards) == 0 {
+		return errors.New("the generator reward must come first")
+	}
+
+	if len(c.Rewards)+1 > maxOutputs {
+		return errors.New("maximum amount of outputs reached")
+	}
+
+	output := &Output{
+		PubKey:          *pubKey.StealthAddress(c.ProvisionerNonce(), uint32(len(c.Rewards))),
+		EncryptedAmount: amount,
+	}
+
+	c.Rewards = append(c.Rewards, output)
+	c.index = uint32(len(c.Rewards))
+	return nil
+}
+
+// ProvisionerNonce returns the nonce from which the stealth addresses of the
+// provisioner rewards are derived. It is the hash of the public key of the
+// transaction
+func (c *Coinbase) ProvisionerNonce() ristretto.Scalar {
+	var r ristretto.Scalar
+	r.Derive(append([]byte("provisioner rewards"), c.R.Bytes()...))
+	return r
+}
+
+// TxPubKeyAt returns the public key of the transaction for the reward at
+// index `i`, to be used in place of R when checking if the reward was
+// received
+func (c *Coinbase) TxPubKeyAt(i int) ristretto.Point {
+	if i == 0 {
+		return c.R
+	}
+
+	r := c.ProvisionerNonce()
+	var R ristretto.Point
+	R.ScalarMultBase(&r)
+	return R
+}
+
 // SetTxPubKey sets the public key of the transaction
 func (c *Coinbase) SetTxPubKey(r ristretto.Scalar) {
 	c.r = r
@@ -0,0 +1,42 @@
+package transactions
+
+import (
+	"math"
+	"math/bits"
+)
+
+// FeeRateUnit is the number of bytes a fee rate refers to. Fee rates are
+// expressed in DUSK units per kB of marshaled tx.
+const FeeRateUnit = 1000
+
+// FeeRate returns the fee rate of a tx paying `fee` for `size` bytes. The
+// rate is rounded down.
+func FeeRate(fee, size uint64) uint64 {
+	if size == 0 {
+		return 0
+	}
+
+	hi, lo := bits.Mul64(fee, FeeRateUnit)
+	if hi >= size {
+		return math.MaxUint64
+	}
+
+	rate, _ := bits.Div64(hi, lo, size)
+	return rate
+}
+
+// FeeForRate returns the fee a tx of `size` bytes pays at the fee rate
+// `rate`. The fee is rounded up, so that the tx pays at least the rate.
+func FeeForRate(rate, size uint64) uint64 {
+	hi, lo := bits.Mul64(rate, size)
+	if hi >= FeeRateUnit {
+		return math.MaxUint64
+	}
+
+	fee, rem := bits.Div64(hi, lo, FeeRateUnit)
+	if rem > 0 {
+		fee++
+	}
+
+	return fee
+}
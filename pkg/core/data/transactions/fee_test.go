@@ -0,0 +1,30 @@
+package transactions
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeeRate(t *testing.T) {
+	assert.Equal(t, uint64(50), FeeRate(100, 2000))
+	// Rounded down
+	assert.Equal(t, uint64(33), FeeRate(100, 3000))
+	assert.Equal(t, uint64(0), FeeRate(100, 0))
+	assert.Equal(t, uint64(math.MaxUint64), FeeRate(math.MaxUint64, 1))
+}
+
+func TestFeeForRate(t *testing.T) {
+	assert.Equal(t, uint64(100), FeeForRate(50, 2000))
+	// Rounded up
+	assert.Equal(t, uint64(100), FeeForRate(33, 3001))
+	assert.Equal(t, uint64(0), FeeForRate(0, 3000))
+	assert.Equal(t, uint64(math.MaxUint64), FeeForRate(math.MaxUint64, 2000))
+
+	// The fee for the rate of a tx pays at least that rate
+	for _, size := range []uint64{1, 999, 1000, 2873, 100000} {
+		fee := FeeForRate(37, size)
+		assert.True(t, FeeRate(fee, size) >= 37)
+	}
+}
@@ -0,0 +1,96 @@
+package transactions
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/dusk-network/dusk-crypto/hash"
+)
+
+// Slash encapsulates a transaction reporting a provisioner which equivocated.
+// Once included in a block, the stake of the offender is reduced
+type Slash struct {
+	*Standard
+	// Evidence is the serialized equivocation evidence (see message.Evidence).
+	// It is kept opaque here, as its verification belongs to the consensus
+	Evidence []byte
+}
+
+// NewSlash creates a new Slash transaction
+func NewSlash(ver uint8, netPrefix byte, fee int64, evidence []byte) (*Slash, error) {
+	tx, err := NewStandard(ver, netPrefix, fee)
+	if err != nil {
+		return nil, err
+	}
+
+	tx.TxType = SlashType
+	return &Slash{
+		tx,
+		evidence,
+	}, nil
+}
+
+// CalculateHash calculates the hash of this transaction
+func (s *Slash) CalculateHash() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := marshalSlash(buf, s); err != nil {
+		return nil, err
+	}
+
+	txid, err := hash.Sha3256(buf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	return txid, nil
+}
+
+// StandardTx returns the Standard transaction
+func (s *Slash) StandardTx() *Standard {
+	return s.Standard
+}
+
+// Type returns the transaction type
+func (s *Slash) Type() TxType {
+	return s.TxType
+}
+
+// Prove the transaction
+func (s *Slash) Prove() error {
+	return s.prove(s.CalculateHash, true)
+}
+
+// Equals test the transactions equality
+func (s *Slash) Equals(t Transaction) bool {
+	other, ok := t.(*Slash)
+	if !ok {
+		return false
+	}
+
+	if !s.Standard.Equals(other.Standard) {
+		return false
+	}
+
+	return bytes.Equal(s.Evidence, other.Evidence)
+}
+
+// LockTime returns 0 since Slash is not a time locked transaction
+func (s *Slash) LockTime() uint64 {
+	return 0
+}
+
+func marshalSlash(b *bytes.Buffer, s *Slash) error {
+	if err := marshalStandard(b, s.Standard); err != nil {
+		return err
+	}
+
+	if err := writeVarInt(b, uint64(len(s.Evidence))); err != nil {
+		return err
+	}
+
+	if err := binary.Write(b, binary.BigEndian, s.Evidence); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -7,10 +7,19 @@ import (
 	"github.com/dusk-network/dusk-crypto/hash"
 )
 
+// StakeRewardVersion is the version of the Stake transactions carrying a
+// RewardAddress. Stakes with a lower version are not rewarded for the
+// consensus work of the provisioner
+const StakeRewardVersion uint8 = 1
+
 // Stake encapsulates a Stake transaction
 type Stake struct {
 	*Timelock
 	PubKeyBLS []byte
+	// RewardAddress is the public key (see key.PublicKey.Bytes) of the
+	// wallet receiving the rewards of the provisioner. It is only encoded
+	// from StakeRewardVersion on
+	RewardAddress []byte
 }
 
 // NewStake creates a new Stake transaction
@@ -22,8 +31,8 @@ func NewStake(ver uint8, netPrefix byte, fee int64, lock uint64, pubKeyBLS []byt
 
 	tx.TxType = StakeType
 	return &Stake{
-		tx,
-		pubKeyBLS,
+		Timelock:  tx,
+		PubKeyBLS: pubKeyBLS,
 	}, nil
 }
 
@@ -72,6 +81,10 @@ func (s *Stake) Equals(t Transaction) bool {
 		return false
 	}
 
+	if !bytes.Equal(s.RewardAddress, other.RewardAddress) {
+		return false
+	}
+
 	return true
 }
 
@@ -93,5 +106,13 @@ func marshalStake(b *bytes.Buffer, s *Stake) error {
 		return err
 	}
 
-	return nil
+	if s.Version < StakeRewardVersion {
+		return nil
+	}
+
+	if err := writeVarInt(b, uint64(len(s.RewardAddress))); err != nil {
+		return err
+	}
+
+	return binary.Write(b, binary.BigEndian, s.RewardAddress)
 }
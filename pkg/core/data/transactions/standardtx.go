@@ -19,6 +19,10 @@ const minDecoys = 7
 const maxInputs = 2000
 const maxOutputs = 16
 
+// MaxRecipients is the maximum number of recipients of a Standard
+// transaction, as one output is left for the change
+const MaxRecipients = maxOutputs - 1
+
 // FetchDecoys is a function that creates a decoy (ring signature) by
 // camuflaging the actual public key of the signer among a collection of public
 // keys
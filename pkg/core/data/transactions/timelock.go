@@ -10,9 +10,18 @@ import (
 // MaxLockTime sets the maximum lock time to 250000 blocks
 const MaxLockTime = 250000
 
+// MaxLockDuration sets the maximum timestamp-based lock to the duration, in
+// seconds, of MaxLockTime blocks of 10 seconds
+const MaxLockDuration = MaxLockTime * 10
+
 // GenesisExpirationHeight sets the heigth for expiration of the Genesis block
 const GenesisExpirationHeight = 250001
 
+// LockTimeThreshold sets how the Lock of a standard Timelock is interpreted.
+// Below the threshold, Lock is the minimum height of the block including the
+// tx. Otherwise, Lock is the minimum unix timestamp of that block.
+const LockTimeThreshold = 500000000
+
 // Timelock represents a standard transaction that has an additional time restriction
 // What does the time-lock represent?
 // For a `Standard TimeLock`; that the TX can only become valid after the time stated.
@@ -80,11 +89,46 @@ func (tl *Timelock) Equals(t Transaction) bool {
 	return true
 }
 
-// LockTime returns the remaining lock time
+// LockTime returns the lock of the transaction. See LockTimeThreshold for its
+// interpretation
 func (tl *Timelock) LockTime() uint64 {
 	return tl.Lock
 }
 
+// IsTimestampLock returns true if the Lock is a unix timestamp rather than a
+// block height
+func (tl *Timelock) IsTimestampLock() bool {
+	return tl.Lock >= LockTimeThreshold
+}
+
+// Matured returns true if the Timelock can be included in a block with the
+// given height and timestamp
+func (tl *Timelock) Matured(height, blockTime uint64) bool {
+	if tl.IsTimestampLock() {
+		return blockTime >= tl.Lock
+	}
+
+	return height >= tl.Lock
+}
+
+// UnlockHeight returns the height from which the first output of a tx,
+// included in a block at the given height, can be spent.
+//
+// A standard Timelock is included only once matured, thus its outputs can be
+// spent as soon as the lock is met. For any other tx, the lock time is relative
+// to the inclusion height.
+func UnlockHeight(tx Transaction, height uint64) uint64 {
+	if tl, ok := tx.(*Timelock); ok {
+		if !tl.IsTimestampLock() && tl.Lock > height {
+			return tl.Lock
+		}
+
+		return height
+	}
+
+	return height + tx.LockTime()
+}
+
 func marshalTimelock(b *bytes.Buffer, tl *Timelock) error {
 	if err := marshalStandard(b, tl.Standard); err != nil {
 		return err
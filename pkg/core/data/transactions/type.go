@@ -21,6 +21,12 @@ const (
 
 	// ContractType is the identifier for a smart contract transaction
 	ContractType TxType = 0x05
+
+	// UnstakeType is the identifier for the early termination of a stake
+	UnstakeType TxType = 0x06
+
+	// SlashType is the identifier for the report of an equivocation
+	SlashType TxType = 0x07
 )
 
 var _ Transaction = (*Coinbase)(nil)
@@ -28,3 +34,5 @@ var _ Transaction = (*Bid)(nil)
 var _ Transaction = (*Stake)(nil)
 var _ Transaction = (*Standard)(nil)
 var _ Transaction = (*Timelock)(nil)
+var _ Transaction = (*Unstake)(nil)
+var _ Transaction = (*Slash)(nil)
@@ -0,0 +1,131 @@
+package transactions
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/dusk-network/dusk-crypto/bls"
+	"github.com/dusk-network/dusk-crypto/hash"
+)
+
+// UnstakeCooldown is the amount of blocks, after the inclusion of an Unstake
+// transaction, for which the stakes of the provisioner remain active
+const UnstakeCooldown = 1000
+
+// UnstakeUnlockHeight returns the height the stake outputs of a provisioner,
+// unstaking in the block at `height`, unlock at. As for the stakes running to
+// their expiry, it is the height the stakes end at
+func UnstakeUnlockHeight(height uint64) uint64 {
+	return height + 2 + UnstakeCooldown
+}
+
+// Unstake encapsulates a transaction ending the stakes of a provisioner
+// before their expiry. It needs to be signed with the BLS key of the
+// provisioner, and takes effect only after UnstakeCooldown blocks
+type Unstake struct {
+	*Standard
+	PubKeyBLS []byte
+	// Signature is the BLS signature of the transaction hash. It is not part
+	// of the hash itself, as much as the input signatures are not
+	Signature []byte
+}
+
+// NewUnstake creates a new Unstake transaction
+func NewUnstake(ver uint8, netPrefix byte, fee int64, pubKeyBLS []byte) (*Unstake, error) {
+	tx, err := NewStandard(ver, netPrefix, fee)
+	if err != nil {
+		return nil, err
+	}
+
+	tx.TxType = UnstakeType
+	return &Unstake{
+		Standard:  tx,
+		PubKeyBLS: pubKeyBLS,
+	}, nil
+}
+
+// CalculateHash calculates the hash of this transaction
+func (u *Unstake) CalculateHash() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := marshalUnstake(buf, u); err != nil {
+		return nil, err
+	}
+
+	txid, err := hash.Sha3256(buf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	return txid, nil
+}
+
+// Sign the transaction hash with the BLS key of the provisioner. It should be
+// called after the transaction is proven
+func (u *Unstake) Sign(sk *bls.SecretKey, pk *bls.PublicKey) error {
+	txid, err := u.CalculateHash()
+	if err != nil {
+		return err
+	}
+
+	sig, err := bls.Sign(sk, pk, txid)
+	if err != nil {
+		return err
+	}
+
+	u.Signature = sig.Compress()
+	return nil
+}
+
+// StandardTx returns the Standard transaction
+func (u *Unstake) StandardTx() *Standard {
+	return u.Standard
+}
+
+// Type returns the transaction type
+func (u *Unstake) Type() TxType {
+	return u.TxType
+}
+
+// Prove the transaction
+func (u *Unstake) Prove() error {
+	return u.prove(u.CalculateHash, true)
+}
+
+// Equals test the transactions equality
+func (u *Unstake) Equals(t Transaction) bool {
+	other, ok := t.(*Unstake)
+	if !ok {
+		return false
+	}
+
+	if !u.Standard.Equals(other.Standard) {
+		return false
+	}
+
+	if !bytes.Equal(u.PubKeyBLS, other.PubKeyBLS) {
+		return false
+	}
+
+	return bytes.Equal(u.Signature, other.Signature)
+}
+
+// LockTime returns 0 since Unstake is not a time locked transaction
+func (u *Unstake) LockTime() uint64 {
+	return 0
+}
+
+func marshalUnstake(b *bytes.Buffer, u *Unstake) error {
+	if err := marshalStandard(b, u.Standard); err != nil {
+		return err
+	}
+
+	if err := writeVarInt(b, uint64(len(u.PubKeyBLS))); err != nil {
+		return err
+	}
+
+	if err := binary.Write(b, binary.BigEndian, u.PubKeyBLS); err != nil {
+		return err
+	}
+
+	return nil
+}
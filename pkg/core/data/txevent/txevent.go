@@ -0,0 +1,85 @@
+// Package txevent defines the notifications published on each change in the
+// lifecycle of a transaction, from its submission to the mempool to its
+// inclusion in an accepted block.
+package txevent
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
+)
+
+// Status of a transaction in its lifecycle
+type Status uint8
+
+const (
+	// Accepted means the tx was verified and stored in the mempool
+	Accepted Status = iota
+	// Rejected means the tx failed the mempool verification
+	Rejected
+	// Included means the tx is part of an accepted block
+	Included
+	// Evicted means the tx was removed from the mempool without being
+	// included in a block
+	Evicted
+	// Pending means the tx is a timelock held by the mempool until its lock
+	// is met
+	Pending
+)
+
+var statusNames = [...]string{"accepted", "rejected", "included", "evicted", "pending"}
+
+func (s Status) String() string {
+	if int(s) < len(statusNames) {
+		return statusNames[s]
+	}
+	return "unknown"
+}
+
+// Event is published on topics.TxEvent on each status change of a tx
+type Event struct {
+	TxID   []byte
+	Status Status
+	// Reason is set for rejected and evicted txs
+	Reason string
+	// Height is set for included txs
+	Height uint64
+}
+
+// Publish an Event on the topics.TxEvent
+func Publish(publisher eventbus.Publisher, e Event) {
+	msg := message.New(topics.TxEvent, e)
+	publisher.Publish(topics.TxEvent, msg)
+}
+
+// Filter selects the events of a set of txs. An empty Filter matches all
+// events.
+type Filter map[string]struct{}
+
+// NewFilter creates a Filter from a list of hex-encoded txids
+func NewFilter(txids []string) (Filter, error) {
+	f := make(Filter, len(txids))
+	for _, txid := range txids {
+		b, err := hex.DecodeString(txid)
+		if err != nil || len(b) != 32 {
+			return nil, fmt.Errorf("invalid txid %q", txid)
+		}
+
+		f[hex.EncodeToString(b)] = struct{}{}
+	}
+
+	return f, nil
+}
+
+// Match returns true if the event should pass the filter
+func (f Filter) Match(e Event) bool {
+	if len(f) == 0 {
+		return true
+	}
+
+	_, ok := f[hex.EncodeToString(e.TxID)]
+	return ok
+}
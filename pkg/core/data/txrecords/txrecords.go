@@ -42,7 +42,7 @@ func New(tx transactions.Transaction, height uint64, direction Direction, privVi
 		Height:       height,
 		TxType:       tx.Type(),
 		Amount:       tx.StandardTx().Outputs[0].EncryptedAmount.BigInt().Uint64(),
-		UnlockHeight: height + tx.LockTime(),
+		UnlockHeight: transactions.UnlockHeight(tx, height),
 		Recipient:    hex.EncodeToString(tx.StandardTx().Outputs[0].PubKey.P.Bytes()),
 	}
 
@@ -2,6 +2,7 @@ package wallet
 
 import (
 	"encoding/binary"
+	"errors"
 
 	ristretto "github.com/bwesterb/go-ristretto"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
@@ -9,16 +10,53 @@ import (
 	"golang.org/x/crypto/sha3"
 )
 
+// consensusSeedSize is the size of the seed of the consensus keys
+const consensusSeedSize = 128
+
+// ErrNotConsensusKeys is returned when loading the consensus keys from a file
+// which does not hold them, like a wallet seed file
+var ErrNotConsensusKeys = errors.New("not a consensus keys file")
+
+// ExportConsensusKeys writes the consensus keys of the wallet stored in
+// `walletFile` to `file`, encrypted with `password`. The exported file only
+// holds the seed of the consensus keys, so that the hosts which only sign on
+// behalf of a provisioner, like the remote signer, never hold the wallet seed
+func ExportConsensusKeys(walletPassword, walletFile, password, file string) error {
+	seed, _, err := fetchSeed(walletPassword, walletFile)
+	if err != nil {
+		return err
+	}
+
+	return saveSeed(consensusSeed(seed), password, file)
+}
+
+// LoadConsensusKeys loads the consensus keys exported to `file` by
+// ExportConsensusKeys
+func LoadConsensusKeys(password string, file string) (key.Keys, error) {
+	seed, _, err := fetchSeed(password, file)
+	if err != nil {
+		return key.Keys{}, err
+	}
+
+	if len(seed) != consensusSeedSize {
+		return key.Keys{}, ErrNotConsensusKeys
+	}
+
+	return key.NewKeysFromBytes(seed)
+}
+
 func generateKeys(seed []byte) (key.Keys, error) {
+	return key.NewKeysFromBytes(consensusSeed(seed))
+}
+
+func consensusSeed(seed []byte) []byte {
 	// Consensus keys require >80 bytes of seed, so we will hash seed twice and concatenate
 	// both hashes to get 128 bytes
 
 	seedHash := sha3.Sum512(seed)
 	secondSeedHash := sha3.Sum512(seedHash[:])
 
-	consensusSeed := append(seedHash[:], secondSeedHash[:]...)
-
-	return key.NewKeysFromBytes(consensusSeed)
+	return append(seedHash[:], secondSeedHash[:]...)
 }
 
 func generateM(PrivateSpend []byte, index uint32) []byte {
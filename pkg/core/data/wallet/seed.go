@@ -1,16 +1,58 @@
 package wallet
 
 import (
+	"bytes"
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/rand"
+	"encoding/binary"
+	"errors"
 	"io"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/database"
+	"golang.org/x/crypto/argon2"
 	"golang.org/x/crypto/sha3"
 )
 
+// Seed file layout
+//
+//	magic | version | kdf time (uint32) | kdf memory (uint32) | kdf threads (uint8) | salt | nonce | ciphertext
+//
+// The header is authenticated along with the seed. Files written by the
+// previous versions of the wallet carry no header: they hold the nonce and the
+// ciphertext of the seed, encrypted with the SHA3-256 digest of the password.
+const seedMagic = "dusk-seed"
+
+// seedVersion is the version of the seed file header
+const seedVersion byte = 1
+
+const (
+	seedSaltSize   = 16
+	seedHeaderSize = len(seedMagic) + 1 + 4 + 4 + 1 + seedSaltSize
+
+	// maxKDFMemory bounds the memory, in KiB, a seed file can require to be
+	// opened
+	maxKDFMemory = 4 * 1024 * 1024
+)
+
+// kdfParams are the Argon2id parameters deriving the seed file key from the
+// password
+type kdfParams struct {
+	time    uint32
+	memory  uint32
+	threads uint8
+}
+
+// seedKDF are the parameters of the seed files written by this version
+var seedKDF = kdfParams{time: 3, memory: 64 * 1024, threads: 4}
+
+func (p kdfParams) key(password string, salt []byte) []byte {
+	return argon2.IDKey([]byte(password), salt, p.time, p.memory, p.threads, 32)
+}
+
 // Save saves the seed to a dat file
 func saveSeed(seed []byte, password string, file string) error {
 	// Overwriting a seed file may cause loss of funds
@@ -18,14 +60,26 @@ func saveSeed(seed []byte, password string, file string) error {
 		return ErrSeedFileExists
 	}
 
-	digest := sha3.Sum256([]byte(password))
+	return writeSeed(seed, password, file)
+}
 
-	c, err := aes.NewCipher(digest[:])
-	if err != nil {
+// writeSeed encrypts the seed with the password, and replaces the seed file
+// with it. The file is only readable by its owner
+func writeSeed(seed []byte, password string, file string) error {
+	header := new(bytes.Buffer)
+	salt := make([]byte, seedSaltSize)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
 		return err
 	}
 
-	gcm, err := cipher.NewGCM(c)
+	_, _ = header.WriteString(seedMagic)
+	_ = header.WriteByte(seedVersion)
+	_ = binary.Write(header, binary.LittleEndian, seedKDF.time)
+	_ = binary.Write(header, binary.LittleEndian, seedKDF.memory)
+	_ = header.WriteByte(seedKDF.threads)
+	_, _ = header.Write(salt)
+
+	gcm, err := newGCM(seedKDF.key(password, salt))
 	if err != nil {
 		return err
 	}
@@ -35,34 +89,131 @@ func saveSeed(seed []byte, password string, file string) error {
 		return err
 	}
 
-	return ioutil.WriteFile(file, gcm.Seal(nonce, nonce, seed, nil), 0777)
+	data := append(header.Bytes(), gcm.Seal(nonce, nonce, seed, header.Bytes())...)
+
+	// Write to a temporary file first, so that a failure does not leave a
+	// truncated seed file behind
+	tmp, err := ioutil.TempFile(filepath.Dir(file), filepath.Base(file)+".tmp")
+	if err != nil {
+		return err
+	}
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+
+	// The content must reach the disk before the rename, or a crash could
+	// leave an empty seed file in place of the previous one
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+
+	if err := os.Rename(tmp.Name(), file); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+
+	return syncDir(filepath.Dir(file))
 }
 
-//Modified from https://tutorialedge.net/golang/go-encrypt-decrypt-aes-tutorial/
-func fetchSeed(password string, file string) ([]byte, error) {
+// syncDir flushes a directory, so that a rename within it is durable
+func syncDir(dir string) error {
+	d, err := os.Open(dir) //nolint
+	if err != nil {
+		return err
+	}
 
-	digest := sha3.Sum256([]byte(password))
+	if err := d.Sync(); err != nil {
+		_ = d.Close()
+		return err
+	}
 
-	ciphertext, err := ioutil.ReadFile(file) //nolint
+	return d.Close()
+}
+
+// fetchSeed decrypts the seed file with the password. It reports whether the
+// file was written by a previous version of the wallet
+func fetchSeed(password string, file string) ([]byte, bool, error) {
+	data, err := ioutil.ReadFile(file) //nolint
 	if err != nil {
-		return nil, err
+		return nil, false, err
+	}
+
+	if !bytes.HasPrefix(data, []byte(seedMagic)) {
+		seed, err := fetchLegacySeed(password, data)
+		return seed, true, err
+	}
+
+	if len(data) < seedHeaderSize {
+		return nil, false, errors.New("seed file is too short")
+	}
+
+	header, ciphertext := data[:seedHeaderSize], data[seedHeaderSize:]
+	r := bytes.NewReader(header[len(seedMagic):])
+	version, _ := r.ReadByte()
+	if version != seedVersion {
+		return nil, false, errors.New("unsupported seed file version")
+	}
+
+	var p kdfParams
+	_ = binary.Read(r, binary.LittleEndian, &p.time)
+	_ = binary.Read(r, binary.LittleEndian, &p.memory)
+	p.threads, _ = r.ReadByte()
+	if p.time == 0 || p.threads == 0 || p.memory < 8*uint32(p.threads) || p.memory > maxKDFMemory {
+		return nil, false, errors.New("invalid seed file key derivation parameters")
 	}
 
-	c, err := aes.NewCipher(digest[:])
+	salt := header[seedHeaderSize-seedSaltSize:]
+	gcm, err := newGCM(p.key(password, salt))
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
-	gcm, err := cipher.NewGCM(c)
+	seed, err := openSeed(gcm, ciphertext, header)
+	return seed, false, err
+}
+
+// Modified from https://tutorialedge.net/golang/go-encrypt-decrypt-aes-tutorial/
+func fetchLegacySeed(password string, ciphertext []byte) ([]byte, error) {
+	digest := sha3.Sum256([]byte(password))
+
+	gcm, err := newGCM(digest[:])
 	if err != nil {
 		return nil, err
 	}
 
+	return openSeed(gcm, ciphertext, nil)
+}
+
+func openSeed(gcm cipher.AEAD, ciphertext, additionalData []byte) ([]byte, error) {
 	nonceSize := gcm.NonceSize()
 	if len(ciphertext) < nonceSize {
-		return nil, err
+		return nil, errors.New("seed file is too short")
 	}
 
 	nonce, ciphertext := ciphertext[:nonceSize], ciphertext[nonceSize:]
-	return gcm.Open(nil, nonce, ciphertext, nil)
+	seed, err := gcm.Open(nil, nonce, ciphertext, additionalData)
+	if err != nil {
+		return nil, database.ErrWrongPassword
+	}
+
+	return seed, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	c, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(c)
 }
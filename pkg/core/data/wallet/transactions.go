@@ -10,6 +10,10 @@ import (
 
 // NewStandardTx creates a new standard transaction
 func (w *Wallet) NewStandardTx(fee int64) (*transactions.Standard, error) {
+	if w.WatchOnly() {
+		return nil, ErrWatchOnly
+	}
+
 	tx, err := transactions.NewStandard(0, w.netPrefix, fee)
 	if err != nil {
 		return nil, err
@@ -19,12 +23,19 @@ func (w *Wallet) NewStandardTx(fee int64) (*transactions.Standard, error) {
 
 // NewStakeTx creates a new Stake transaction
 func (w *Wallet) NewStakeTx(fee int64, lockTime uint64, amount ristretto.Scalar) (*transactions.Stake, error) {
+	if w.WatchOnly() {
+		return nil, ErrWatchOnly
+	}
+
 	blsPubBytes := w.consensusKeys.BLSPubKeyBytes
-	tx, err := transactions.NewStake(0, w.netPrefix, fee, lockTime, blsPubBytes)
+	tx, err := transactions.NewStake(transactions.StakeRewardVersion, w.netPrefix, fee, lockTime, blsPubBytes)
 	if err != nil {
 		return nil, err
 	}
 
+	// The rewards of the provisioner go to this wallet
+	tx.RewardAddress = w.keyPair.PublicKey().Bytes()
+
 	// Send locked stake amount to self
 	walletAddr, err := w.keyPair.PublicKey().PublicAddress(w.netPrefix)
 	if err != nil {
@@ -40,6 +51,10 @@ func (w *Wallet) NewStakeTx(fee int64, lockTime uint64, amount ristretto.Scalar)
 
 // NewBidTx creates a new bid transaction
 func (w *Wallet) NewBidTx(fee int64, lockTime uint64, amount ristretto.Scalar) (*transactions.Bid, error) {
+	if w.WatchOnly() {
+		return nil, ErrWatchOnly
+	}
+
 	privateSpend, err := w.keyPair.PrivateSpend()
 	if err != nil {
 		return nil, err
@@ -70,6 +85,10 @@ func (w *Wallet) NewBidTx(fee int64, lockTime uint64, amount ristretto.Scalar) (
 
 // AddInputs adds up the total outputs and fee then fetches inputs to consolidate this
 func (w *Wallet) AddInputs(tx *transactions.Standard) error {
+	if w.WatchOnly() {
+		return ErrWatchOnly
+	}
+
 	totalAmount := tx.Fee.BigInt().Int64() + tx.TotalSent.BigInt().Int64()
 	inputs, changeAmount, err := w.fetchInputs(w.netPrefix, w.db, totalAmount, w.keyPair)
 	if err != nil {
@@ -94,24 +113,24 @@ func (w *Wallet) AddInputs(tx *transactions.Standard) error {
 	return tx.AddOutput(*changeAddr, x)
 }
 
-// Sign a transaction
-func (w *Wallet) Sign(tx SignableTx) error {
-	// Assuming user has added all of the outputs
-	standardTx := tx.StandardTx()
-
-	// Fetch Inputs
-	err := w.AddInputs(standardTx)
-	if err != nil {
-		return err
+// CountInputs returns the number of inputs spent by a tx sending totalAmount,
+// fee included. The inputs are left in the database.
+func (w *Wallet) CountInputs(totalAmount int64) (int, error) {
+	if w.WatchOnly() {
+		return 0, ErrWatchOnly
 	}
 
-	// Fetch decoys
-	err = standardTx.AddDecoys(numMixins, w.fetchDecoys)
+	inputs, _, err := w.fetchInputs(w.netPrefix, w.db, totalAmount, w.keyPair)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	if err := tx.Prove(); err != nil {
+	return len(inputs), nil
+}
+
+// Sign a transaction
+func (w *Wallet) Sign(tx SignableTx) error {
+	if err := w.Prepare(tx); err != nil {
 		return err
 	}
 
@@ -131,3 +150,29 @@ func (w *Wallet) Sign(tx SignableTx) error {
 
 	return nil
 }
+
+// Prepare adds the inputs and the decoys to a transaction and proves it, as
+// Sign does, but leaves the inputs in the database. It is meant to measure the
+// size of a signed transaction, which is not published.
+func (w *Wallet) Prepare(tx SignableTx) error {
+	if w.WatchOnly() {
+		return ErrWatchOnly
+	}
+
+	// Assuming user has added all of the outputs
+	standardTx := tx.StandardTx()
+
+	// Fetch Inputs
+	err := w.AddInputs(standardTx)
+	if err != nil {
+		return err
+	}
+
+	// Fetch decoys
+	err = standardTx.AddDecoys(numMixins, w.fetchDecoys)
+	if err != nil {
+		return err
+	}
+
+	return tx.Prove()
+}
@@ -17,24 +17,44 @@ func (w *Wallet) CheckWireBlockReceived(blk block.Block) (uint64, error) {
 		return 0, err
 	}
 
-	privSpend, err := w.keyPair.PrivateSpend()
-	if err != nil {
-		return 0, err
-	}
-
 	var totalReceivedCount uint64
 
 	for _, tx := range blk.Txs {
 		var didReceiveFunds bool
 		for i, output := range tx.StandardTx().Outputs {
-			privKey, ok := w.keyPair.DidReceiveTx(tx.StandardTx().R, output.PubKey, uint32(i))
+			R := tx.StandardTx().R
+			// The provisioner rewards use their own tx public key
+			if coinbase, ok := tx.(*transactions.Coinbase); ok {
+				R = coinbase.TxPubKeyAt(i)
+			}
+
+			if w.WatchOnly() {
+				offset, ok := w.keyPair.OneTimeKeyOffset(R, output.PubKey, uint32(i))
+				if !ok {
+					continue
+				}
+
+				didReceiveFunds = true
+
+				// Without the private spend key, neither the one time
+				// private key nor the key image of the output are known.
+				// The offset of the key is stored instead, for an offline
+				// wallet to sign the unsigned transactions spending it
+				if err := w.writeOutputToDatabase(*output, privView, *offset, tx, i, blk.Header.Height); err != nil {
+					return 0, err
+				}
+
+				continue
+			}
+
+			privKey, ok := w.keyPair.DidReceiveTx(R, output.PubKey, uint32(i))
 			if !ok {
 				continue
 			}
 
 			didReceiveFunds = true
 
-			if err := w.writeOutputToDatabase(*output, privView, privSpend, *privKey, tx, i, blk.Header.Height); err != nil {
+			if err := w.writeOutputToDatabase(*output, privView, *privKey, tx, i, blk.Header.Height); err != nil {
 				return 0, err
 			}
 
@@ -52,7 +72,7 @@ func (w *Wallet) CheckWireBlockReceived(blk block.Block) (uint64, error) {
 	return totalReceivedCount, nil
 }
 
-func (w *Wallet) writeOutputToDatabase(output transactions.Output, privView *key.PrivateView, privSpend *key.PrivateSpend, privKey ristretto.Scalar, tx transactions.Transaction, i int, blockHeight uint64) error {
+func (w *Wallet) writeOutputToDatabase(output transactions.Output, privView *key.PrivateView, privKey ristretto.Scalar, tx transactions.Transaction, i int, blockHeight uint64) error {
 	var amount, mask ristretto.Scalar
 	amount.Set(&output.EncryptedAmount)
 	mask.Set(&output.EncryptedMask)
@@ -65,10 +85,10 @@ func (w *Wallet) writeOutputToDatabase(output transactions.Output, privView *key
 	// Only the first output of a tx is locked, to avoid locking up
 	// a change output.
 	if i == 0 {
-		return w.db.PutInput(privSpend.Bytes(), output.PubKey.P, amount, mask, privKey, tx.LockTime()+blockHeight)
+		return w.db.PutInput(output.PubKey.P, amount, mask, privKey, transactions.UnlockHeight(tx, blockHeight))
 	}
 
-	return w.db.PutInput(privSpend.Bytes(), output.PubKey.P, amount, mask, privKey, 0)
+	return w.db.PutInput(output.PubKey.P, amount, mask, privKey, 0)
 }
 
 func (w *Wallet) writeKeyImageToDatabase(output transactions.Output, privKey ristretto.Scalar) error {
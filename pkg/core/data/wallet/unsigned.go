@@ -0,0 +1,465 @@
+package wallet
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+
+	ristretto "github.com/bwesterb/go-ristretto"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/encoding"
+	"github.com/dusk-network/dusk-crypto/mlsag"
+)
+
+// Unsigned tx layout
+//
+//	magic | version | net prefix | fee (uint64) | outputs | inputs
+//
+// An output is its address (var bytes) followed by its amount (uint64). An
+// input is its one time public key, amount (uint64), mask, key offset and
+// decoys, each a 32 bytes point or scalar.
+const unsignedTxMagic = "dusk-unsigned-tx"
+
+// unsignedTxVersion is the version of the unsigned tx encoding
+const unsignedTxVersion byte = 1
+
+const (
+	// maxUnsignedInputs bounds the inputs of a decoded unsigned tx, as
+	// transactions.Standard does
+	maxUnsignedInputs = 2000
+
+	// maxDecoys bounds the decoys of an input of a decoded unsigned tx
+	maxDecoys = 128
+)
+
+// ErrNotWatchOnly is returned when a wallet which can sign its transactions
+// is asked to build an unsigned one
+var ErrNotWatchOnly = errors.New("only a watch-only wallet creates unsigned transactions")
+
+// UnsignedTx is a standard transaction built by a watch-only wallet. It holds
+// the inputs, the decoys and the outputs of the tx, but not the keys spending
+// the inputs. It is signed by a Signer holding the seed of the wallet, on a
+// device which can be kept offline, and the signed tx is then published by the
+// online node.
+type UnsignedTx struct {
+	NetPrefix byte
+	Fee       uint64
+	// Outputs pay the recipients, and the change back to the wallet
+	Outputs []UnsignedOutput
+	Inputs  []UnsignedInput
+}
+
+// UnsignedOutput pays an amount to an address
+type UnsignedOutput struct {
+	Address key.PublicAddress
+	Amount  uint64
+}
+
+// UnsignedInput is an output of the wallet, spent by an UnsignedTx
+type UnsignedInput struct {
+	// PubKey is the one time public key of the output
+	PubKey ristretto.Point
+	Amount uint64
+	Mask   ristretto.Scalar
+	// KeyOffset completes the private spend key into the one time private key
+	// of the output (see key.Key.OneTimeKeyOffset)
+	KeyOffset ristretto.Scalar
+	// Decoys are the one time public keys of other outputs, among which the
+	// input is hidden
+	Decoys []ristretto.Point
+}
+
+// NewUnsignedTx builds a standard tx paying the outputs, along with the
+// change back to the wallet. The inputs and the decoys are fetched as Sign
+// does, but the inputs remain in the database until the signed tx is
+// published (see RemoveSpentInputs).
+func (w *Wallet) NewUnsignedTx(outputs []UnsignedOutput, fee int64) (*UnsignedTx, error) {
+	if !w.WatchOnly() {
+		return nil, ErrNotWatchOnly
+	}
+
+	if len(outputs) > transactions.MaxRecipients {
+		return nil, fmt.Errorf("a tx can have at most %d recipients", transactions.MaxRecipients)
+	}
+
+	if fee < 0 {
+		return nil, errors.New("fee cannot be negative")
+	}
+
+	total := big.NewInt(fee)
+	for _, o := range outputs {
+		total.Add(total, new(big.Int).SetUint64(o.Amount))
+	}
+
+	if !total.IsInt64() {
+		return nil, errors.New("total amount is too high")
+	}
+
+	records, changeAmount, err := w.db.FetchInputRecords(total.Int64())
+	if err != nil {
+		return nil, err
+	}
+
+	changeAddr, err := w.keyPair.PublicKey().PublicAddress(w.netPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	u := &UnsignedTx{
+		NetPrefix: w.netPrefix,
+		Fee:       uint64(fee),
+		Outputs:   append(append([]UnsignedOutput{}, outputs...), UnsignedOutput{Address: *changeAddr, Amount: uint64(changeAmount)}),
+		Inputs:    make([]UnsignedInput, len(records)),
+	}
+
+	for i, r := range records {
+		ring := w.fetchDecoys(numMixins)
+		decoys := make([]ristretto.Point, len(ring))
+		for j := range ring {
+			decoys[j] = ring[j].OutputKey()
+		}
+
+		u.Inputs[i] = UnsignedInput{
+			PubKey:    w.keyPair.OneTimePubKey(r.PrivKey),
+			Amount:    r.Amount.BigInt().Uint64(),
+			Mask:      r.Mask,
+			KeyOffset: r.PrivKey,
+			Decoys:    decoys,
+		}
+	}
+
+	return u, nil
+}
+
+// RemoveSpentInputs removes the inputs spent by a tx from the database. A
+// watch-only wallet does not know the key images of its inputs, hence the
+// inputs spent by the txs signed offline are removed when they are published,
+// as Sign does.
+func (w *Wallet) RemoveSpentInputs(tx transactions.Transaction) error {
+	for _, input := range tx.StandardTx().Inputs {
+		if err := w.db.RemoveInput(input.PubKey.P.Bytes(), input.KeyImage.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Signer holds the keys of a wallet, without its database. It signs the
+// unsigned transactions built by the watch-only wallet created from its view
+// key.
+type Signer struct {
+	netPrefix byte
+	keyPair   *key.Key
+}
+
+// NewSigner creates a Signer from the seed of a wallet
+func NewSigner(seed []byte, netPrefix byte) (*Signer, error) {
+	if len(seed) < 64 {
+		return nil, errors.New("seed must be atleast 64 bytes in size")
+	}
+
+	return &Signer{netPrefix: netPrefix, keyPair: key.NewKeyPair(seed)}, nil
+}
+
+// LoadSigner creates a Signer from a seed file. Unlike LoadFromFile, it leaves
+// the seed files written by the previous versions of the wallet untouched
+func LoadSigner(netPrefix byte, password string, file string) (*Signer, error) {
+	seed, _, err := fetchSeed(password, file)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSigner(seed, netPrefix)
+}
+
+// ViewKey returns the view key of the wallet, from which the watch-only
+// wallet is created
+func (s *Signer) ViewKey() []byte {
+	return s.keyPair.ViewKey()
+}
+
+// PublicAddress returns the wallet public address
+func (s *Signer) PublicAddress() (string, error) {
+	pubAddr, err := s.keyPair.PublicKey().PublicAddress(s.netPrefix)
+	if err != nil {
+		return "", err
+	}
+	return pubAddr.String(), nil
+}
+
+// Sign proves an unsigned tx, spending inputs of the wallet. It fails if an
+// input belongs to another wallet, or if the inputs do not add up to the
+// outputs and the fee
+func (s *Signer) Sign(u *UnsignedTx) (*transactions.Standard, error) {
+	if u.NetPrefix != s.netPrefix {
+		return nil, errors.New("unsigned tx is intended for another network")
+	}
+
+	if u.Fee > math.MaxInt64 {
+		return nil, errors.New("fee is too high")
+	}
+
+	tx, err := transactions.NewStandard(0, s.netPrefix, int64(u.Fee))
+	if err != nil {
+		return nil, err
+	}
+
+	totalOut := new(big.Int).SetUint64(u.Fee)
+	for i, o := range u.Outputs {
+		var amount ristretto.Scalar
+		amount.SetBigInt(new(big.Int).SetUint64(o.Amount))
+		if err := tx.AddOutput(o.Address, amount); err != nil {
+			return nil, fmt.Errorf("output %d: %v", i+1, err)
+		}
+
+		totalOut.Add(totalOut, new(big.Int).SetUint64(o.Amount))
+	}
+
+	totalIn := new(big.Int)
+	for i, in := range u.Inputs {
+		privKey, err := s.keyPair.OneTimeKey(in.KeyOffset)
+		if err != nil {
+			return nil, err
+		}
+
+		var amount ristretto.Scalar
+		amount.SetBigInt(new(big.Int).SetUint64(in.Amount))
+		input := transactions.NewInput(amount, in.Mask, *privKey)
+		if !input.PubKey.P.Equals(&in.PubKey) {
+			return nil, fmt.Errorf("input %d is not spent by this wallet", i+1)
+		}
+
+		if err := tx.AddInput(input); err != nil {
+			return nil, err
+		}
+
+		totalIn.Add(totalIn, new(big.Int).SetUint64(in.Amount))
+	}
+
+	if totalIn.Cmp(totalOut) != 0 {
+		return nil, errors.New("inputs do not add up to the outputs and the fee")
+	}
+
+	// AddDecoys fetches the decoys of each input in turn
+	next := 0
+	err = tx.AddDecoys(numMixins, func(int) []mlsag.PubKeys {
+		ring := decoyRing(u.Inputs[next].Decoys)
+		next++
+		return ring
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Prove(); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+// decoyRing turns the decoys of an input into the key vectors of its ring
+// signature. The secondary key of a decoy is random, as it only serves the
+// balance proof of the signer
+func decoyRing(decoys []ristretto.Point) []mlsag.PubKeys {
+	ring := make([]mlsag.PubKeys, len(decoys))
+	for i, decoy := range decoys {
+		ring[i].AddPubKey(decoy)
+
+		var secondaryKey ristretto.Point
+		secondaryKey.Rand()
+		ring[i].AddPubKey(secondaryKey)
+	}
+
+	return ring
+}
+
+// MarshalUnsignedTx encodes an unsigned tx, to be carried to the Signer
+func MarshalUnsignedTx(w *bytes.Buffer, u *UnsignedTx) error {
+	if _, err := w.WriteString(unsignedTxMagic); err != nil {
+		return err
+	}
+
+	if err := encoding.WriteUint8(w, unsignedTxVersion); err != nil {
+		return err
+	}
+
+	if err := encoding.WriteUint8(w, u.NetPrefix); err != nil {
+		return err
+	}
+
+	if err := encoding.WriteUint64LE(w, u.Fee); err != nil {
+		return err
+	}
+
+	if err := encoding.WriteVarInt(w, uint64(len(u.Outputs))); err != nil {
+		return err
+	}
+
+	for _, o := range u.Outputs {
+		if err := encoding.WriteVarBytes(w, []byte(o.Address)); err != nil {
+			return err
+		}
+
+		if err := encoding.WriteUint64LE(w, o.Amount); err != nil {
+			return err
+		}
+	}
+
+	if err := encoding.WriteVarInt(w, uint64(len(u.Inputs))); err != nil {
+		return err
+	}
+
+	for _, in := range u.Inputs {
+		if err := encoding.Write256(w, in.PubKey.Bytes()); err != nil {
+			return err
+		}
+
+		if err := encoding.WriteUint64LE(w, in.Amount); err != nil {
+			return err
+		}
+
+		if err := encoding.Write256(w, in.Mask.Bytes()); err != nil {
+			return err
+		}
+
+		if err := encoding.Write256(w, in.KeyOffset.Bytes()); err != nil {
+			return err
+		}
+
+		if err := encoding.WriteVarInt(w, uint64(len(in.Decoys))); err != nil {
+			return err
+		}
+
+		for _, decoy := range in.Decoys {
+			if err := encoding.Write256(w, decoy.Bytes()); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// UnmarshalUnsignedTx decodes an unsigned tx encoded by MarshalUnsignedTx
+func UnmarshalUnsignedTx(r *bytes.Buffer) (*UnsignedTx, error) {
+	if !bytes.HasPrefix(r.Bytes(), []byte(unsignedTxMagic)) {
+		return nil, errors.New("not an unsigned tx")
+	}
+	r.Next(len(unsignedTxMagic))
+
+	var version uint8
+	if err := encoding.ReadUint8(r, &version); err != nil {
+		return nil, err
+	}
+
+	if version != unsignedTxVersion {
+		return nil, errors.New("unsupported unsigned tx version")
+	}
+
+	u := &UnsignedTx{}
+	if err := encoding.ReadUint8(r, &u.NetPrefix); err != nil {
+		return nil, err
+	}
+
+	if err := encoding.ReadUint64LE(r, &u.Fee); err != nil {
+		return nil, err
+	}
+
+	lOutputs, err := encoding.ReadVarInt(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if lOutputs > transactions.MaxRecipients+1 {
+		return nil, errors.New("output count too large")
+	}
+
+	u.Outputs = make([]UnsignedOutput, lOutputs)
+	for i := range u.Outputs {
+		var addr []byte
+		if err := encoding.ReadVarBytes(r, &addr); err != nil {
+			return nil, err
+		}
+		u.Outputs[i].Address = key.PublicAddress(addr)
+
+		if err := encoding.ReadUint64LE(r, &u.Outputs[i].Amount); err != nil {
+			return nil, err
+		}
+	}
+
+	lInputs, err := encoding.ReadVarInt(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if lInputs > maxUnsignedInputs {
+		return nil, errors.New("input count too large")
+	}
+
+	u.Inputs = make([]UnsignedInput, lInputs)
+	for i := range u.Inputs {
+		if err := unmarshalUnsignedInput(r, &u.Inputs[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return u, nil
+}
+
+func unmarshalUnsignedInput(r *bytes.Buffer, in *UnsignedInput) error {
+	b := make([]byte, 32)
+	if err := encoding.Read256(r, b); err != nil {
+		return err
+	}
+
+	if err := in.PubKey.UnmarshalBinary(b); err != nil {
+		return err
+	}
+
+	if err := encoding.ReadUint64LE(r, &in.Amount); err != nil {
+		return err
+	}
+
+	if err := encoding.Read256(r, b); err != nil {
+		return err
+	}
+
+	if err := in.Mask.UnmarshalBinary(b); err != nil {
+		return err
+	}
+
+	if err := encoding.Read256(r, b); err != nil {
+		return err
+	}
+
+	if err := in.KeyOffset.UnmarshalBinary(b); err != nil {
+		return err
+	}
+
+	lDecoys, err := encoding.ReadVarInt(r)
+	if err != nil {
+		return err
+	}
+
+	if lDecoys > maxDecoys {
+		return errors.New("decoy count too large")
+	}
+
+	in.Decoys = make([]ristretto.Point, lDecoys)
+	for i := range in.Decoys {
+		if err := encoding.Read256(r, b); err != nil {
+			return err
+		}
+
+		if err := in.Decoys[i].UnmarshalBinary(b); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
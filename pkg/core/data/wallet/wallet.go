@@ -1,6 +1,8 @@
 package wallet
 
 import (
+	"bytes"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
@@ -24,6 +26,10 @@ const DUSK = uint64(100000000)
 // ErrSeedFileExists is returned if the seed file already exists
 var ErrSeedFileExists = fmt.Errorf("wallet seed file already exists")
 
+// ErrWatchOnly is returned when a watch-only wallet is asked to create or
+// sign a transaction
+var ErrWatchOnly = errors.New("watch-only wallet can not create transactions")
+
 // FetchInputs returns a slice of inputs such that Sum(Inputs)- Sum(Outputs) >= 0
 // If > 0, then a change address is created for the remaining amount
 type FetchInputs func(netPrefix byte, db *database.DB, totalAmount int64, key *key.Key) ([]*transactions.Input, int64, error)
@@ -33,6 +39,7 @@ type FetchInputs func(netPrefix byte, db *database.DB, totalAmount int64, key *k
 type Wallet struct {
 	db        *database.DB
 	netPrefix byte
+	file      string
 
 	keyPair       *key.Key
 	consensusKeys *consensuskey.Keys
@@ -50,11 +57,19 @@ type SignableTx interface {
 
 // New creates a wallet instance
 func New(Read func(buf []byte) (n int, err error), netPrefix byte, db *database.DB, fDecoys transactions.FetchDecoys, fInputs FetchInputs, password string, file string) (*Wallet, error) {
+	seed, err := GenerateSeed(Read)
+	if err != nil {
+		return nil, err
+	}
 
-	var seed []byte
+	return LoadFromSeed(seed, netPrefix, db, fDecoys, fInputs, password, file)
+}
+
+// GenerateSeed creates a random seed for a new wallet
+func GenerateSeed(Read func(buf []byte) (n int, err error)) ([]byte, error) {
 	for {
 		// random seed
-		seed = make([]byte, 64)
+		seed := make([]byte, 64)
 		_, err := Read(seed)
 		if err != nil {
 			return nil, err
@@ -63,7 +78,7 @@ func New(Read func(buf []byte) (n int, err error), netPrefix byte, db *database.
 		// Ensure the seed can be used for generating a BLS keypair.
 		_, err = generateKeys(seed)
 		if err == nil {
-			break
+			return seed, nil
 		}
 
 		if err != io.EOF {
@@ -71,8 +86,6 @@ func New(Read func(buf []byte) (n int, err error), netPrefix byte, db *database.
 		}
 		// If not, we retry.
 	}
-
-	return LoadFromSeed(seed, netPrefix, db, fDecoys, fInputs, password, file)
 }
 
 // LoadFromSeed loads a wallet from the seed
@@ -80,6 +93,13 @@ func LoadFromSeed(seed []byte, netPrefix byte, db *database.DB, fDecoys transact
 	if len(seed) < 64 {
 		return nil, errors.New("seed must be atleast 64 bytes in size")
 	}
+
+	// The database may hold the records of a wallet secured with another
+	// password, in which case the seed is not saved
+	if err := db.Unlock([]byte(password)); err != nil {
+		return nil, err
+	}
+
 	err := saveSeed(seed, password, file)
 	if err != nil {
 		return nil, err
@@ -93,6 +113,7 @@ func LoadFromSeed(seed []byte, netPrefix byte, db *database.DB, fDecoys transact
 	w := &Wallet{
 		db:            db,
 		netPrefix:     netPrefix,
+		file:          file,
 		keyPair:       key.NewKeyPair(seed),
 		consensusKeys: &consensusKeys,
 		fetchDecoys:   fDecoys,
@@ -121,11 +142,22 @@ func LoadFromSeed(seed []byte, netPrefix byte, db *database.DB, fDecoys transact
 // LoadFromFile loads a wallet from a .dat file
 func LoadFromFile(netPrefix byte, db *database.DB, fDecoys transactions.FetchDecoys, fInputs FetchInputs, password string, file string) (*Wallet, error) {
 
-	seed, err := fetchSeed(password, file)
+	seed, legacy, err := fetchSeed(password, file)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := db.Unlock([]byte(password)); err != nil {
+		return nil, err
+	}
+
+	// Upgrade the seed files written by the previous versions of the wallet
+	if legacy {
+		if err := writeSeed(seed, password, file); err != nil {
+			return nil, err
+		}
+	}
+
 	consensusKeys, err := generateKeys(seed)
 	if err != nil {
 		return nil, err
@@ -134,6 +166,7 @@ func LoadFromFile(netPrefix byte, db *database.DB, fDecoys transactions.FetchDec
 	return &Wallet{
 		db:            db,
 		netPrefix:     netPrefix,
+		file:          file,
 		keyPair:       key.NewKeyPair(seed),
 		consensusKeys: &consensusKeys,
 		fetchDecoys:   fDecoys,
@@ -141,6 +174,57 @@ func LoadFromFile(netPrefix byte, db *database.DB, fDecoys transactions.FetchDec
 	}, nil
 }
 
+// NewWatchOnly creates a wallet from a view key, as returned by
+// key.ViewKeyFromBytes. It records the incoming outputs and transactions,
+// but can not spend them nor detect when they are spent. Hence its balance is
+// the sum of the received amounts, less the inputs of the txs it publishes
+// after they are signed offline (see NewUnsignedTx). The records are secured
+// with `password`
+func NewWatchOnly(netPrefix byte, db *database.DB, fDecoys transactions.FetchDecoys, viewKey *key.Key, password string) (*Wallet, error) {
+	if viewKey.CanSpend() {
+		return nil, errors.New("watch-only wallet needs a view key")
+	}
+
+	if err := db.Unlock([]byte(password)); err != nil {
+		return nil, err
+	}
+
+	w := &Wallet{
+		db:          db,
+		netPrefix:   netPrefix,
+		keyPair:     viewKey,
+		fetchDecoys: fDecoys,
+	}
+
+	// Check if this is a new wallet
+	_, err := w.db.GetWalletHeight()
+	if err == nil {
+		return w, nil
+	}
+
+	if err != leveldb.ErrNotFound {
+		return nil, err
+	}
+
+	// Add height of zero into database
+	if err := w.UpdateWalletHeight(0); err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// WatchOnly returns true for the wallets created from a view key
+func (w *Wallet) WatchOnly() bool {
+	return !w.keyPair.CanSpend()
+}
+
+// ViewKey returns the view key of the wallet, from which a watch-only wallet
+// is created
+func (w *Wallet) ViewKey() []byte {
+	return w.keyPair.ViewKey()
+}
+
 // CheckWireBlock check a block
 func (w *Wallet) CheckWireBlock(blk block.Block) (uint64, uint64, error) {
 	// Ensure this block is at the height we expect it to be
@@ -163,23 +247,61 @@ func (w *Wallet) CheckWireBlock(blk block.Block) (uint64, uint64, error) {
 		return 0, 0, err
 	}
 
-	err = w.UpdateWalletHeight(blk.Header.Height + 1)
-	if err != nil {
+	if err := w.checkWireBlockUnstake(blk); err != nil {
 		return 0, 0, err
 	}
 
-	privSpend, err := w.keyPair.PrivateSpend()
+	err = w.UpdateWalletHeight(blk.Header.Height + 1)
 	if err != nil {
 		return 0, 0, err
 	}
 
-	if err := w.db.UpdateLockedInputs(privSpend.Bytes(), blk.Header.Height); err != nil {
+	if err := w.db.UpdateLockedInputs(blk.Header.Height); err != nil {
 		return 0, 0, err
 	}
 
 	return spentCount, receivedCount, nil
 }
 
+// checkWireBlockUnstake brings forward the unlock height of the stake outputs
+// of the wallet to the end of the unstake cooldown, if the block holds an
+// Unstake transaction of the wallet provisioner
+func (w *Wallet) checkWireBlockUnstake(blk block.Block) error {
+	if w.consensusKeys == nil {
+		return nil
+	}
+
+	for _, tx := range blk.Txs {
+		unstake, ok := tx.(*transactions.Unstake)
+		if !ok || !bytes.Equal(unstake.PubKeyBLS, w.consensusKeys.BLSPubKeyBytes) {
+			continue
+		}
+
+		records, err := w.db.FetchTxRecords()
+		if err != nil {
+			return err
+		}
+
+		unlockHeight := transactions.UnstakeUnlockHeight(blk.Header.Height)
+		for _, record := range records {
+			if record.Direction != txrecords.In || record.TxType != transactions.StakeType {
+				continue
+			}
+
+			pubkey, err := hex.DecodeString(record.Recipient)
+			if err != nil {
+				return err
+			}
+
+			if err := w.db.LowerUnlockHeight(pubkey, unlockHeight); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
 // CheckUnconfirmedBalance calculates balance including the unconfirmed
 // transactions from a slice of transactions
 func (w *Wallet) CheckUnconfirmedBalance(txs []transactions.Transaction) (uint64, error) {
@@ -191,7 +313,7 @@ func (w *Wallet) CheckUnconfirmedBalance(txs []transactions.Transaction) (uint64
 	var balance uint64
 	for _, tx := range txs {
 		for i, output := range tx.StandardTx().Outputs {
-			if _, ok := w.keyPair.DidReceiveTx(tx.StandardTx().R, output.PubKey, uint32(i)); !ok {
+			if !w.keyPair.IsRecipient(tx.StandardTx().R, output.PubKey, uint32(i)) {
 				continue
 			}
 
@@ -212,11 +334,7 @@ func (w *Wallet) CheckUnconfirmedBalance(txs []transactions.Transaction) (uint64
 
 // Balance calculates and returns the wallet balance for confirmed transactions
 func (w *Wallet) Balance() (uint64, uint64, error) {
-	privSpend, err := w.keyPair.PrivateSpend()
-	if err != nil {
-		return 0, 0, err
-	}
-	unlockedBalance, lockedBalance, err := w.db.FetchBalance(privSpend.Bytes())
+	unlockedBalance, lockedBalance, err := w.db.FetchBalance()
 	if err != nil {
 		return 0, 0, err
 	}
@@ -253,13 +371,21 @@ func (w *Wallet) PublicAddress() (string, error) {
 	return pubAddr.String(), nil
 }
 
-// Keys returns the BLS keys
+// Keys returns the BLS keys. They are empty for a watch-only wallet
 func (w *Wallet) Keys() consensuskey.Keys {
+	if w.consensusKeys == nil {
+		return consensuskey.Keys{}
+	}
+
 	return *w.consensusKeys
 }
 
 // PrivateSpend calls the PrivateSpend method on the keypair
 func (w *Wallet) PrivateSpend() ([]byte, error) {
+	if w.WatchOnly() {
+		return nil, ErrWatchOnly
+	}
+
 	privateSpend, err := w.keyPair.PrivateSpend()
 	if err != nil {
 		return nil, err
@@ -272,3 +398,31 @@ func (w *Wallet) PrivateSpend() ([]byte, error) {
 func (w *Wallet) ClearDatabase() error {
 	return w.db.Clear()
 }
+
+// ChangePassword encrypts the seed file and the database key with a new
+// password. It fails if the old password does not open the seed file.
+// A watch-only wallet has no seed file, only its database key is resealed.
+func (w *Wallet) ChangePassword(oldPassword, newPassword string) error {
+	if w.WatchOnly() {
+		return w.db.ChangePassword([]byte(oldPassword), []byte(newPassword))
+	}
+
+	seed, _, err := fetchSeed(oldPassword, w.file)
+	if err != nil {
+		return err
+	}
+
+	// The database key sealed with the old password is kept until the seed
+	// file is written, so that the password of the seed file always unlocks
+	// the database
+	if err := w.db.StagePassword([]byte(oldPassword), []byte(newPassword)); err != nil {
+		return err
+	}
+
+	if err := writeSeed(seed, newPassword, w.file); err != nil {
+		_ = w.db.DiscardPassword()
+		return err
+	}
+
+	return w.db.CommitPassword([]byte(newPassword))
+}
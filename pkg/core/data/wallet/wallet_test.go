@@ -2,6 +2,7 @@ package wallet
 
 import (
 	"bytes"
+	"io/ioutil"
 	"math/big"
 	"math/rand"
 	"os"
@@ -10,10 +11,12 @@ import (
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/database"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/mnemonic"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/transactions"
 
 	"github.com/bwesterb/go-ristretto"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/sha3"
 )
 
 const dbPath = "testDb"
@@ -43,6 +46,143 @@ func TestNewWallet(t *testing.T) {
 
 	assert.Equal(t, w.consensusKeys.BLSSecretKey, loadedWallet.consensusKeys.BLSSecretKey)
 	assert.True(t, bytes.Equal(w.consensusKeys.BLSPubKeyBytes, loadedWallet.consensusKeys.BLSPubKeyBytes))
+
+	// The seed file is only readable by its owner
+	info, err := os.Stat(walletPath)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
+
+func TestChangePassword(t *testing.T) {
+	netPrefix := byte(1)
+
+	db, err := database.New(dbPath)
+	assert.Nil(t, err)
+	defer os.RemoveAll(dbPath)
+	defer os.Remove(walletPath)
+
+	w, err := New(rand.Read, netPrefix, db, GenerateDecoys, GenerateInputs, "pass", walletPath)
+	assert.Nil(t, err)
+
+	assert.Equal(t, database.ErrWrongPassword, w.ChangePassword("wrongPass", "newPass"))
+	assert.NoError(t, w.ChangePassword("pass", "newPass"))
+
+	// Reopen the database, to drop the unlocked key
+	assert.NoError(t, db.Close())
+	db, err = database.New(dbPath)
+	assert.Nil(t, err)
+	defer func() { _ = db.Close() }()
+
+	_, err = LoadFromFile(netPrefix, db, GenerateDecoys, GenerateInputs, "pass", walletPath)
+	assert.Equal(t, database.ErrWrongPassword, err)
+
+	loadedWallet, err := LoadFromFile(netPrefix, db, GenerateDecoys, GenerateInputs, "newPass", walletPath)
+	assert.Nil(t, err)
+	assert.Equal(t, w.PublicKey(), loadedWallet.PublicKey())
+}
+
+// Test that a wallet restored from the mnemonic of its seed has the same keys.
+func TestRestoreFromMnemonic(t *testing.T) {
+	netPrefix := byte(1)
+
+	seed, err := GenerateSeed(rand.Read)
+	assert.NoError(t, err)
+	phrase, err := mnemonic.FromSeed(seed)
+	assert.NoError(t, err)
+
+	w := generateWalletFromSeed(t, netPrefix, seed, "alice", "alice.dat")
+	defer os.Remove("alice.dat")
+
+	restoredSeed, err := mnemonic.ToSeed(phrase)
+	assert.NoError(t, err)
+	restored := generateWalletFromSeed(t, netPrefix, restoredSeed, "bob", "bob.dat")
+	defer os.Remove("bob.dat")
+
+	assert.Equal(t, w.PublicKey(), restored.PublicKey())
+	assert.True(t, bytes.Equal(w.consensusKeys.BLSPubKeyBytes, restored.consensusKeys.BLSPubKeyBytes))
+}
+
+// Test that the exported consensus keys are the wallet ones, and that a wallet
+// file is not loaded as a consensus keys file.
+func TestExportConsensusKeys(t *testing.T) {
+	netPrefix := byte(1)
+
+	db, err := database.New(dbPath)
+	assert.Nil(t, err)
+	defer os.RemoveAll(dbPath)
+	defer os.Remove(walletPath)
+	defer os.Remove("consensus.keys")
+
+	w, err := New(rand.Read, netPrefix, db, GenerateDecoys, GenerateInputs, "pass", walletPath)
+	assert.Nil(t, err)
+
+	assert.Error(t, ExportConsensusKeys("wrongPass", walletPath, "keysPass", "consensus.keys"))
+	assert.NoError(t, ExportConsensusKeys("pass", walletPath, "keysPass", "consensus.keys"))
+	assert.Equal(t, ErrSeedFileExists, ExportConsensusKeys("pass", walletPath, "keysPass", "consensus.keys"))
+
+	keys, err := LoadConsensusKeys("keysPass", "consensus.keys")
+	assert.NoError(t, err)
+	assert.True(t, bytes.Equal(w.Keys().BLSPubKeyBytes, keys.BLSPubKeyBytes))
+
+	_, err = LoadConsensusKeys("pass", walletPath)
+	assert.Equal(t, ErrNotConsensusKeys, err)
+}
+
+func generateWalletFromSeed(t *testing.T, netPrefix byte, seed []byte, path string, wPath string) *Wallet {
+	db, err := database.New(path)
+	assert.Nil(t, err)
+	defer os.RemoveAll(path)
+
+	os.Remove(wPath)
+	w, err := LoadFromSeed(seed, netPrefix, db, GenerateDecoys, GenerateInputs, "pass", wPath)
+	assert.Nil(t, err)
+	return w
+}
+
+// Test that the seed files written by the previous versions of the wallet
+// are loaded and upgraded.
+func TestLoadLegacySeedFile(t *testing.T) {
+	netPrefix := byte(1)
+
+	db, err := database.New(dbPath)
+	assert.Nil(t, err)
+	defer os.RemoveAll(dbPath)
+	defer os.Remove(walletPath)
+
+	seed := make([]byte, 64)
+	_, _ = rand.Read(seed)
+	assert.NoError(t, writeLegacySeed(seed, "pass", walletPath))
+
+	w, err := LoadFromFile(netPrefix, db, GenerateDecoys, GenerateInputs, "pass", walletPath)
+	assert.Nil(t, err)
+	assert.Equal(t, key.NewKeyPair(seed).PublicKey(), w.PublicKey())
+
+	data, err := ioutil.ReadFile(walletPath)
+	assert.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(data, []byte(seedMagic)))
+
+	info, err := os.Stat(walletPath)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	fetched, legacy, err := fetchSeed("pass", walletPath)
+	assert.NoError(t, err)
+	assert.False(t, legacy)
+	assert.Equal(t, seed, fetched)
+}
+
+// writeLegacySeed writes a seed file as the previous versions of the wallet
+// did
+func writeLegacySeed(seed []byte, password string, file string) error {
+	digest := sha3.Sum256([]byte(password))
+	gcm, err := newGCM(digest[:])
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	_, _ = rand.Read(nonce)
+	return ioutil.WriteFile(file, gcm.Seal(nonce, nonce, seed, nil), 0777)
 }
 
 func TestReceivedTx(t *testing.T) {
@@ -143,6 +283,78 @@ func TestSpendLockedInputs(t *testing.T) {
 	assert.Error(t, alice.Sign(standard))
 }
 
+// Test that the stake of the wallet unlocks at the end of the cooldown, once
+// the wallet provisioner unstakes.
+func TestUnstakeUnlocksStake(t *testing.T) {
+	netPrefix := byte(1)
+	alice := generateWallet(t, netPrefix, "alice", walletPath)
+	defer os.Remove(walletPath)
+
+	blk := block.NewBlock()
+	blk.Header.Height = 0
+	blk.AddTx(generateStakeTx(t, 20, alice, 100000))
+	_, _, err := alice.CheckWireBlock(*blk)
+	assert.NoError(t, err)
+
+	unstake, err := transactions.NewUnstake(0, netPrefix, 0, alice.Keys().BLSPubKeyBytes)
+	assert.NoError(t, err)
+	blk = block.NewBlock()
+	blk.Header.Height = 1
+	blk.AddTx(unstake)
+	_, _, err = alice.CheckWireBlock(*blk)
+	assert.NoError(t, err)
+
+	_, locked, err := alice.db.FetchBalance()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(20), locked)
+
+	assert.NoError(t, alice.db.UpdateLockedInputs(transactions.UnstakeUnlockHeight(1)))
+	_, locked, err = alice.db.FetchBalance()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), locked)
+}
+
+func TestPrepareKeepsInputs(t *testing.T) {
+	netPrefix := byte(1)
+
+	alice := generateWallet(t, netPrefix, "alice", "alice.dat")
+	bob := generateWallet(t, netPrefix, "bob", "bob.dat")
+	defer os.Remove("alice.dat")
+	defer os.Remove("bob.dat")
+	bobAddr, err := bob.keyPair.PublicKey().PublicAddress(netPrefix)
+	assert.NoError(t, err)
+
+	blk := block.NewBlock()
+	blk.AddTx(generateStandardTx(t, *bobAddr, 20, alice))
+	_, err = bob.CheckWireBlockReceived(*blk)
+	assert.NoError(t, err)
+
+	bob.fetchInputs = fetchInputs
+	var amount ristretto.Scalar
+	amount.SetBigInt(big.NewInt(5))
+
+	// Preparing a tx does not spend the inputs
+	tx, err := bob.NewStandardTx(0)
+	assert.NoError(t, err)
+	assert.NoError(t, tx.AddOutput(*bobAddr, amount))
+	assert.NoError(t, bob.Prepare(tx))
+	assert.NotEmpty(t, tx.Inputs)
+
+	balance, _, err := bob.Balance()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(20), balance)
+
+	// Signing it does
+	tx, err = bob.NewStandardTx(0)
+	assert.NoError(t, err)
+	assert.NoError(t, tx.AddOutput(*bobAddr, amount))
+	assert.NoError(t, bob.Sign(tx))
+
+	balance, _, err = bob.Balance()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), balance)
+}
+
 func TestCheckUnconfirmedBalance(t *testing.T) {
 	netPrefix := byte(1)
 
@@ -168,6 +380,165 @@ func TestCheckUnconfirmedBalance(t *testing.T) {
 	assert.Equal(t, uint64(int64(numTxs)*amount), balance)
 }
 
+// Test that a watch-only wallet records the incoming payments, and refuses to
+// create transactions.
+func TestWatchOnly(t *testing.T) {
+	netPrefix := byte(1)
+
+	alice := generateWallet(t, netPrefix, "alice", "alice.dat")
+	bob := generateWallet(t, netPrefix, "bob", "bob.dat")
+	defer os.Remove("alice.dat")
+	defer os.Remove("bob.dat")
+	bobAddr, err := bob.keyPair.PublicKey().PublicAddress(netPrefix)
+	assert.Nil(t, err)
+
+	viewKey, err := key.ViewKeyFromBytes(bob.ViewKey())
+	assert.NoError(t, err)
+
+	db, err := database.New(dbPath)
+	assert.Nil(t, err)
+	defer os.RemoveAll(dbPath)
+
+	watcher, err := NewWatchOnly(netPrefix, db, GenerateDecoys, viewKey, "watch")
+	assert.NoError(t, err)
+	assert.True(t, watcher.WatchOnly())
+	assert.False(t, bob.WatchOnly())
+	assert.Equal(t, bob.PublicKey(), watcher.PublicKey())
+
+	var numTxs = 3          // numTxs to send to Bob
+	var amount = int64(500) // amount to send for each tx
+
+	blk := block.NewBlock()
+	blk.Header.Height = 0
+	for i := 0; i < numTxs; i++ {
+		blk.AddTx(generateStandardTx(t, *bobAddr, amount, alice))
+	}
+
+	_, received, err := watcher.CheckWireBlock(*blk)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(numTxs), received)
+
+	unlockedBalance, _, err := watcher.Balance()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(int64(numTxs)*amount), unlockedBalance)
+
+	records, err := watcher.FetchTxHistory()
+	assert.NoError(t, err)
+	assert.Len(t, records, numTxs)
+
+	// No transaction can be created
+	_, err = watcher.NewStandardTx(0)
+	assert.Equal(t, ErrWatchOnly, err)
+
+	var stake ristretto.Scalar
+	stake.SetBigInt(big.NewInt(amount))
+	_, err = watcher.NewStakeTx(0, 1000, stake)
+	assert.Equal(t, ErrWatchOnly, err)
+	_, err = watcher.NewBidTx(0, 1000, stake)
+	assert.Equal(t, ErrWatchOnly, err)
+
+	tx, err := bob.NewStandardTx(0)
+	assert.NoError(t, err)
+	assert.NoError(t, tx.AddOutput(*bobAddr, stake))
+	assert.Equal(t, ErrWatchOnly, watcher.Sign(tx))
+
+	_, err = watcher.PrivateSpend()
+	assert.Equal(t, ErrWatchOnly, err)
+
+	// A spending key is not a view key
+	_, err = NewWatchOnly(netPrefix, db, GenerateDecoys, bob.keyPair, "watch")
+	assert.Error(t, err)
+}
+
+// Test that a watch-only wallet builds a tx, which is signed offline from the
+// seed of the wallet.
+func TestOfflineSigning(t *testing.T) {
+	netPrefix := byte(1)
+
+	alice := generateWallet(t, netPrefix, "alice", "alice.dat")
+	defer os.Remove("alice.dat")
+	aliceAddr, err := alice.keyPair.PublicKey().PublicAddress(netPrefix)
+	assert.Nil(t, err)
+
+	seed, err := GenerateSeed(rand.Read)
+	assert.NoError(t, err)
+	signer, err := NewSigner(seed, netPrefix)
+	assert.NoError(t, err)
+	bobAddr, err := signer.PublicAddress()
+	assert.NoError(t, err)
+
+	viewKey, err := key.ViewKeyFromBytes(signer.ViewKey())
+	assert.NoError(t, err)
+
+	db, err := database.New(dbPath)
+	assert.Nil(t, err)
+	defer os.RemoveAll(dbPath)
+
+	watcher, err := NewWatchOnly(netPrefix, db, GenerateDecoys, viewKey, "watch")
+	assert.NoError(t, err)
+
+	blk := block.NewBlock()
+	blk.Header.Height = 0
+	for i := 0; i < 3; i++ {
+		blk.AddTx(generateStandardTx(t, key.PublicAddress(bobAddr), 500, alice))
+	}
+
+	_, _, err = watcher.CheckWireBlock(*blk)
+	assert.NoError(t, err)
+
+	// Two inputs of 500 pay 600 to Alice and a fee of 100
+	u, err := watcher.NewUnsignedTx([]UnsignedOutput{{Address: *aliceAddr, Amount: 600}}, 100)
+	assert.NoError(t, err)
+	assert.Len(t, u.Inputs, 2)
+	assert.Len(t, u.Outputs, 2)
+	assert.Equal(t, uint64(300), u.Outputs[1].Amount)
+	assert.Equal(t, key.PublicAddress(bobAddr), u.Outputs[1].Address)
+
+	buf := new(bytes.Buffer)
+	assert.NoError(t, MarshalUnsignedTx(buf, u))
+	encoded := append([]byte{}, buf.Bytes()...)
+
+	decoded, err := UnmarshalUnsignedTx(buf)
+	assert.NoError(t, err)
+	buf.Reset()
+	assert.NoError(t, MarshalUnsignedTx(buf, decoded))
+	assert.Equal(t, encoded, buf.Bytes())
+
+	_, err = UnmarshalUnsignedTx(bytes.NewBuffer(encoded[1:]))
+	assert.Error(t, err)
+
+	tx, err := signer.Sign(decoded)
+	assert.NoError(t, err)
+	assert.Len(t, tx.Inputs, 2)
+	assert.Len(t, tx.Outputs, 2)
+	for i, input := range tx.Inputs {
+		assert.True(t, input.PubKey.P.Equals(&u.Inputs[i].PubKey))
+	}
+
+	// Another wallet can not sign the tx
+	otherSeed, err := GenerateSeed(rand.Read)
+	assert.NoError(t, err)
+	other, err := NewSigner(otherSeed, netPrefix)
+	assert.NoError(t, err)
+	_, err = other.Sign(decoded)
+	assert.Error(t, err)
+
+	// The inputs must add up to the outputs and the fee
+	decoded.Fee++
+	_, err = signer.Sign(decoded)
+	assert.Error(t, err)
+
+	// Only a watch-only wallet builds unsigned txs
+	_, err = alice.NewUnsignedTx(nil, 100)
+	assert.Equal(t, ErrNotWatchOnly, err)
+
+	// The inputs are spent once the signed tx is published
+	assert.NoError(t, watcher.RemoveSpentInputs(tx))
+	unlockedBalance, _, err := watcher.Balance()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(500), unlockedBalance)
+}
+
 func TestCatchEOF(t *testing.T) {
 	netPrefix := byte(1)
 
@@ -175,6 +546,10 @@ func TestCatchEOF(t *testing.T) {
 	assert.Nil(t, err)
 	defer os.RemoveAll(dbPath)
 
+	// The cost of the seed file KDF is irrelevant here
+	defer func(p kdfParams) { seedKDF = p }(seedKDF)
+	seedKDF = kdfParams{time: 1, memory: 8, threads: 1}
+
 	// Generate 1000 new wallets
 	for i := 0; i < 1000; i++ {
 		_, err = New(rand.Read, netPrefix, db, GenerateDecoys, GenerateInputs, "pass", walletPath)
@@ -242,12 +617,8 @@ func hasDuplicates(elements []ristretto.Point) bool {
 	return false
 }
 
-func fetchInputs(netPrefix byte, db *database.DB, totalAmount int64, key *key.Key) ([]*transactions.Input, int64, error) {
+func fetchInputs(netPrefix byte, db *database.DB, totalAmount int64, _ *key.Key) ([]*transactions.Input, int64, error) {
 	// Fetch all inputs from database that are >= totalAmount
 	// returns error if inputs do not add up to total amount
-	privSpend, err := key.PrivateSpend()
-	if err != nil {
-		return nil, 0, err
-	}
-	return db.FetchInputs(privSpend.Bytes(), totalAmount)
+	return db.FetchInputs(totalAmount)
 }
@@ -53,6 +53,11 @@ var (
 	OutputKeyPrefix = []byte{0x07}
 	// BidValuesPrefix is the prefix to identify Bid Values
 	BidValuesPrefix = []byte{0x08}
+	// EvidencePrefix is the prefix to identify equivocation Evidence
+	EvidencePrefix = []byte{0x09}
+	// StakePrefix is the prefix to identify the stake outputs of a
+	// provisioner
+	StakePrefix = []byte{0x0A}
 )
 
 type transaction struct {
@@ -108,6 +113,11 @@ func (t transaction) StoreBlock(b *block.Block) error {
 		return errors.New("too many transactions")
 	}
 
+	// stake outputs, and their unlock heights, added by the block. The batch
+	// is not visible to the snapshot
+	stakes := make(map[string][]byte)
+	unlockHeights := make(map[string]uint64)
+
 	// Put block transaction data. A KV pair per a single transaction is added
 	// into the store
 	for i, tx := range b.Txs {
@@ -169,11 +179,57 @@ func (t transaction) StoreBlock(b *block.Block) error {
 			// Only lock the first output, so that change outputs are
 			// not affected.
 			if i == 0 {
-				binary.LittleEndian.PutUint64(v, tx.LockTime()+b.Header.Height)
+				binary.LittleEndian.PutUint64(v, transactions.UnlockHeight(tx, b.Header.Height))
 			}
 			t.put(append(OutputKeyPrefix, output.PubKey.P.Bytes()...), v)
 		}
 
+		switch tx.Type() {
+		case transactions.StakeType:
+			// Schema
+			//
+			// Key = StakePrefix + tx.PubKeyBLS
+			// Value = tx.output[0].PublicKey of every stake
+			//
+			// To unlock the stake outputs of an unstaking provisioner
+			stake := tx.(*transactions.Stake)
+			outputs, err := t.fetchStakeOutputs(stake.PubKeyBLS, stakes)
+			if err != nil {
+				return err
+			}
+
+			destkey := stake.Outputs[0].PubKey.P.Bytes()
+			outputs = append(outputs[:len(outputs):len(outputs)], destkey...)
+			stakes[string(stake.PubKeyBLS)] = outputs
+			unlockHeights[string(destkey)] = transactions.UnlockHeight(tx, b.Header.Height)
+			t.put(append(StakePrefix, stake.PubKeyBLS...), outputs)
+		case transactions.UnstakeType:
+			// The stake outputs unlock at the end of the cooldown, along
+			// with the stakes
+			unstake := tx.(*transactions.Unstake)
+			outputs, err := t.fetchStakeOutputs(unstake.PubKeyBLS, stakes)
+			if err != nil {
+				return err
+			}
+
+			unlockHeight := transactions.UnstakeUnlockHeight(b.Header.Height)
+			for j := 0; j+32 <= len(outputs); j += 32 {
+				destkey := outputs[j : j+32]
+				current, ok := unlockHeights[string(destkey)]
+				if !ok {
+					if current, err = t.FetchOutputUnlockHeight(destkey); err != nil {
+						return err
+					}
+				}
+
+				if current > unlockHeight {
+					v := make([]byte, 8)
+					binary.LittleEndian.PutUint64(v, unlockHeight)
+					unlockHeights[string(destkey)] = unlockHeight
+					t.put(append(OutputKeyPrefix, destkey...), v)
+				}
+			}
+		}
 	}
 
 	// Key = HeightPrefix + block.header.height
@@ -303,6 +359,21 @@ func (t transaction) FetchOutputUnlockHeight(destkey []byte) (uint64, error) {
 	return unlockHeight, err
 }
 
+// fetchStakeOutputs returns the public keys of the stake outputs of a
+// provisioner, including the ones added by the block being stored
+func (t transaction) fetchStakeOutputs(pubKeyBLS []byte, stakes map[string][]byte) ([]byte, error) {
+	if outputs, ok := stakes[string(pubKeyBLS)]; ok {
+		return outputs, nil
+	}
+
+	outputs, err := t.snapshot.Get(append(StakePrefix, pubKeyBLS...), nil)
+	if err == leveldb.ErrNotFound {
+		return nil, nil
+	}
+
+	return outputs, err
+}
+
 // FetchDecoys iterates over the outputs and fetches `numDecoys` amount
 // of output public keys
 func (t transaction) FetchDecoys(numDecoys int) []ristretto.Point {
@@ -625,6 +696,40 @@ func (t transaction) FetchBidValues() ([]byte, []byte, error) {
 	return value[0:32], value[32:64], nil
 }
 
+// StoreEvidence stores a marshaled Evidence.
+// Key = EvidencePrefix + evidence ID
+func (t transaction) StoreEvidence(id []byte, evidence []byte) error {
+	key := append(EvidencePrefix, id...)
+	t.put(key, evidence)
+	return nil
+}
+
+// FetchEvidenceExists checks if an Evidence with the given ID is stored
+func (t transaction) FetchEvidenceExists(id []byte) (bool, error) {
+	key := append(EvidencePrefix, id...)
+	return t.snapshot.Has(key, nil)
+}
+
+// FetchEvidence returns all the stored Evidence
+func (t transaction) FetchEvidence() ([][]byte, error) {
+	iterator := t.snapshot.NewIterator(util.BytesPrefix(EvidencePrefix), nil)
+	defer iterator.Release()
+
+	evidence := make([][]byte, 0)
+	for iterator.Next() {
+		// the iterator reuses its buffers, so the value needs to be copied
+		value := make([]byte, len(iterator.Value()))
+		copy(value, iterator.Value())
+		evidence = append(evidence, value)
+	}
+
+	if err := iterator.Error(); err != nil {
+		return nil, err
+	}
+
+	return evidence, nil
+}
+
 // FetchBlockHeightSince uses binary search to find a block height
 func (t transaction) FetchBlockHeightSince(sinceUnixTime int64, offset uint64) (uint64, error) {
 
@@ -105,6 +105,18 @@ type Transaction interface {
 	// expiry height from the database.
 	FetchBidValues() ([]byte, []byte, error)
 
+	// StoreEvidence stores a marshaled equivocation evidence under its ID.
+	// Storing the same evidence twice is harmless.
+	StoreEvidence(id []byte, evidence []byte) error
+
+	// FetchEvidenceExists returns whether or not an evidence with the given
+	// ID has been stored
+	FetchEvidenceExists(id []byte) (bool, error)
+
+	// FetchEvidence returns all the marshaled equivocation evidence stored
+	// in the database
+	FetchEvidence() ([][]byte, error)
+
 	// FetchBlockHeightSince try to find height of a block generated around
 	// sinceUnixTime starting the search from height (tip - offset)
 	FetchBlockHeightSince(sinceUnixTime int64, offset uint64) (uint64, error)
@@ -22,6 +22,8 @@ const (
 	stateInd
 	bidValuesInd
 	outputKeyInd
+	evidenceInd
+	stakesInd
 	maxInd
 )
 
@@ -13,6 +13,7 @@ import (
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database/utils"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-crypto/hash"
 )
 
 type transaction struct {
@@ -75,10 +76,46 @@ func (t *transaction) StoreBlock(b *block.Block) error {
 			// Only lock the first output, so that change outputs are
 			// not affected.
 			if i == 0 {
-				binary.LittleEndian.PutUint64(value, tx.LockTime()+b.Header.Height)
+				binary.LittleEndian.PutUint64(value, transactions.UnlockHeight(tx, b.Header.Height))
 			}
 			t.batch[outputKeyInd][toKey(output.PubKey.P.Bytes())] = value
 		}
+
+		switch tx.Type() {
+		case transactions.StakeType:
+			stake := tx.(*transactions.Stake)
+			k, err := stakesKey(stake.PubKeyBLS)
+			if err != nil {
+				return err
+			}
+
+			outputs := t.fetchStakeOutputs(k)
+			t.batch[stakesInd][k] = append(outputs[:len(outputs):len(outputs)], stake.Outputs[0].PubKey.P.Bytes()...)
+		case transactions.UnstakeType:
+			// The stake outputs unlock at the end of the cooldown, along
+			// with the stakes
+			unstake := tx.(*transactions.Unstake)
+			k, err := stakesKey(unstake.PubKeyBLS)
+			if err != nil {
+				return err
+			}
+
+			outputs := t.fetchStakeOutputs(k)
+			unlockHeight := transactions.UnstakeUnlockHeight(b.Header.Height)
+			for j := 0; j+32 <= len(outputs); j += 32 {
+				destkey := toKey(outputs[j : j+32])
+				current, ok := t.batch[outputKeyInd][destkey]
+				if !ok {
+					current = t.db.storage[outputKeyInd][destkey]
+				}
+
+				if len(current) == 8 && binary.LittleEndian.Uint64(current) > unlockHeight {
+					value := make([]byte, 8)
+					binary.LittleEndian.PutUint64(value, unlockHeight)
+					t.batch[outputKeyInd][destkey] = value
+				}
+			}
+		}
 	}
 
 	// Map height to buffer bytes
@@ -271,6 +308,27 @@ func (t transaction) FetchState() (*database.State, error) {
 	return s, nil
 }
 
+// stakesKey returns the key of the stake outputs of a provisioner. The BLS
+// public keys do not fit a key
+func stakesKey(pubKeyBLS []byte) (key, error) {
+	digest, err := hash.Sha3256(pubKeyBLS)
+	if err != nil {
+		return key{}, err
+	}
+
+	return toKey(digest), nil
+}
+
+// fetchStakeOutputs returns the public keys of the stake outputs of a
+// provisioner, including the ones added by the batch
+func (t transaction) fetchStakeOutputs(k key) []byte {
+	if outputs, ok := t.batch[stakesInd][k]; ok {
+		return outputs
+	}
+
+	return t.db.storage[stakesInd][k]
+}
+
 func toKey(d []byte) key {
 	var k key
 	copy(k[:], d)
@@ -346,6 +404,27 @@ func (t *transaction) FetchBidValues() ([]byte, []byte, error) {
 	return values[0:32], values[32:], nil
 }
 
+func (t *transaction) StoreEvidence(id []byte, evidence []byte) error {
+	key := append([]byte("evidence"), id...)
+	t.batch[evidenceInd][toKey(key)] = evidence
+	return nil
+}
+
+func (t transaction) FetchEvidenceExists(id []byte) (bool, error) {
+	key := append([]byte("evidence"), id...)
+	_, exists := t.db.storage[evidenceInd][toKey(key)]
+	return exists, nil
+}
+
+func (t transaction) FetchEvidence() ([][]byte, error) {
+	evidence := make([][]byte, 0, len(t.db.storage[evidenceInd]))
+	for _, v := range t.db.storage[evidenceInd] {
+		evidence = append(evidence, v)
+	}
+
+	return evidence, nil
+}
+
 // FetchBlockHeightSince uses binary search to find a block height
 // NB: Duplicates FetchBlockHeightSince heavy driver
 func (t transaction) FetchBlockHeightSince(sinceUnixTime int64, offset uint64) (uint64, error) {
@@ -9,6 +9,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/transactions"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
@@ -784,6 +785,39 @@ func TestFetchOutputUnlockHeight(test *testing.T) {
 	}
 }
 
+// TestUnstakeUnlockHeight ensures that the stake outputs of an unstaking
+// provisioner unlock at the end of the unstake cooldown
+func TestUnstakeUnlockHeight(test *testing.T) {
+
+	genBlocks, err := generateChainBlocks(test, 2)
+	require.NoError(test, err)
+
+	keys, err := key.NewRandKeys()
+	require.NoError(test, err)
+
+	stake, err := helper.RandomStakeTx(test, false)
+	require.NoError(test, err)
+	stake.PubKeyBLS = keys.BLSPubKeyBytes
+	genBlocks[0].Txs = append(genBlocks[0].Txs, stake)
+	genBlocks[1].Txs = append(genBlocks[1].Txs, helper.RandomUnstakeTx(test, keys))
+
+	// the stake output is locked until the end of the stake
+	require.NoError(test, storeBlocks(db, genBlocks[:1]))
+	destkey := stake.Outputs[0].PubKey.P.Bytes()
+	require.NoError(test, db.View(func(t database.Transaction) error {
+		unlockHeight, err := t.FetchOutputUnlockHeight(destkey)
+		assert.Equal(test, transactions.UnlockHeight(stake, genBlocks[0].Header.Height), unlockHeight)
+		return err
+	}))
+
+	require.NoError(test, storeBlocks(db, genBlocks[1:]))
+	require.NoError(test, db.View(func(t database.Transaction) error {
+		unlockHeight, err := t.FetchOutputUnlockHeight(destkey)
+		assert.Equal(test, transactions.UnstakeUnlockHeight(genBlocks[1].Header.Height), unlockHeight)
+		return err
+	}))
+}
+
 func TestFetchDecoys(test *testing.T) {
 	test.Parallel()
 
@@ -889,6 +923,47 @@ func TestStoreFetchBidValues(test *testing.T) {
 	}))
 }
 
+func TestStoreFetchEvidence(test *testing.T) {
+	test.Parallel()
+
+	id1, _ := crypto.RandEntropy(32)
+	id2, _ := crypto.RandEntropy(32)
+	e1, _ := crypto.RandEntropy(100)
+	e2, _ := crypto.RandEntropy(100)
+
+	assert.NoError(test, db.Update(func(t database.Transaction) error {
+		if err := t.StoreEvidence(id1, e1); err != nil {
+			return err
+		}
+
+		return t.StoreEvidence(id2, e2)
+	}))
+
+	assert.NoError(test, db.View(func(t database.Transaction) error {
+		exists, err := t.FetchEvidenceExists(id1)
+		if err != nil {
+			return err
+		}
+		assert.True(test, exists)
+
+		unknown, _ := crypto.RandEntropy(32)
+		exists, err = t.FetchEvidenceExists(unknown)
+		if err != nil {
+			return err
+		}
+		assert.False(test, exists)
+
+		evidence, err := t.FetchEvidence()
+		if err != nil {
+			return err
+		}
+
+		assert.Contains(test, evidence, e1)
+		assert.Contains(test, evidence, e2)
+		return nil
+	}))
+}
+
 // _TestPersistence tries to ensure if driver provides persistence storage.
 // The procedure is simply based on:
 // 1. Close the driver
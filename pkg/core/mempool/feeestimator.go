@@ -0,0 +1,188 @@
+package mempool
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+)
+
+const (
+	// MaxFeeTarget is the maximum number of blocks a fee rate can be
+	// estimated for
+	MaxFeeTarget = 50
+
+	// feeHistoryLen is the number of recent blocks the fee estimation is
+	// based on
+	feeHistoryLen = 200
+
+	// fullBlockPercent is the percentage of the block tx set capacity above
+	// which a block is considered full. The txs of a block which is not full
+	// could have paid the minimum fee
+	fullBlockPercent = 90
+
+	// feeMissProbability is the accepted probability of a tx paying the
+	// estimated fee rate not to be included within the target
+	feeMissProbability = 0.05
+)
+
+// ErrInvalidFeeTarget is returned when estimating a fee rate for a target out
+// of range
+var ErrInvalidFeeTarget = fmt.Errorf("fee target must be between 1 and %d blocks", MaxFeeTarget)
+
+// FeeEstimate is the fee rate suggested for a tx to be included within a
+// target number of blocks
+type FeeEstimate struct {
+	// FeeRate is the suggested fee rate, in units per kB (see
+	// transactions.FeeRateUnit). A zero rate means that the minimum fee is
+	// enough
+	FeeRate uint64
+	// TargetBlocks is the number of blocks the tx is expected to be
+	// included within
+	TargetBlocks uint64
+	// MinFee is the minimum fee of a tx, regardless of its size
+	MinFee uint64
+	// Blocks is the number of recent blocks the estimate is based on
+	Blocks int
+}
+
+// feeEstimator suggests fee rates from the minimum fee rates of the recent
+// blocks and from the fee rates of the txs in the verified pool. The recent
+// blocks are the ones accepted since the node started.
+type feeEstimator struct {
+	// capacity is the size in bytes of the tx set of a block
+	capacity uint64
+
+	// minRates are the minimum fee rates of the recent blocks, the oldest
+	// first. It is zero for the blocks which are not full.
+	minRates []uint64
+}
+
+func newFeeEstimator(capacity uint64) *feeEstimator {
+	return &feeEstimator{
+		capacity: capacity,
+		minRates: make([]uint64, 0, feeHistoryLen),
+	}
+}
+
+// addBlock records the minimum fee rate of the txs of an accepted block
+func (f *feeEstimator) addBlock(b block.Block) {
+	var size uint64
+	minRate := uint64(math.MaxUint64)
+	for _, tx := range b.Txs {
+		if tx.Type() == transactions.CoinbaseType {
+			continue
+		}
+
+		buf := new(bytes.Buffer)
+		if err := message.MarshalTx(buf, tx); err != nil {
+			continue
+		}
+
+		txSize := uint64(buf.Len())
+		size += txSize
+
+		rate := transactions.FeeRate(tx.StandardTx().Fee.BigInt().Uint64(), txSize)
+		if rate < minRate {
+			minRate = rate
+		}
+	}
+
+	if size*100 < f.capacity*fullBlockPercent {
+		minRate = 0
+	}
+
+	if len(f.minRates) == feeHistoryLen {
+		f.minRates = append(f.minRates[:0], f.minRates[1:]...)
+	}
+
+	f.minRates = append(f.minRates, minRate)
+}
+
+// estimate suggests the fee rate for a tx to be included within `target`
+// blocks. The rate is the highest between the one which got into the recent
+// blocks and the one needed to be ahead of the pool txs which do not fit in
+// `target` blocks.
+func (f *feeEstimator) estimate(pool Pool, target uint64) (FeeEstimate, error) {
+	if target == 0 || target > MaxFeeTarget {
+		return FeeEstimate{}, ErrInvalidFeeTarget
+	}
+
+	rate := f.historicalRate(target)
+	if r := f.pendingRate(pool, target); r > rate {
+		rate = r
+	}
+
+	return FeeEstimate{
+		FeeRate:      rate,
+		TargetBlocks: target,
+		MinFee:       uint64(config.MinFee),
+		Blocks:       len(f.minRates),
+	}, nil
+}
+
+// historicalRate returns the lowest fee rate which misses `target` blocks
+// with a probability below feeMissProbability, assuming the minimum fee rates
+// of the next blocks are distributed as the recent ones.
+//
+// A tx paying the rate r misses a block with probability 1-F(r), F(r) being
+// the ratio of the recent blocks with a minimum fee rate up to r. It misses
+// `target` blocks with probability (1-F(r))^target.
+func (f *feeEstimator) historicalRate(target uint64) uint64 {
+	if len(f.minRates) == 0 {
+		return 0
+	}
+
+	sorted := make([]uint64, len(f.minRates))
+	copy(sorted, f.minRates)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	ratio := 1 - math.Pow(feeMissProbability, 1/float64(target))
+	i := int(math.Ceil(ratio*float64(len(sorted)))) - 1
+	if i < 0 {
+		i = 0
+	}
+
+	return sorted[i]
+}
+
+// pendingRate returns the fee rate needed for a tx to be ahead of the pool
+// txs which do not fit in `target` blocks, or zero if all of them fit
+func (f *feeEstimator) pendingRate(pool Pool, target uint64) uint64 {
+	type pending struct {
+		rate uint64
+		size uint64
+	}
+
+	txs := make([]pending, 0, pool.Len())
+	_ = pool.Range(func(k txHash, t TxDesc) error {
+		fee := t.tx.StandardTx().Fee.BigInt().Uint64()
+		txs = append(txs, pending{
+			rate: transactions.FeeRate(fee, uint64(t.size)),
+			size: uint64(t.size),
+		})
+		return nil
+	})
+
+	sort.Slice(txs, func(i, j int) bool { return txs[i].rate > txs[j].rate })
+
+	capacity := f.capacity * target
+	var total uint64
+	for _, tx := range txs {
+		total += tx.size
+		if total > capacity && tx.rate < math.MaxUint64 {
+			return tx.rate + 1
+		}
+
+		if total > capacity {
+			return tx.rate
+		}
+	}
+
+	return 0
+}
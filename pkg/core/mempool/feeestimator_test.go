@@ -0,0 +1,114 @@
+package mempool
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/tests/helper"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimateFeeTarget(t *testing.T) {
+	f := newFeeEstimator(1000)
+	pool := &HashMap{Capacity: 10}
+
+	for _, target := range []uint64{0, MaxFeeTarget + 1} {
+		_, err := f.estimate(pool, target)
+		assert.Equal(t, ErrInvalidFeeTarget, err)
+	}
+
+	// With no history and an empty pool, the minimum fee is enough
+	estimate, err := f.estimate(pool, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, FeeEstimate{FeeRate: 0, TargetBlocks: 1, MinFee: uint64(config.MinFee)}, estimate)
+}
+
+func TestEstimateFeeFromBlocks(t *testing.T) {
+	// Any block with a tx is full
+	f := newFeeEstimator(1)
+	pool := &HashMap{Capacity: 10}
+
+	// The minimum fee rates of the recent blocks go from 1000 to 100000.
+	// Multiples of transactions.FeeRateUnit are not rounded
+	for i := uint64(1); i <= 100; i++ {
+		rate := i * transactions.FeeRateUnit
+		b := helper.RandomBlock(t, i, 1)
+		b.Txs = []transactions.Transaction{
+			helper.RandomCoinBaseTx(t, false),
+			txWithFeeRate(t, rate),
+			txWithFeeRate(t, rate+10*transactions.FeeRateUnit),
+		}
+
+		f.addBlock(*b)
+	}
+
+	// 95% of the blocks include a tx with a fee rate of 95000
+	estimate, err := f.estimate(pool, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(95000), estimate.FeeRate)
+	assert.Equal(t, 100, estimate.Blocks)
+
+	// A tx with a fee rate of 78000 misses two blocks with a probability of
+	// 0.22^2, below 5%
+	estimate, err = f.estimate(pool, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(78000), estimate.FeeRate)
+
+	// Longer targets never need higher fee rates
+	prev := estimate.FeeRate
+	for target := uint64(3); target <= MaxFeeTarget; target++ {
+		estimate, err := f.estimate(pool, target)
+		assert.NoError(t, err)
+		assert.True(t, estimate.FeeRate <= prev)
+		prev = estimate.FeeRate
+	}
+
+	// Blocks which are not full do not require any fee rate
+	f.capacity = 1000 * 1000
+	for i := 0; i < feeHistoryLen; i++ {
+		f.addBlock(*block.NewBlock())
+	}
+
+	estimate, err = f.estimate(pool, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), estimate.FeeRate)
+	assert.Equal(t, feeHistoryLen, estimate.Blocks)
+}
+
+func TestEstimateFeeFromPool(t *testing.T) {
+	f := newFeeEstimator(1000)
+	pool := &HashMap{Capacity: 10}
+
+	for _, rate := range []uint64{300, 200, 100} {
+		tx := helper.RandomStandardTx(t, false)
+		tx.Fee.SetBigInt(new(big.Int).SetUint64(transactions.FeeForRate(rate, 600)))
+		assert.NoError(t, pool.Put(TxDesc{tx: tx, size: 600}))
+	}
+
+	// Only the tx with the highest fee rate fits in the next block
+	estimate, err := f.estimate(pool, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(201), estimate.FeeRate)
+
+	// All the txs fit in the next two blocks
+	estimate, err = f.estimate(pool, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), estimate.FeeRate)
+}
+
+func txWithFeeRate(t *testing.T, rate uint64) transactions.Transaction {
+	tx := helper.RandomStandardTx(t, false)
+
+	buf := new(bytes.Buffer)
+	if err := message.MarshalTx(buf, tx); err != nil {
+		t.Fatal(err)
+	}
+
+	tx.Fee.SetBigInt(new(big.Int).SetUint64(transactions.FeeForRate(rate, uint64(buf.Len()))))
+	return tx
+}
@@ -30,8 +30,9 @@ type (
 		// Block Generator to fetch highest-fee txs without delays in sorting
 		sorted []keyFee
 
-		// spent key images from the transactions in the pool
-		spentkeyImages map[keyImage]bool
+		// spent key images from the transactions in the pool, mapped to the
+		// spending tx
+		spentkeyImages map[keyImage]txHash
 		Capacity       uint32
 		txsSize        uint32
 	}
@@ -47,7 +48,7 @@ func (m *HashMap) Put(t TxDesc) error {
 	}
 
 	if m.spentkeyImages == nil {
-		m.spentkeyImages = make(map[keyImage]bool)
+		m.spentkeyImages = make(map[keyImage]txHash)
 	}
 
 	// store tx
@@ -80,7 +81,7 @@ func (m *HashMap) Put(t TxDesc) error {
 		if len(input.KeyImage.Bytes()) == keyImageSize {
 			var ki keyImage
 			copy(ki[:], input.KeyImage.Bytes())
-			m.spentkeyImages[ki] = true
+			m.spentkeyImages[ki] = k
 		} else {
 			return fmt.Errorf("invalid key image found at index %d", i)
 		}
@@ -89,6 +90,49 @@ func (m *HashMap) Put(t TxDesc) error {
 	return nil
 }
 
+// Delete removes the tx with the given txID, along with its key images
+func (m *HashMap) Delete(txID []byte) (TxDesc, bool) {
+	var k txHash
+	copy(k[:], txID)
+
+	t, ok := m.data[k]
+	if !ok {
+		return TxDesc{}, false
+	}
+
+	delete(m.data, k)
+	m.txsSize -= uint32(t.size)
+
+	// keys sorted by Fee are not indexed, so a linear search is needed
+	for i := range m.sorted {
+		if m.sorted[i].k == k {
+			m.sorted = append(m.sorted[:i], m.sorted[i+1:]...)
+			break
+		}
+	}
+
+	for _, input := range t.tx.StandardTx().Inputs {
+		var ki keyImage
+		copy(ki[:], input.KeyImage.Bytes())
+		delete(m.spentkeyImages, ki)
+	}
+
+	return t, true
+}
+
+// DeleteByKeyImage removes the tx which spends the given key image
+func (m *HashMap) DeleteByKeyImage(txInputKeyImage []byte) (TxDesc, bool) {
+	var ki keyImage
+	copy(ki[:], txInputKeyImage)
+
+	k, ok := m.spentkeyImages[ki]
+	if !ok {
+		return TxDesc{}, false
+	}
+
+	return m.Delete(k[:])
+}
+
 // Clone the entire pool
 func (m HashMap) Clone() []transactions.Transaction {
 
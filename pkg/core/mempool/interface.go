@@ -27,6 +27,12 @@ type Pool interface {
 	// Put sets the value for the given key. It overwrites any previous value
 	// for that key;
 	Put(t TxDesc) error
+	// Delete removes the tx with the given txID, along with its key images.
+	// It returns false if the tx is not in the pool.
+	Delete(txID []byte) (TxDesc, bool)
+	// DeleteByKeyImage removes the tx which spends the given keyImage. It
+	// returns false if no tx in the pool spends it.
+	DeleteByKeyImage(keyImage []byte) (TxDesc, bool)
 	// Get retrieves a transaction for a given txID, if it exists.
 	Get(txID []byte) transactions.Transaction
 	// Contains returns true if the given key is in the pool.
@@ -48,7 +54,7 @@ type Pool interface {
 	// Range iterates through all tx entries
 	Range(fn func(k txHash, t TxDesc) error) error
 
-	// RangeSort iterates through all tx entries sorted by Fee
-	// in a descending order
+	// RangeSort iterates through all tx entries sorted by Fee (or fee rate,
+	// depending on the implementation) in a descending order
 	RangeSort(fn func(k txHash, t TxDesc) (bool, error)) error
 }
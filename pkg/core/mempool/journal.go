@@ -0,0 +1,83 @@
+package mempool
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/encoding"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+)
+
+// journal is a flat file keeping a snapshot of the verified pool, so that
+// pending txs are not lost on node restart.
+//
+// The file format is a varint with the number of txs, followed by each tx in
+// its wire format.
+type journal struct {
+	path string
+}
+
+func newJournal(path string) *journal {
+	return &journal{path: path}
+}
+
+// save writes all txs from the pool into the journal file. The file is first
+// written to a temporary location and then renamed, in order to not corrupt
+// the previous snapshot in case of a crash.
+func (j *journal) save(p Pool) error {
+
+	buf := new(bytes.Buffer)
+	if err := encoding.WriteVarInt(buf, uint64(p.Len())); err != nil {
+		return err
+	}
+
+	err := p.Range(func(k txHash, t TxDesc) error {
+		return message.MarshalTx(buf, t.tx)
+	})
+
+	if err != nil {
+		return err
+	}
+
+	tmpPath := j.path + ".tmp"
+	if err := ioutil.WriteFile(tmpPath, buf.Bytes(), 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, j.path)
+}
+
+// load reads all txs stored in the journal file. A missing journal file is
+// not an error, as it simply means there is nothing to restore.
+func (j *journal) load() ([]transactions.Transaction, error) {
+
+	data, err := ioutil.ReadFile(j.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	buf := bytes.NewBuffer(data)
+	count, err := encoding.ReadVarInt(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	// The count is read from disk, so it is not trusted to preallocate the
+	// slice. A corrupted count fails on the first missing tx instead.
+	txs := make([]transactions.Transaction, 0)
+	for i := uint64(0); i < count; i++ {
+		tx, err := message.UnmarshalTx(buf)
+		if err != nil {
+			return txs, err
+		}
+
+		txs = append(txs, tx)
+	}
+
+	return txs, nil
+}
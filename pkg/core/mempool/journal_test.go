@@ -0,0 +1,43 @@
+package mempool
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJournalSaveLoad(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "mempool_journal")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	j := newJournal(filepath.Join(dir, "mempool.dat"))
+
+	// Loading a missing journal should yield no txs
+	txs, err := j.load()
+	assert.NoError(err)
+	assert.Empty(txs)
+
+	pool := &HashMap{Capacity: 20}
+	for _, tx := range randomSliceOfTxs(t, 5) {
+		assert.NoError(pool.Put(TxDesc{tx: tx, received: time.Now()}))
+	}
+
+	assert.NoError(j.save(pool))
+
+	txs, err = j.load()
+	assert.NoError(err)
+	assert.Equal(pool.Len(), len(txs))
+
+	for _, tx := range txs {
+		txid, err := tx.CalculateHash()
+		assert.NoError(err)
+		assert.True(pool.Contains(txid))
+	}
+}
@@ -10,8 +10,10 @@ import (
 
 	"github.com/dusk-network/dusk-blockchain/pkg/config"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/candidate"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/txevent"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database/heavy"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/verifiers"
@@ -21,7 +23,6 @@ import (
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
-	"github.com/dusk-network/dusk-crypto/merkletree"
 	"github.com/dusk-network/dusk-protobuf/autogen/go/node"
 	logger "github.com/sirupsen/logrus"
 )
@@ -31,6 +32,14 @@ var log = logger.WithFields(logger.Fields{"prefix": "mempool"})
 const (
 	consensusSeconds = 20
 	maxPendingLen    = 1000
+
+	// maxImmatureLen is the maximum number of timelocked txs held until
+	// their lock is met
+	maxImmatureLen = 1000
+
+	// defaultJournalInterval is the number of seconds between two journal
+	// writes, if not set in the config
+	defaultJournalInterval = 60
 )
 
 var (
@@ -40,6 +49,10 @@ var (
 	ErrAlreadyExists = errors.New("already exists")
 	// ErrDoubleSpending transaction uses outputs spent in other mempool txs
 	ErrDoubleSpending = errors.New("double-spending in mempool")
+	// ErrImmature timelocked transaction is held until its lock is met
+	ErrImmature = errors.New("timelock not matured, held until maturity")
+	// ErrImmatureQueueFull too many timelocked transactions are already held
+	ErrImmatureQueueFull = errors.New("pending-maturity queue is full")
 )
 
 // Mempool is a storage for the chain transactions that are valid according to the
@@ -49,6 +62,8 @@ type Mempool struct {
 	getMempoolTxsBySizeChan <-chan rpcbus.Request
 	getMempoolViewChan      <-chan rpcbus.Request
 	sendTxChan              <-chan rpcbus.Request
+	validateTxChan          <-chan rpcbus.Request
+	estimateFeeChan         <-chan rpcbus.Request
 
 	// transactions emitted by RPC and Peer subsystems
 	// pending to be verified before adding them to verified pool
@@ -57,12 +72,26 @@ type Mempool struct {
 	// verified txs to be included in next block
 	verified Pool
 
+	// fees suggests the fee rates of the txs, based on the recent blocks and
+	// the verified pool
+	fees *feeEstimator
+
 	// the collector to listen for new intermediate blocks
 	intermediateBlockChan <-chan block.Block
 	acceptedBlockChan     <-chan block.Block
 
+	// txs removed from the verified pool because of an intermediate block,
+	// mapped by block height
+	intermediateTxs map[uint64]intermediateTxs
+
+	// timelocked txs which are valid but can not be included in the next
+	// block, as their lock is not met yet. They are verified again once
+	// matured.
+	immature map[txHash]TxDesc
+
 	// used by tx verification procedure
 	latestBlockTimestamp int64
+	latestBlockHeight    uint64
 
 	eventBus *eventbus.EventBus
 	db       database.DB
@@ -70,9 +99,23 @@ type Mempool struct {
 	// the magic function that knows best what is valid chain Tx
 	verifyTx func(tx transactions.Transaction) error
 	quitChan chan struct{}
+	// stopped is closed when the main loop terminates. It is nil until Run
+	// is called
+	stopped chan struct{}
 
 	// ID of subscription to the TX topic on the EventBus
 	txSubscriberID uint32
+
+	// journal persists the verified txs in between node restarts. It is nil
+	// if the journal is disabled
+	journal *journal
+}
+
+// intermediateTxs are the txs removed from the verified pool because of an
+// intermediate block that has not been finalized yet
+type intermediateTxs struct {
+	blockHash []byte
+	txs       []TxDesc
 }
 
 // checkTx is responsible to determine if a tx is valid or not
@@ -88,8 +131,47 @@ func (m *Mempool) checkTx(tx transactions.Transaction) error {
 	}
 
 	// run the default blockchain verifier
-	approxBlockTime := uint64(consensusSeconds) + uint64(m.latestBlockTimestamp)
-	return verifiers.CheckTx(m.db, 0, approxBlockTime, tx)
+	return verifiers.CheckTx(m.db, 0, m.nextBlockHeight(), m.nextBlockTime(), tx)
+}
+
+// nextBlockHeight is the height of the block the verified txs are expected
+// to be included in
+func (m *Mempool) nextBlockHeight() uint64 {
+	return m.latestBlockHeight + 1
+}
+
+// nextBlockTime approximates the timestamp of the block the verified txs are
+// expected to be included in
+func (m *Mempool) nextBlockTime() uint64 {
+	if m.latestBlockTimestamp < 0 {
+		return uint64(consensusSeconds)
+	}
+
+	return uint64(consensusSeconds) + uint64(m.latestBlockTimestamp)
+}
+
+// loadChainTip sets the latest block height and timestamp from the tip
+// stored in the database, as no block is accepted before the mempool restores
+// its journal
+func (m *Mempool) loadChainTip() {
+	var header *block.Header
+	err := m.db.View(func(t database.Transaction) error {
+		state, err := t.FetchState()
+		if err != nil {
+			return err
+		}
+
+		header, err = t.FetchBlockHeader(state.TipHash)
+		return err
+	})
+
+	if err != nil {
+		log.WithError(err).Warnln("could not load the chain tip")
+		return
+	}
+
+	m.latestBlockTimestamp = header.Timestamp
+	m.latestBlockHeight = header.Height
 }
 
 // NewMempool instantiates and initializes node mempool
@@ -117,29 +199,54 @@ func NewMempool(eventBus *eventbus.EventBus, rpcBus *rpcbus.RPCBus, verifyTx fun
 		log.Errorf("rpcbus.SendMempoolTx err=%v", err)
 	}
 
+	validateTxChan := make(chan rpcbus.Request, 1)
+	if err := rpcBus.Register(topics.ValidateTx, validateTxChan); err != nil {
+		log.Errorf("rpcbus.ValidateTx err=%v", err)
+	}
+
+	estimateFeeChan := make(chan rpcbus.Request, 1)
+	if err := rpcBus.Register(topics.EstimateFee, estimateFeeChan); err != nil {
+		log.Errorf("rpcbus.EstimateFee err=%v", err)
+	}
+
 	intermediateBlockChan := initIntermediateBlockCollector(eventBus)
 	acceptedBlockChan, _ := consensus.InitAcceptedBlockUpdate(eventBus)
 
 	m := &Mempool{
 		eventBus:                eventBus,
 		latestBlockTimestamp:    math.MinInt32,
-		quitChan:                make(chan struct{}),
+		quitChan:                make(chan struct{}, 1),
 		intermediateBlockChan:   intermediateBlockChan,
 		acceptedBlockChan:       acceptedBlockChan,
+		intermediateTxs:         make(map[uint64]intermediateTxs),
+		immature:                make(map[txHash]TxDesc),
 		getMempoolTxsChan:       getMempoolTxsChan,
 		getMempoolTxsBySizeChan: getMempoolTxsBySizeChan,
 		getMempoolViewChan:      getMempoolViewChan,
 		sendTxChan:              sendTxChan,
+		validateTxChan:          validateTxChan,
+		estimateFeeChan:         estimateFeeChan,
+		fees:                    newFeeEstimator(candidate.MaxTxSetSize),
 	}
 
 	if verifyTx != nil {
 		m.verifyTx = verifyTx
+	} else {
+		// The default verifier checks the timelocks against the chain tip,
+		// which must be known before the journal txs are verified again
+		_, m.db = heavy.CreateDBConnection()
+		m.loadChainTip()
 	}
 
 	m.verified = m.newPool()
 
 	log.Infof("Running with pool type %s", config.Get().Mempool.PoolType)
 
+	if journalFile := config.Get().Mempool.JournalFile; len(journalFile) > 0 {
+		m.journal = newJournal(journalFile)
+		log.Infof("Running with journal file %s", journalFile)
+	}
+
 	// topics.Tx will be published by RPC subsystem or Peer subsystem (deserialized from gossip msg)
 	m.pending = make(chan TxDesc, maxPendingLen)
 	l := eventbus.NewCallbackListener(m.CollectPending)
@@ -154,23 +261,45 @@ func NewMempool(eventBus *eventbus.EventBus, rpcBus *rpcbus.RPCBus, verifyTx fun
 // All operations are always executed in a single go-routine so no
 // protection-by-mutex needed
 func (m *Mempool) Run() {
+	stopped := make(chan struct{})
+	m.stopped = stopped
+
 	go func() {
+		defer close(stopped)
+
+		// Txs persisted on the last shutdown are passed again through the
+		// verification procedure, so that stale or invalid ones are discarded
+		m.loadJournal()
+
+		// A nil channel is never selected, which disables the periodic
+		// journal writes
+		var journalChan <-chan time.Time
+		if m.journal != nil {
+			ticker := time.NewTicker(m.journalInterval())
+			defer ticker.Stop()
+			journalChan = ticker.C
+		}
+
 		for {
 			select {
 			//rpcbus methods
 			case r := <-m.sendTxChan:
 				handleRequest(r, m.processSendMempoolTxRequest, "SendTx")
+			case r := <-m.validateTxChan:
+				handleRequest(r, m.processValidateTxRequest, "ValidateTx")
 			case r := <-m.getMempoolTxsChan:
 				handleRequest(r, m.processGetMempoolTxsRequest, "GetMempoolTxs")
 			case r := <-m.getMempoolTxsBySizeChan:
 				handleRequest(r, m.processGetMempoolTxsBySizeRequest, "GetMempoolTxsBySize")
 			case r := <-m.getMempoolViewChan:
 				handleRequest(r, m.processGetMempoolViewRequest, "GetMempoolView")
+			case r := <-m.estimateFeeChan:
+				handleRequest(r, m.processEstimateFeeRequest, "EstimateFee")
 			// Mempool input channels
 			case b := <-m.intermediateBlockChan:
-				m.onBlock(b)
+				m.onIntermediateBlock(b)
 			case b := <-m.acceptedBlockChan:
-				m.onBlock(b)
+				m.onAcceptedBlock(b)
 			case tx := <-m.pending:
 				// TODO: the m.pending channel looks a bit wasteful. Consider
 				// removing it and call onPendingTx directly within
@@ -178,6 +307,8 @@ func (m *Mempool) Run() {
 				_, _ = m.onPendingTx(tx)
 			case <-time.After(20 * time.Second):
 				m.onIdle()
+			case <-journalChan:
+				m.saveJournal()
 			// Mempool terminating
 			case <-m.quitChan:
 				//m.eventBus.Unsubscribe(topics.Tx, m.txSubscriberID)
@@ -207,13 +338,18 @@ func (m *Mempool) onPendingTx(t TxDesc) ([]byte, error) {
 
 // processTx ensures all transaction rules are satisfied before adding the tx
 // into the verified pool
-func (m *Mempool) processTx(t TxDesc) ([]byte, error) {
+func (m *Mempool) processTx(t TxDesc) (txid []byte, err error) {
 
-	txid, err := t.tx.CalculateHash()
+	txid, err = t.tx.CalculateHash()
 	if err != nil {
 		return txid, fmt.Errorf("hash err: %s", err.Error())
 	}
 
+	// notify subscribers whether the tx got into the pool
+	defer func() {
+		m.publishTxEvent(txid, err)
+	}()
+
 	log.Infof("Pending txid=%s size=%d bytes", toHex(txid), t.size)
 
 	if t.tx.Type() == transactions.CoinbaseType {
@@ -221,8 +357,10 @@ func (m *Mempool) processTx(t TxDesc) ([]byte, error) {
 		return txid, ErrCoinbaseTxNotAllowed
 	}
 
-	// expect it is not already a verified tx
-	if m.verified.Contains(txid) {
+	// expect it is not already a verified or held tx
+	var k txHash
+	copy(k[:], txid)
+	if _, held := m.immature[k]; held || m.verified.Contains(txid) {
 		return txid, ErrAlreadyExists
 	}
 
@@ -233,6 +371,9 @@ func (m *Mempool) processTx(t TxDesc) ([]byte, error) {
 
 	// execute tx verification procedure
 	if err := m.checkTx(t.tx); err != nil {
+		if err == verifiers.ErrTimelockNotMatured {
+			return txid, m.holdImmature(k, t)
+		}
 		return txid, fmt.Errorf("verification: %v", err)
 	}
 
@@ -253,66 +394,242 @@ func (m *Mempool) processTx(t TxDesc) ([]byte, error) {
 	return txid, nil
 }
 
-func (m *Mempool) onBlock(b block.Block) {
+// onIntermediateBlock removes the txs of the intermediate block from the
+// verified pool. As the intermediate block may not become final, the removed
+// txs are kept aside until a block at the same height is accepted.
+func (m *Mempool) onIntermediateBlock(b block.Block) {
+	m.latestBlockTimestamp = b.Header.Timestamp
+	m.latestBlockHeight = b.Header.Height
+	removed := m.removeAccepted(b)
+
+	height := b.Header.Height
+
+	// A new intermediate block at the same height supersedes the previous one
+	prev, ok := m.intermediateTxs[height]
+	if ok && !bytes.Equal(prev.blockHash, b.Header.Hash) {
+		removed = append(removed, m.reinject(prev.txs, b)...)
+	}
+
+	m.intermediateTxs[height] = intermediateTxs{
+		blockHash: b.Header.Hash,
+		txs:       removed,
+	}
+
+	m.promoteMatured()
+}
+
+// onAcceptedBlock removes the txs of the accepted block from the verified
+// pool. If the accepted block differs from the intermediate block at the same
+// height, the txs removed because of the abandoned intermediate block are
+// re-verified and put back into the verified pool.
+func (m *Mempool) onAcceptedBlock(b block.Block) {
 	m.latestBlockTimestamp = b.Header.Timestamp
+	m.latestBlockHeight = b.Header.Height
 	m.removeAccepted(b)
+	m.fees.addBlock(b)
+
+	for height, it := range m.intermediateTxs {
+		if height > b.Header.Height {
+			continue
+		}
+
+		if height == b.Header.Height && !bytes.Equal(it.blockHash, b.Header.Hash) {
+			log.Infof("Intermediate block %s abandoned, re-injecting %d txs", toHex(it.blockHash), len(it.txs))
+			m.reinject(it.txs, b)
+		}
+
+		delete(m.intermediateTxs, height)
+	}
+
+	m.promoteMatured()
+}
+
+// holdImmature puts a timelocked tx aside until its lock is met
+func (m *Mempool) holdImmature(k txHash, t TxDesc) error {
+	if len(m.immature) >= maxImmatureLen {
+		return ErrImmatureQueueFull
+	}
+
+	m.immature[k] = t
+	return ErrImmature
+}
+
+// promoteMatured passes the held txs, which lock is met by the next block,
+// through the verification procedure again
+func (m *Mempool) promoteMatured() {
+	height, blockTime := m.nextBlockHeight(), m.nextBlockTime()
+
+	matured := make([]TxDesc, 0)
+	for k, t := range m.immature {
+		tl, ok := t.tx.(*transactions.Timelock)
+		if ok && !tl.Matured(height, blockTime) {
+			continue
+		}
+
+		delete(m.immature, k)
+		matured = append(matured, t)
+	}
+
+	for _, t := range matured {
+		if txid, err := m.processTx(t); err != nil {
+			log.Tracef("Discarded matured txid=%s err='%v'", toHex(txid), err)
+		}
+	}
+}
+
+// reinject passes the txs through the verification procedure again. Any tx
+// that is still valid goes back to the verified pool. Txs included in the
+// passed block are not re-verified but returned instead.
+func (m *Mempool) reinject(txs []TxDesc, b block.Block) []TxDesc {
+
+	included := make(map[txHash]struct{}, len(b.Txs))
+	for _, tx := range b.Txs {
+		txid, err := tx.CalculateHash()
+		if err != nil {
+			continue
+		}
+
+		var k txHash
+		copy(k[:], txid)
+		included[k] = struct{}{}
+	}
+
+	skipped := make([]TxDesc, 0)
+	for _, t := range txs {
+		txid, err := t.tx.CalculateHash()
+		if err != nil {
+			continue
+		}
+
+		var k txHash
+		copy(k[:], txid)
+		if _, ok := included[k]; ok {
+			skipped = append(skipped, t)
+			continue
+		}
+
+		if _, err := m.processTx(t); err != nil {
+			log.Tracef("Discarded txid=%s err='%v'", toHex(txid), err)
+		}
+	}
+
+	return skipped
+}
+
+// publishTxEvent notifies the outcome of processing a tx. A tx which is
+// already in the pool is not notified as rejected.
+func (m *Mempool) publishTxEvent(txid []byte, err error) {
+	switch err {
+	case nil:
+		txevent.Publish(m.eventBus, txevent.Event{TxID: txid, Status: txevent.Accepted})
+	case ErrAlreadyExists:
+	case ErrImmature:
+		txevent.Publish(m.eventBus, txevent.Event{TxID: txid, Status: txevent.Pending})
+	default:
+		txevent.Publish(m.eventBus, txevent.Event{TxID: txid, Status: txevent.Rejected, Reason: err.Error()})
+	}
 }
 
 // removeAccepted to clean up all txs from the mempool that have been already
 // added to the chain.
 //
 // Instead of doing a full DB scan, here we rely on the latest accepted block to
-// update.
+// update. Along with the block txs, any verified or held tx spending a key
+// image already spent by the block is removed, as it is not valid anymore.
 //
-// The passed block is supposed to be the last one accepted. That said, it must
-// contain a valid TxRoot.
-func (m *Mempool) removeAccepted(b block.Block) {
+// It returns the txs which have been removed from the verified pool.
+func (m *Mempool) removeAccepted(b block.Block) []TxDesc {
 
 	blockHash := toHex(b.Header.Hash)
 
 	log.Infof("Processing block %s with %d txs", blockHash, len(b.Txs))
 
+	m.removeSpentImmature(b)
+
+	removed := make([]TxDesc, 0)
 	if m.verified.Len() == 0 {
 		// No txs accepted then no cleanup needed
-		return
+		return removed
 	}
 
-	payloads := make([]merkletree.Payload, len(b.Txs))
-	for i, tx := range b.Txs {
-		payloads[i] = tx.(merkletree.Payload)
-	}
-
-	tree, err := merkletree.NewTree(payloads)
+	for _, tx := range b.Txs {
+		txid, err := tx.CalculateHash()
+		if err != nil {
+			log.WithError(err).Errorln("could not calculate block tx hash")
+			continue
+		}
 
-	if err == nil && tree != nil {
+		if t, ok := m.verified.Delete(txid); ok {
+			removed = append(removed, t)
+			continue
+		}
 
-		s := m.newPool()
-		// Check if mempool verified tx is part of merkle tree of this block
-		// if not, then keep it in the mempool for the next block
-		err = m.verified.Range(func(k txHash, t TxDesc) error {
-			if r, _ := tree.VerifyContent(t.tx); !r {
-				if e := s.Put(t); e != nil {
-					return e
-				}
+		// txs spending the same inputs as the block tx are evicted
+		for _, input := range tx.StandardTx().Inputs {
+			if t, ok := m.verified.DeleteByKeyImage(input.KeyImage.Bytes()); ok {
+				removed = append(removed, t)
+				m.publishEviction(t, txid)
 			}
-			return nil
-		})
+		}
+	}
+
+	log.Infof("Processing block %s completed", blockHash)
+	return removed
+}
 
+// removeSpentImmature drops the held txs which are included in the block, or
+// spend a key image already spent by the block, so that they do not take up
+// the pending-maturity queue until their lock is met
+func (m *Mempool) removeSpentImmature(b block.Block) {
+	if len(m.immature) == 0 {
+		return
+	}
+
+	// block txs by spent key image
+	spentBy := make(map[string][]byte)
+	for _, tx := range b.Txs {
+		txid, err := tx.CalculateHash()
 		if err != nil {
-			log.Error(err.Error())
+			continue
 		}
 
-		m.verified = s
+		var k txHash
+		copy(k[:], txid)
+		delete(m.immature, k)
+
+		for _, input := range tx.StandardTx().Inputs {
+			spentBy[string(input.KeyImage.Bytes())] = txid
+		}
 	}
 
-	log.Infof("Processing block %s completed", toHex(b.Header.Hash))
+	for k, t := range m.immature {
+		for _, input := range t.tx.StandardTx().Inputs {
+			if blockTxID, ok := spentBy[string(input.KeyImage.Bytes())]; ok {
+				delete(m.immature, k)
+				m.publishEviction(t, blockTxID)
+				break
+			}
+		}
+	}
+}
+
+// publishEviction notifies that a tx was removed from the pool since a block
+// tx spends the same inputs
+func (m *Mempool) publishEviction(t TxDesc, blockTxID []byte) {
+	txid, err := t.tx.CalculateHash()
+	if err != nil {
+		return
+	}
+
+	reason := fmt.Sprintf("double-spent by block tx %s", toHex(blockTxID))
+	txevent.Publish(m.eventBus, txevent.Event{TxID: txid, Status: txevent.Evicted, Reason: reason})
 }
 
 func (m *Mempool) onIdle() {
 
 	// stats to log
 	poolSize := float32(m.verified.Size()) / 1000
-	log.Infof("Txs count %d, total size %.3f kB", m.verified.Len(), poolSize)
+	log.Infof("Txs count %d, total size %.3f kB, immature txs count %d", m.verified.Len(), poolSize, len(m.immature))
 
 	// trigger alarms/notifications in case of abnormal state
 
@@ -351,8 +668,8 @@ func (m *Mempool) newPool() Pool {
 	switch config.Get().Mempool.PoolType {
 	case "hashmap":
 		p = &HashMap{Capacity: preallocTxs}
-	case "syncpool":
-		log.Panic("syncpool not supported")
+	case "skiplist":
+		p = &SkipList{Capacity: preallocTxs}
 	default:
 		p = &HashMap{Capacity: preallocTxs}
 	}
@@ -479,6 +796,13 @@ func (m Mempool) processGetMempoolTxsBySizeRequest(r rpcbus.Request) (interface{
 	return txs, err
 }
 
+// processEstimateFeeRequest suggests the fee rate for a tx to be included
+// within the requested number of blocks
+func (m Mempool) processEstimateFeeRequest(r rpcbus.Request) (interface{}, error) {
+	target := r.Params.(uint64)
+	return m.fees.estimate(m.verified, target)
+}
+
 // processSendMempoolTxRequest utilizes rpcbus to allow submitting a tx to mempool with
 func (m Mempool) processSendMempoolTxRequest(r rpcbus.Request) (interface{}, error) {
 	tx := r.Params.(transactions.Transaction)
@@ -507,9 +831,69 @@ func (m *Mempool) checkTXDoubleSpent(tx transactions.Transaction) error {
 	return nil
 }
 
-// Quit makes mempool main loop to terminate
+// Quit makes mempool main loop to terminate. Once the main loop is done, the
+// verified txs are written to the journal, if enabled. If the main loop was
+// never started, the journal is written right away.
 func (m *Mempool) Quit() {
-	m.quitChan <- struct{}{}
+	if m.stopped != nil {
+		select {
+		case m.quitChan <- struct{}{}:
+		default:
+			// a quit request is already pending
+		}
+
+		<-m.stopped
+		m.stopped = nil
+	}
+
+	m.saveJournal()
+}
+
+// saveJournal writes the verified pool into the journal file
+func (m *Mempool) saveJournal() {
+	if m.journal == nil {
+		return
+	}
+
+	if err := m.journal.save(m.verified); err != nil {
+		log.WithError(err).Errorln("could not save mempool journal")
+		return
+	}
+
+	log.Tracef("Journal saved with %d txs", m.verified.Len())
+}
+
+// loadJournal re-submits all txs from the journal file into the mempool
+func (m *Mempool) loadJournal() {
+	if m.journal == nil {
+		return
+	}
+
+	txs, err := m.journal.load()
+	if err != nil {
+		log.WithError(err).Errorln("could not load mempool journal")
+	}
+
+	for _, tx := range txs {
+		buf := new(bytes.Buffer)
+		if err := message.MarshalTx(buf, tx); err != nil {
+			log.WithError(err).Errorln("could not marshal journal tx")
+			continue
+		}
+
+		_, _ = m.onPendingTx(TxDesc{tx: tx, received: time.Now(), size: uint(buf.Len())})
+	}
+
+	log.Infof("Journal loaded with %d txs, %d verified", len(txs), m.verified.Len())
+}
+
+func (m *Mempool) journalInterval() time.Duration {
+	interval := config.Get().Mempool.JournalInterval
+	if interval == 0 {
+		interval = defaultJournalInterval
+	}
+
+	return time.Duration(interval) * time.Second
 }
 
 // Send Inventory message to all peers
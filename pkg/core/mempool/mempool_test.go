@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"encoding/hex"
 	"errors"
+	"io/ioutil"
 	"math"
 	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 	"testing"
@@ -14,8 +16,10 @@ import (
 	"github.com/dusk-network/dusk-blockchain/pkg/config"
 	"github.com/dusk-network/dusk-protobuf/autogen/go/node"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/candidate"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/transactions"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/tests/helper"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/verifiers"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
@@ -56,6 +60,7 @@ func (c *ctx) reset() {
 	c.mu.Lock()
 	c.m.Quit()
 	c.m.verified = c.m.newPool()
+	c.m.intermediateTxs = make(map[uint64]intermediateTxs)
 	c.verifiedTx = make([]transactions.Transaction, 0)
 	c.propagated = make([][]byte, 0)
 	c.mu.Unlock()
@@ -294,6 +299,133 @@ func TestRemoveAccepted(t *testing.T) {
 	c.assert(t, false)
 }
 
+// TestReinjectAbandonedIntermediateTxs ensures txs removed because of an
+// intermediate block are back in the pool if a different block is accepted at
+// the same height.
+func TestReinjectAbandonedIntermediateTxs(t *testing.T) {
+
+	c.reset()
+
+	// Create an intermediate block which includes all published txs
+	ib := helper.RandomBlock(t, 300, 0)
+	ib.Txs = make([]transactions.Transaction, 0)
+
+	txs := randomSliceOfTxs(t, 2)
+	for _, tx := range txs {
+		// We avoid sharing this pointer between the mempool and the block
+		// by marshaling and unmarshaling the tx
+		buf := new(bytes.Buffer)
+		if err := message.MarshalTx(buf, tx); err != nil {
+			t.Fatal(err)
+		}
+
+		txCopy, err := message.UnmarshalTx(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		c.bus.Publish(topics.Tx, prepTx(txCopy))
+		ib.AddTx(tx)
+		c.addTx(tx)
+	}
+
+	c.wait()
+
+	root, _ := ib.CalculateRoot()
+	ib.Header.TxRoot = root
+	c.bus.Publish(topics.IntermediateBlock, message.New(topics.IntermediateBlock, *ib))
+
+	c.wait()
+
+	// All txs should be removed from the pool by the intermediate block
+	resp, err := c.rpcBus.Call(topics.GetMempoolTxs, rpcbus.NewRequest(bytes.Buffer{}), 1*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Empty(t, resp.([]transactions.Transaction))
+
+	// A different block is accepted at the same height
+	ab := helper.RandomBlock(t, 300, 1)
+	c.bus.Publish(topics.AcceptedBlock, message.New(topics.AcceptedBlock, *ab))
+
+	c.assert(t, false)
+}
+
+// TestImmatureTimelock ensures a timelocked tx is held aside until its lock
+// is met, and verified again once matured.
+func TestImmatureTimelock(t *testing.T) {
+	assert := assert.New(t)
+
+	m := &Mempool{
+		eventBus:        eventbus.New(),
+		verified:        &HashMap{Capacity: 10},
+		intermediateTxs: make(map[uint64]intermediateTxs),
+		immature:        make(map[txHash]TxDesc),
+		fees:            newFeeEstimator(candidate.MaxTxSetSize),
+	}
+
+	m.verifyTx = func(tx transactions.Transaction) error {
+		return verifiers.CheckSpecialFields(0, m.nextBlockHeight(), m.nextBlockTime(), tx)
+	}
+
+	tx := helper.RandomTLockTx(t, false)
+	tx.Lock = 10
+
+	txid, err := m.processTx(TxDesc{tx: tx, received: time.Now()})
+	assert.Equal(ErrImmature, err)
+	assert.False(m.verified.Contains(txid))
+	assert.Len(m.immature, 1)
+
+	// Submitting it twice should not hold it twice
+	_, err = m.processTx(TxDesc{tx: tx, received: time.Now()})
+	assert.Equal(ErrAlreadyExists, err)
+
+	// The lock is not met by the next block yet
+	m.onAcceptedBlock(*helper.RandomBlock(t, 8, 1))
+	assert.False(m.verified.Contains(txid))
+	assert.Len(m.immature, 1)
+
+	// The lock is met by the next block
+	m.onAcceptedBlock(*helper.RandomBlock(t, 9, 1))
+	assert.True(m.verified.Contains(txid))
+	assert.Empty(m.immature)
+}
+
+// TestRemoveSpentImmature ensures a held tx is dropped once a block spends
+// one of its inputs.
+func TestRemoveSpentImmature(t *testing.T) {
+	assert := assert.New(t)
+
+	m := &Mempool{
+		eventBus:        eventbus.New(),
+		verified:        &HashMap{Capacity: 10},
+		intermediateTxs: make(map[uint64]intermediateTxs),
+		immature:        make(map[txHash]TxDesc),
+		fees:            newFeeEstimator(candidate.MaxTxSetSize),
+	}
+
+	m.verifyTx = func(tx transactions.Transaction) error {
+		return verifiers.CheckSpecialFields(0, m.nextBlockHeight(), m.nextBlockTime(), tx)
+	}
+
+	tx := helper.RandomTLockTx(t, false)
+	tx.Lock = 100
+
+	_, err := m.processTx(TxDesc{tx: tx, received: time.Now()})
+	assert.Equal(ErrImmature, err)
+	assert.Len(m.immature, 1)
+
+	// A block tx spending the same inputs evicts the held tx
+	spending := helper.RandomStandardTx(t, false)
+	spending.Inputs = tx.Inputs
+
+	blk := helper.RandomBlock(t, 8, 1)
+	blk.AddTx(spending)
+
+	m.onAcceptedBlock(*blk)
+	assert.Empty(m.immature)
+}
+
 // TestDoubleSpent ensures mempool rejects txs with keyImages that have been
 // already spent from other transactions in the pool.
 func TestDoubleSpent(t *testing.T) {
@@ -389,6 +521,55 @@ func TestSendMempoolTx(t *testing.T) {
 
 }
 
+func TestValidateTx(t *testing.T) {
+
+	c.reset()
+
+	tx := helper.RandomStandardTx(t, false)
+	tx.Version = 0
+
+	// A valid tx should pass the dry-run without being stored or propagated
+	resp, err := c.rpcBus.Call(topics.ValidateTx, rpcbus.NewRequest(tx), 0)
+	assert.NoError(t, err)
+
+	result := resp.(ValidationResult)
+	assert.True(t, result.Valid())
+
+	txid, _ := tx.CalculateHash()
+	assert.Equal(t, txid, result.TxID)
+	c.assert(t, true)
+
+	// Once in the mempool, the same tx should be reported as both existing
+	// and double-spending
+	_, err = c.rpcBus.Call(topics.SendMempoolTx, rpcbus.NewRequest(tx), 0)
+	assert.NoError(t, err)
+	c.addTx(tx)
+
+	resp, err = c.rpcBus.Call(topics.ValidateTx, rpcbus.NewRequest(tx), 0)
+	assert.NoError(t, err)
+
+	result = resp.(ValidationResult)
+	assert.False(t, result.Valid())
+	assert.Equal(t, FailureAlreadyExists, result.Failures[0].Code)
+	for _, f := range result.Failures[1:] {
+		assert.Equal(t, FailureDoubleSpending, f.Code)
+	}
+
+	// A tx failing the verifier should be reported with the verifier error
+	invalid := helper.RandomStandardTx(t, false)
+	invalid.Version = 1
+
+	resp, err = c.rpcBus.Call(topics.ValidateTx, rpcbus.NewRequest(invalid), 0)
+	assert.NoError(t, err)
+
+	result = resp.(ValidationResult)
+	assert.Len(t, result.Failures, 1)
+	assert.Equal(t, FailureVerification, result.Failures[0].Code)
+	assert.Equal(t, "invalid tx version", result.Failures[0].Reason)
+
+	c.assert(t, true)
+}
+
 func TestMempoolView(t *testing.T) {
 	c.reset()
 
@@ -437,6 +618,37 @@ func TestMempoolView(t *testing.T) {
 	assert.Equal(t, numTxs, len(s.Result))
 }
 
+// Test that Quit does not wait for a main loop which was never started, and
+// still writes the journal.
+func TestQuitWithoutRun(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mempool_journal")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	m := NewMempool(eventbus.New(), rpcbus.New(), verifyFunc)
+	m.journal = newJournal(filepath.Join(dir, "mempool.dat"))
+	txs := randomSliceOfTxs(t, 1)
+	for _, tx := range txs {
+		assert.NoError(t, m.verified.Put(TxDesc{tx: tx, received: time.Now()}))
+	}
+
+	done := make(chan struct{})
+	go func() {
+		m.Quit()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Quit blocked without a running main loop")
+	}
+
+	saved, err := m.journal.load()
+	assert.NoError(t, err)
+	assert.Len(t, saved, len(txs))
+}
+
 // Only difference with helper.RandomSliceOfTxs is lack of appending a coinbase tx
 func randomSliceOfTxs(t *testing.T, txsBatchCount uint16) []transactions.Transaction {
 	var txs []transactions.Transaction
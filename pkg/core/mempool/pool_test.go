@@ -0,0 +1,204 @@
+package mempool
+
+import (
+	"math/big"
+	"math/rand"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/transactions"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	// number of txs to fill a pool with on benchmarking
+	benchPoolSize = 100000
+	// number of txs in a block to remove from a pool on benchmarking
+	benchBlockSize = 1000
+)
+
+// pools lists all Pool implementations to test and bench against each other
+var pools = []struct {
+	name    string
+	newPool func(capacity uint32) Pool
+}{
+	{"hashmap", func(capacity uint32) Pool { return &HashMap{Capacity: capacity} }},
+	{"skiplist", func(capacity uint32) Pool { return &SkipList{Capacity: capacity} }},
+}
+
+func TestPoolDelete(t *testing.T) {
+	for _, p := range pools {
+		t.Run(p.name, func(t *testing.T) {
+			assert := assert.New(t)
+			pool := p.newPool(10)
+
+			txs := randomSliceOfTxs(t, 3)
+			var totalSize uint32
+			for i, tx := range txs {
+				td := TxDesc{tx: tx, received: time.Now(), size: uint(100 + i)}
+				assert.NoError(pool.Put(td))
+				totalSize += uint32(td.size)
+			}
+
+			// Delete by txID
+			txid, _ := txs[0].CalculateHash()
+			td, ok := pool.Delete(txid)
+			assert.True(ok)
+			assert.True(td.tx.Equals(txs[0]))
+			assert.False(pool.Contains(txid))
+			for _, input := range txs[0].StandardTx().Inputs {
+				assert.False(pool.ContainsKeyImage(input.KeyImage.Bytes()))
+			}
+
+			// Deleting it twice should fail
+			_, ok = pool.Delete(txid)
+			assert.False(ok)
+
+			// Delete by key image
+			ki := txs[1].StandardTx().Inputs[0].KeyImage.Bytes()
+			td, ok = pool.DeleteByKeyImage(ki)
+			assert.True(ok)
+			assert.True(td.tx.Equals(txs[1]))
+			assert.False(pool.ContainsKeyImage(ki))
+
+			assert.Equal(len(txs)-2, pool.Len())
+			assert.Equal(totalSize-100-101, pool.Size())
+
+			// Only the remaining txs should be iterated in a sorted order
+			count := 0
+			assert.NoError(pool.RangeSort(func(k txHash, t TxDesc) (bool, error) {
+				assert.False(t.tx.Equals(txs[0]))
+				assert.False(t.tx.Equals(txs[1]))
+				count++
+				return false, nil
+			}))
+			assert.Equal(pool.Len(), count)
+		})
+	}
+}
+
+func BenchmarkPoolPut(b *testing.B) {
+
+	txs := uniqueTransactionsSet(benchPoolSize)
+
+	for _, p := range pools {
+		b.Run(p.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for tN := 0; tN < b.N; tN++ {
+				_ = fillPool(b, p.newPool(uint32(len(txs))), txs)
+			}
+		})
+	}
+}
+
+func BenchmarkPoolRangeSort(b *testing.B) {
+
+	txs := uniqueTransactionsSet(benchPoolSize)
+
+	for _, p := range pools {
+		b.Run(p.name, func(b *testing.B) {
+			pool := fillPool(b, p.newPool(uint32(len(txs))), txs)
+			b.ResetTimer()
+
+			for tN := 0; tN < b.N; tN++ {
+				err := pool.RangeSort(func(k txHash, t TxDesc) (bool, error) {
+					return false, nil
+				})
+
+				if err != nil {
+					b.Fatalf(err.Error())
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkPoolRemoveBlock measures the removal of the txs included in an
+// accepted block from a full pool
+func BenchmarkPoolRemoveBlock(b *testing.B) {
+
+	txs := uniqueTransactionsSet(benchPoolSize)
+
+	// Pick the block txs evenly from the pool txs
+	blk := block.NewBlock()
+	step := len(txs) / benchBlockSize
+	for i := 0; i < len(txs); i += step {
+		blk.Txs = append(blk.Txs, txs[i])
+	}
+
+	for _, p := range pools {
+		b.Run(p.name, func(b *testing.B) {
+			for tN := 0; tN < b.N; tN++ {
+				b.StopTimer()
+				m := &Mempool{verified: fillPool(b, p.newPool(uint32(len(txs))), txs)}
+				b.StartTimer()
+
+				removed := m.removeAccepted(*blk)
+				if len(removed) != len(blk.Txs) {
+					b.Fatalf("expected %d removed txs, got %d", len(blk.Txs), len(removed))
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkPoolMemory reports the heap size allocated by a full pool
+func BenchmarkPoolMemory(b *testing.B) {
+
+	txs := uniqueTransactionsSet(benchPoolSize)
+
+	for _, p := range pools {
+		b.Run(p.name, func(b *testing.B) {
+			var heapSize uint64
+			for tN := 0; tN < b.N; tN++ {
+				var before, after runtime.MemStats
+
+				runtime.GC()
+				runtime.ReadMemStats(&before)
+
+				pool := fillPool(b, p.newPool(uint32(len(txs))), txs)
+
+				runtime.GC()
+				runtime.ReadMemStats(&after)
+				runtime.KeepAlive(pool)
+
+				if after.HeapAlloc > before.HeapAlloc {
+					heapSize += after.HeapAlloc - before.HeapAlloc
+				}
+			}
+
+			b.ReportMetric(float64(heapSize)/float64(b.N), "heap-bytes/pool")
+		})
+	}
+}
+
+func fillPool(b *testing.B, pool Pool, txs []*transactions.Standard) Pool {
+	for i := 0; i < len(txs); i++ {
+		td := TxDesc{tx: txs[i], received: time.Now(), size: uint(i + 1)}
+		if err := pool.Put(td); err != nil {
+			b.Fatalf(err.Error())
+		}
+	}
+
+	return pool
+}
+
+// uniqueTransactionsSet differs from dummyTransactionsSet as it ensures that
+// all txs have distinct fees, and thus distinct txIDs
+func uniqueTransactionsSet(size int) []*transactions.Standard {
+
+	txs := make([]*transactions.Standard, size)
+	dummyTx, _ := transactions.NewStandard(0, 2, 0)
+	for i, fee := range rand.Perm(size) {
+
+		clone := *dummyTx
+		clone.Fee.SetBigInt(big.NewInt(int64(fee)))
+		clone.TxID, _ = clone.CalculateHash()
+
+		txs[i] = &clone
+	}
+
+	return txs
+}
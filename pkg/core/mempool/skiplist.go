@@ -0,0 +1,320 @@
+package mempool
+
+import (
+	"fmt"
+	"math/bits"
+	"math/rand"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/transactions"
+)
+
+const (
+	// skipListMaxLevel allows efficient indexing of up to 4^16 txs
+	skipListMaxLevel = 16
+	// skipListP is the probability of a node to be promoted to the next level
+	skipListP = 0.25
+)
+
+type (
+	// feeRateKey orders the txs by fee rate in a descending order. Txs with
+	// the same fee rate are ordered by their time of insertion.
+	feeRateKey struct {
+		fee  uint64
+		size uint64
+		seq  uint64
+	}
+
+	skipNode struct {
+		key  feeRateKey
+		k    txHash
+		next []*skipNode
+	}
+
+	skipEntry struct {
+		t    TxDesc
+		node *skipNode
+	}
+
+	// SkipList represents a pool implementation where txs are indexed by fee
+	// rate in a skip list. Unlike HashMap, txs are removed incrementally by
+	// txID or key image, without rebuilding the pool or re-sorting the keys.
+	SkipList struct {
+		// transactions pool
+		data map[txHash]skipEntry
+
+		// spent key images from the transactions in the pool, mapped to the
+		// spending tx
+		spentkeyImages map[keyImage]txHash
+
+		head  *skipNode
+		level int
+		seq   uint64
+		rnd   *rand.Rand
+
+		Capacity uint32
+		txsSize  uint32
+	}
+)
+
+// before returns true if the tx with key a should be ordered before the tx with
+// key b. Fee rates are compared by cross-multiplication, to avoid precision
+// loss of a division.
+func (a feeRateKey) before(b feeRateKey) bool {
+	ah, al := bits.Mul64(a.fee, b.size)
+	bh, bl := bits.Mul64(b.fee, a.size)
+
+	if ah != bh {
+		return ah > bh
+	}
+
+	if al != bl {
+		return al > bl
+	}
+
+	return a.seq < b.seq
+}
+
+func (m *SkipList) lazyInit() {
+	if m.data == nil {
+		m.data = make(map[txHash]skipEntry, m.Capacity)
+	}
+
+	if m.spentkeyImages == nil {
+		m.spentkeyImages = make(map[keyImage]txHash)
+	}
+
+	if m.head == nil {
+		m.head = &skipNode{next: make([]*skipNode, skipListMaxLevel)}
+		m.level = 1
+		m.rnd = rand.New(rand.NewSource(1))
+	}
+}
+
+func (m *SkipList) randomLevel() int {
+	level := 1
+	for level < skipListMaxLevel && m.rnd.Float64() < skipListP {
+		level++
+	}
+	return level
+}
+
+// Put sets the value for the given key. It overwrites any previous value
+// for that key;
+func (m *SkipList) Put(t TxDesc) error {
+
+	m.lazyInit()
+
+	txID, err := t.tx.CalculateHash()
+	if err != nil {
+		return err
+	}
+
+	// validate key images before any change on the pool
+	inputs := t.tx.StandardTx().Inputs
+	for i, input := range inputs {
+		if len(input.KeyImage.Bytes()) != keyImageSize {
+			return fmt.Errorf("invalid key image found at index %d", i)
+		}
+	}
+
+	var k txHash
+	copy(k[:], txID)
+
+	// overwrite any previous value
+	m.Delete(txID)
+
+	size := uint64(t.size)
+	if size == 0 {
+		size = 1
+	}
+
+	m.seq++
+	key := feeRateKey{
+		fee:  t.tx.StandardTx().Fee.BigInt().Uint64(),
+		size: size,
+		seq:  m.seq,
+	}
+
+	// find the predecessor of the new node on each level
+	var update [skipListMaxLevel]*skipNode
+	x := m.head
+	for i := m.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && x.next[i].key.before(key) {
+			x = x.next[i]
+		}
+		update[i] = x
+	}
+
+	level := m.randomLevel()
+	if level > m.level {
+		for i := m.level; i < level; i++ {
+			update[i] = m.head
+		}
+		m.level = level
+	}
+
+	node := &skipNode{key: key, k: k, next: make([]*skipNode, level)}
+	for i := 0; i < level; i++ {
+		node.next[i] = update[i].next[i]
+		update[i].next[i] = node
+	}
+
+	m.data[k] = skipEntry{t: t, node: node}
+	m.txsSize += uint32(t.size)
+
+	for _, input := range inputs {
+		var ki keyImage
+		copy(ki[:], input.KeyImage.Bytes())
+		m.spentkeyImages[ki] = k
+	}
+
+	return nil
+}
+
+// Delete removes the tx with the given txID, along with its key images
+func (m *SkipList) Delete(txID []byte) (TxDesc, bool) {
+	var k txHash
+	copy(k[:], txID)
+
+	e, ok := m.data[k]
+	if !ok {
+		return TxDesc{}, false
+	}
+
+	// unlink the node on each level it is present
+	x := m.head
+	for i := m.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && x.next[i].key.before(e.node.key) {
+			x = x.next[i]
+		}
+
+		if x.next[i] == e.node {
+			x.next[i] = e.node.next[i]
+		}
+	}
+
+	for m.level > 1 && m.head.next[m.level-1] == nil {
+		m.level--
+	}
+
+	delete(m.data, k)
+	m.txsSize -= uint32(e.t.size)
+
+	for _, input := range e.t.tx.StandardTx().Inputs {
+		var ki keyImage
+		copy(ki[:], input.KeyImage.Bytes())
+		delete(m.spentkeyImages, ki)
+	}
+
+	return e.t, true
+}
+
+// DeleteByKeyImage removes the tx which spends the given key image
+func (m *SkipList) DeleteByKeyImage(txInputKeyImage []byte) (TxDesc, bool) {
+	var ki keyImage
+	copy(ki[:], txInputKeyImage)
+
+	k, ok := m.spentkeyImages[ki]
+	if !ok {
+		return TxDesc{}, false
+	}
+
+	return m.Delete(k[:])
+}
+
+// Clone the entire pool
+func (m SkipList) Clone() []transactions.Transaction {
+
+	r := make([]transactions.Transaction, len(m.data))
+	i := 0
+	for _, e := range m.data {
+		r[i] = e.t.tx
+		i++
+	}
+
+	return r
+}
+
+// FilterByType returns all transactions for a specific type that are
+// currently in the SkipList.
+func (m SkipList) FilterByType(filterType transactions.TxType) []transactions.Transaction {
+	txs := make([]transactions.Transaction, 0)
+	for _, e := range m.data {
+		if e.t.tx.Type() == filterType {
+			txs = append(txs, e.t.tx)
+		}
+	}
+
+	return txs
+}
+
+// Contains returns true if the given key is in the pool.
+func (m *SkipList) Contains(txID []byte) bool {
+	var k txHash
+	copy(k[:], txID)
+	_, ok := m.data[k]
+	return ok
+}
+
+// Get returns a tx for a given txID if it exists.
+func (m *SkipList) Get(txID []byte) transactions.Transaction {
+	var k txHash
+	copy(k[:], txID)
+	e, ok := m.data[k]
+	if !ok {
+		return nil
+	}
+	return e.t.tx
+}
+
+// Size of the txs
+func (m *SkipList) Size() uint32 {
+	return m.txsSize
+}
+
+// Len returns the number of tx entries
+func (m *SkipList) Len() int {
+	return len(m.data)
+}
+
+// Range iterates through all tx entries
+func (m *SkipList) Range(fn func(k txHash, t TxDesc) error) error {
+
+	for k, e := range m.data {
+		err := fn(k, e.t)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RangeSort iterates through all tx entries sorted by fee rate
+// in a descending order
+func (m *SkipList) RangeSort(fn func(k txHash, t TxDesc) (bool, error)) error {
+
+	if m.head == nil {
+		return nil
+	}
+
+	for x := m.head.next[0]; x != nil; x = x.next[0] {
+		done, err := fn(x.k, m.data[x.k].t)
+		if err != nil {
+			return err
+		}
+
+		if done {
+			return nil
+		}
+	}
+	return nil
+}
+
+// ContainsKeyImage returns true if txpool includes a input that contains
+// this keyImage
+func (m *SkipList) ContainsKeyImage(txInputKeyImage []byte) bool {
+	var ki keyImage
+	copy(ki[:], txInputKeyImage)
+	_, ok := m.spentkeyImages[ki]
+	return ok
+}
@@ -0,0 +1,88 @@
+package mempool
+
+import (
+	"errors"
+	"math/big"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/tests/helper"
+)
+
+func TestSkipListSortedByFeeRate(t *testing.T) {
+
+	pool := &SkipList{Capacity: 100}
+
+	// Generate 100 random txs
+	for i := 0; i < 100; i++ {
+
+		tx := helper.RandomStandardTx(t, false)
+
+		randFee := big.NewInt(0).SetUint64(uint64(rand.Intn(10000)))
+		tx.Fee.SetBigInt(randFee)
+
+		td := TxDesc{tx: tx, size: uint(1 + rand.Intn(1000))}
+		if err := pool.Put(td); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+
+	// Iterate through all tx expecting each one has lower fee rate than
+	// the previous one
+	var prev *TxDesc
+	err := pool.RangeSort(func(k txHash, t TxDesc) (bool, error) {
+
+		if prev != nil {
+			prevRate := new(big.Rat).SetFrac64(int64(prev.tx.StandardTx().Fee.BigInt().Uint64()), int64(prev.size))
+			rate := new(big.Rat).SetFrac64(int64(t.tx.StandardTx().Fee.BigInt().Uint64()), int64(t.size))
+			if prevRate.Cmp(rate) < 0 {
+				return false, errors.New("keys not in a descending order")
+			}
+		}
+
+		prev = &t
+		return false, nil
+	})
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+}
+
+func TestSkipListStableSortedKeys(t *testing.T) {
+
+	pool := SkipList{Capacity: 100}
+
+	// Generate 100 random txs
+	for i := 0; i < 100; i++ {
+
+		tx := helper.RandomStandardTx(t, false)
+
+		constFee := big.NewInt(0).SetUint64(20)
+		tx.Fee.SetBigInt(constFee)
+
+		td := TxDesc{tx: tx, received: time.Now(), size: 100}
+		if err := pool.Put(td); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+
+	// Iterate through all tx expecting order of receiving is kept when
+	// tx has same fee rate
+	var prevReceived time.Time
+	err := pool.RangeSort(func(k txHash, t TxDesc) (bool, error) {
+
+		val := t.received
+		if prevReceived.After(val) {
+			return false, errors.New("order of receiving should be kept")
+		}
+
+		prevReceived = val
+		return false, nil
+	})
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+}
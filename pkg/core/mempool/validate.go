@@ -0,0 +1,108 @@
+package mempool
+
+import (
+	"fmt"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/verifiers"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
+)
+
+// FailureCode identifies the rule a tx fails on validation
+type FailureCode uint8
+
+const (
+	// FailureHash means the tx hash could not be calculated
+	FailureHash FailureCode = iota
+	// FailureCoinbase means the tx is a coinbase tx
+	FailureCoinbase
+	// FailureAlreadyExists means the tx is already in the mempool
+	FailureAlreadyExists
+	// FailureDoubleSpending means the tx spends a key image already spent
+	// by a mempool tx
+	FailureDoubleSpending
+	// FailureVerification means the tx does not pass the blockchain
+	// verifier against the current tip
+	FailureVerification
+	// FailureImmature means the tx is a timelock which can not be included
+	// in the next block. The mempool would hold it until its lock is met.
+	FailureImmature
+)
+
+var failureNames = [...]string{"hash", "coinbase", "already_exists", "double_spending", "verification", "immature"}
+
+func (c FailureCode) String() string {
+	if int(c) < len(failureNames) {
+		return failureNames[c]
+	}
+	return "unknown"
+}
+
+// ValidationFailure is a rule a tx fails on validation
+type ValidationFailure struct {
+	Code   FailureCode
+	Reason string
+}
+
+// ValidationResult is the outcome of a tx dry-run. A tx with no failures
+// would be accepted by the mempool.
+type ValidationResult struct {
+	TxID     []byte
+	Failures []ValidationFailure
+}
+
+// Valid returns true if the tx passed all the checks
+func (r ValidationResult) Valid() bool {
+	return len(r.Failures) == 0
+}
+
+// validateTx runs the same checks as processTx without storing or
+// advertising the tx. Unlike processTx, it does not stop on the first failing
+// check, so that all failures are reported.
+func (m *Mempool) validateTx(tx transactions.Transaction) ValidationResult {
+
+	var r ValidationResult
+
+	txid, err := tx.CalculateHash()
+	if err != nil {
+		r.Failures = append(r.Failures, ValidationFailure{FailureHash, err.Error()})
+		return r
+	}
+
+	r.TxID = txid
+
+	if tx.Type() == transactions.CoinbaseType {
+		r.Failures = append(r.Failures, ValidationFailure{FailureCoinbase, ErrCoinbaseTxNotAllowed.Error()})
+	}
+
+	var k txHash
+	copy(k[:], txid)
+	if _, held := m.immature[k]; held || m.verified.Contains(txid) {
+		r.Failures = append(r.Failures, ValidationFailure{FailureAlreadyExists, ErrAlreadyExists.Error()})
+	}
+
+	for i, input := range tx.StandardTx().Inputs {
+		if m.verified.ContainsKeyImage(input.KeyImage.Bytes()) {
+			reason := fmt.Sprintf("key image of input %d already spent in mempool", i)
+			r.Failures = append(r.Failures, ValidationFailure{FailureDoubleSpending, reason})
+		}
+	}
+
+	if err := m.checkTx(tx); err != nil {
+		code := FailureVerification
+		if err == verifiers.ErrTimelockNotMatured {
+			code = FailureImmature
+		}
+
+		r.Failures = append(r.Failures, ValidationFailure{code, err.Error()})
+	}
+
+	return r
+}
+
+// processValidateTxRequest utilizes rpcbus to allow a dry-run of a tx. The
+// tx is neither stored nor advertised.
+func (m *Mempool) processValidateTxRequest(r rpcbus.Request) (interface{}, error) {
+	tx := r.Params.(transactions.Transaction)
+	return m.validateTx(tx), nil
+}
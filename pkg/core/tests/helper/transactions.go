@@ -9,8 +9,10 @@ import (
 
 	"github.com/bwesterb/go-ristretto"
 	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	consensuskey "github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/key"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-crypto/mlsag"
 	"github.com/dusk-network/dusk-crypto/rangeproof"
 	"github.com/stretchr/testify/assert"
@@ -181,6 +183,69 @@ func RandomStakeTx(t *testing.T, malformed bool) (*transactions.Stake, error) {
 	return tx, nil
 }
 
+// RandomUnstakeTx returns a random unstake tx for testing, signed with the
+// given BLS keys
+func RandomUnstakeTx(t *testing.T, keys consensuskey.Keys) *transactions.Unstake {
+	tx, err := transactions.NewUnstake(0, 2, fee, keys.BLSPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rp := randomRangeProofBuffer(t)
+	_ = tx.RangeProof.Decode(rp, true)
+
+	// Inputs
+	tx.Inputs = RandomInputs(t, numInputs)
+
+	// Outputs
+	tx.Outputs = RandomOutputs(t, numOutputs)
+
+	if err := tx.Sign(keys.BLSSecretKey, keys.BLSPubKey); err != nil {
+		t.Fatal(err)
+	}
+
+	// Set TxID
+	hash, err := tx.CalculateHash()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx.TxID = hash
+
+	return tx
+}
+
+// RandomSlashTx returns a random slash tx for testing, carrying the given
+// evidence
+func RandomSlashTx(t *testing.T, evidence message.Evidence) *transactions.Slash {
+	buf := new(bytes.Buffer)
+	if err := message.MarshalEvidence(buf, evidence); err != nil {
+		t.Fatal(err)
+	}
+
+	tx, err := transactions.NewSlash(0, 2, fee, buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	rp := randomRangeProofBuffer(t)
+	_ = tx.RangeProof.Decode(rp, true)
+
+	// Inputs
+	tx.Inputs = RandomInputs(t, numInputs)
+
+	// Outputs
+	tx.Outputs = RandomOutputs(t, numOutputs)
+
+	// Set TxID
+	hash, err := tx.CalculateHash()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx.TxID = hash
+
+	return tx
+}
+
 //func fetchDecoys(numMixins int) []mlsag.PubKeys {
 //	var decoys []ristretto.Point
 //	for i := 0; i < numMixins; i++ {
@@ -4,7 +4,10 @@ import (
 	"bytes"
 	"crypto/rand"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"math"
+	"strings"
 
 	"math/big"
 
@@ -12,13 +15,41 @@ import (
 	cfg "github.com/dusk-network/dusk-blockchain/pkg/config"
 	walletdb "github.com/dusk-network/dusk-blockchain/pkg/core/data/database"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/mnemonic"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/transactions"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/wallet"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 )
 
 var testnet = byte(2)
 
+const (
+	// maxFeeEstimates is the maximum number of size estimates made to
+	// compute the fee of a tx from a fee rate
+	maxFeeEstimates = 16
+
+	// feeSizeMargin is added to the estimated size of a tx, to cover the
+	// varint encoding the number of inputs growing with them
+	feeSizeMargin = 8
+)
+
+// Recipient is paid an amount by an output of a standard tx
+type Recipient struct {
+	Address string
+	Amount  uint64
+}
+
+// Fee is the fee paid by a tx created by the Transactor. The zero Fee pays the
+// minimum fee.
+type Fee struct {
+	// Amount is the fee of the tx
+	Amount uint64
+	// Rate is the fee rate of the tx, in units per kB (see
+	// transactions.FeeRateUnit). It can not be set along with Amount
+	Rate uint64
+}
+
 func (t *Transactor) loadWallet(password string) (string, error) {
 	// First load the database
 	db, err := walletdb.New(cfg.Get().Wallet.Store)
@@ -43,13 +74,62 @@ func (t *Transactor) loadWallet(password string) (string, error) {
 	return walletAddr, nil
 }
 
-func (t *Transactor) createWallet(password string) (string, error) {
+// createWallet creates a wallet from a random seed. It returns the address
+// of the wallet, and the mnemonic encoding the seed
+func (t *Transactor) createWallet(password string) (string, string, error) {
+	seed, err := wallet.GenerateSeed(rand.Read)
+	if err != nil {
+		return "", "", err
+	}
+
+	phrase, err := mnemonic.FromSeed(seed)
+	if err != nil {
+		return "", "", err
+	}
+
+	walletAddr, err := t.loadFromSeed(seed, password)
+	if err != nil {
+		return "", "", err
+	}
+
+	return walletAddr, phrase, nil
+}
+
+// createFromSeed restores a wallet from its seed, either hex-encoded or as a
+// mnemonic
+func (t *Transactor) createFromSeed(seed string, password string) (string, error) {
+	seedBytes, err := decodeSeed(seed)
+	if err != nil {
+		return "", err
+	}
+
+	return t.loadFromSeed(seedBytes, password)
+}
+
+func decodeSeed(seed string) ([]byte, error) {
+	// A mnemonic is made of several words, while a raw seed is a single hex
+	// string
+	if len(strings.Fields(seed)) > 1 {
+		return mnemonic.ToSeed(seed)
+	}
+
+	seedBytes, err := hex.DecodeString(strings.TrimSpace(seed))
+	if err != nil {
+		return nil, fmt.Errorf("error attempting to decode seed: %v", err)
+	}
+
+	return seedBytes, nil
+}
+
+func (t *Transactor) loadFromSeed(seedBytes []byte, password string) (string, error) {
+	// First load the database
 	db, err := walletdb.New(cfg.Get().Wallet.Store)
 	if err != nil {
 		return "", err
 	}
 
-	w, err := wallet.New(rand.Read, testnet, db, t.fetchDecoys, t.fetchInputs, password, cfg.Get().Wallet.File)
+	// Then load the wallet
+	w, err := wallet.LoadFromSeed(seedBytes, testnet, db, t.fetchDecoys, t.fetchInputs, password, cfg.Get().Wallet.File)
 	if err != nil {
 		_ = db.Close()
 		return "", err
@@ -65,11 +145,16 @@ func (t *Transactor) createWallet(password string) (string, error) {
 	return walletAddr, nil
 }
 
-func (t *Transactor) createFromSeed(seed string, password string) (string, error) {
+// loadWatchOnly loads a watch-only wallet from a hex-encoded view key
+func (t *Transactor) loadWatchOnly(viewKey string, password string) (string, error) {
+	viewKeyBytes, err := hex.DecodeString(strings.TrimSpace(viewKey))
+	if err != nil {
+		return "", fmt.Errorf("error attempting to decode view key: %v", err)
+	}
 
-	seedBytes, err := hex.DecodeString(seed)
+	k, err := key.ViewKeyFromBytes(viewKeyBytes)
 	if err != nil {
-		return "", fmt.Errorf("error attempting to decode seed: %v", err)
+		return "", err
 	}
 
 	// First load the database
@@ -79,7 +164,7 @@ func (t *Transactor) createFromSeed(seed string, password string) (string, error
 	}
 
 	// Then load the wallet
-	w, err := wallet.LoadFromSeed(seedBytes, testnet, db, t.fetchDecoys, t.fetchInputs, password, cfg.Get().Wallet.File)
+	w, err := wallet.NewWatchOnly(testnet, db, t.fetchDecoys, k, password)
 	if err != nil {
 		_ = db.Close()
 		return "", err
@@ -95,23 +180,81 @@ func (t *Transactor) createFromSeed(seed string, password string) (string, error
 	return walletAddr, nil
 }
 
-// CreateStandardTx will create a tx for given amount and address
-func (t *Transactor) CreateStandardTx(amount uint64, address string) (transactions.Transaction, error) {
+// CreateUnsignedTx builds a standard tx paying the recipients from a
+// watch-only wallet, to be signed offline by a wallet.Signer. It pays a fee
+// amount, as the size of the signed tx is not known to pay a fee rate.
+func (t *Transactor) CreateUnsignedTx(recipients []Recipient, fee Fee) (*wallet.UnsignedTx, error) {
+	if _, err := checkRecipients(recipients); err != nil {
+		return nil, err
+	}
+
+	if fee.Rate > 0 {
+		return nil, errUnsignedFeeRate
+	}
 
-	// Create a new standard tx
-	// TODO: customizable fee
-	tx, err := t.w.NewStandardTx(cfg.MinFee)
+	txFee, err := t.txFee(fee, 0, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	// Turn amount into a scalar
-	amountScalar := ristretto.Scalar{}
-	amountScalar.SetBigInt(big.NewInt(0).SetUint64(amount))
+	outputs := make([]wallet.UnsignedOutput, len(recipients))
+	for i, r := range recipients {
+		// The addresses are checked here, as the tx is signed elsewhere
+		if _, err := key.PublicAddress(r.Address).ToKey(testnet); err != nil {
+			return nil, fmt.Errorf("recipient %d: %v", i+1, err)
+		}
+
+		outputs[i] = wallet.UnsignedOutput{Address: key.PublicAddress(r.Address), Amount: r.Amount}
+	}
+
+	return t.w.NewUnsignedTx(outputs, txFee)
+}
+
+// CreateStandardTx will create a tx sending an amount to each recipient. The
+// recipients are paid by the outputs of the tx, along with a single change
+// output.
+func (t *Transactor) CreateStandardTx(recipients []Recipient, fee Fee) (transactions.Transaction, error) {
+	sent, err := checkRecipients(recipients)
+	if err != nil {
+		return nil, err
+	}
+
+	newTx := func(fee int64) (*transactions.Standard, error) {
+		// Create a new standard tx
+		tx, err := t.w.NewStandardTx(fee)
+		if err != nil {
+			return nil, err
+		}
+
+		for i, r := range recipients {
+			// Turn amount into a scalar
+			amountScalar := ristretto.Scalar{}
+			amountScalar.SetBigInt(big.NewInt(0).SetUint64(r.Amount))
+
+			// Send amount to address
+			if e := tx.AddOutput(key.PublicAddress(r.Address), amountScalar); e != nil {
+				return nil, fmt.Errorf("recipient %d: %v", i+1, e)
+			}
+		}
+
+		return tx, nil
+	}
+
+	txFee, err := t.txFee(fee, sent, func(fee int64) (transactions.Transaction, error) {
+		tx, err := newTx(fee)
+		if err != nil {
+			return nil, err
+		}
 
-	// Send amount to address
-	if e := tx.AddOutput(key.PublicAddress(address), amountScalar); e != nil {
-		return nil, e
+		return tx, t.w.Prepare(tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := newTx(txFee)
+	if err != nil {
+		return nil, err
 	}
 
 	// Sign tx
@@ -123,14 +266,26 @@ func (t *Transactor) CreateStandardTx(amount uint64, address string) (transactio
 }
 
 // CreateStakeTx create a stake for amount and lockTime
-func (t *Transactor) CreateStakeTx(amount, lockTime uint64) (transactions.Transaction, error) {
+func (t *Transactor) CreateStakeTx(amount, lockTime uint64, fee Fee) (transactions.Transaction, error) {
 
 	// Turn amount into a scalar
 	amountScalar := ristretto.Scalar{}
 	amountScalar.SetBigInt(big.NewInt(0).SetUint64(amount))
 
+	txFee, err := t.txFee(fee, amount, func(fee int64) (transactions.Transaction, error) {
+		tx, err := t.w.NewStakeTx(fee, lockTime, amountScalar)
+		if err != nil {
+			return nil, err
+		}
+
+		return tx, t.w.Prepare(tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	// Create a new stake tx
-	tx, err := t.w.NewStakeTx(cfg.MinFee, lockTime, amountScalar)
+	tx, err := t.w.NewStakeTx(txFee, lockTime, amountScalar)
 	if err != nil {
 		return nil, err
 	}
@@ -145,14 +300,26 @@ func (t *Transactor) CreateStakeTx(amount, lockTime uint64) (transactions.Transa
 }
 
 // CreateBidTx for a amount and lockTime
-func (t *Transactor) CreateBidTx(amount, lockTime uint64) (transactions.Transaction, error) {
+func (t *Transactor) CreateBidTx(amount, lockTime uint64, fee Fee) (transactions.Transaction, error) {
 
 	// Turn amount into a scalar
 	amountScalar := ristretto.Scalar{}
 	amountScalar.SetBigInt(big.NewInt(0).SetUint64(amount))
 
+	txFee, err := t.txFee(fee, amount, func(fee int64) (transactions.Transaction, error) {
+		tx, err := t.w.NewBidTx(fee, lockTime, amountScalar)
+		if err != nil {
+			return nil, err
+		}
+
+		return tx, t.w.Prepare(tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	// Create a new bid tx
-	tx, err := t.w.NewBidTx(cfg.MinFee, lockTime, amountScalar)
+	tx, err := t.w.NewBidTx(txFee, lockTime, amountScalar)
 	if err != nil {
 		return nil, err
 	}
@@ -166,6 +333,111 @@ func (t *Transactor) CreateBidTx(amount, lockTime uint64) (transactions.Transact
 	return tx, nil
 }
 
+// checkRecipients ensures the recipients fit in a single tx, and the total
+// amount sent to them can be spent. It returns the total amount.
+func checkRecipients(recipients []Recipient) (uint64, error) {
+	if len(recipients) == 0 {
+		return 0, errNoRecipients
+	}
+
+	if len(recipients) > transactions.MaxRecipients {
+		return 0, errTooManyRecipients
+	}
+
+	var total uint64
+	for i, r := range recipients {
+		if r.Amount == 0 {
+			return 0, fmt.Errorf("recipient %d: amount must be positive", i+1)
+		}
+
+		if r.Amount > math.MaxInt64-total {
+			return 0, errors.New("total amount is too high")
+		}
+
+		total += r.Amount
+	}
+
+	return total, nil
+}
+
+// txFee returns the fee a tx sending an amount of `sent` pays. When paying a
+// fee rate, a single draft of the tx is prepared to measure its size. Only the
+// number of inputs changes with the fee, so the size of the tx paying a higher
+// fee is estimated from the inputs needed to pay it, until the fee covers the
+// estimate. The fee is never below the minimum fee.
+func (t *Transactor) txFee(fee Fee, sent uint64, draft func(fee int64) (transactions.Transaction, error)) (int64, error) {
+	if fee.Amount > 0 && fee.Rate > 0 {
+		return 0, errAmbiguousFee
+	}
+
+	if fee.Rate == 0 {
+		switch {
+		case fee.Amount == 0:
+			return cfg.MinFee, nil
+		case fee.Amount < uint64(cfg.MinFee):
+			return 0, fmt.Errorf("fee must be at least %d", cfg.MinFee)
+		case fee.Amount > math.MaxInt64:
+			return 0, errFeeTooHigh
+		}
+
+		return int64(fee.Amount), nil
+	}
+
+	amount := uint64(cfg.MinFee)
+	tx, err := draft(int64(amount))
+	if err != nil {
+		return 0, err
+	}
+
+	buf := new(bytes.Buffer)
+	if err := message.MarshalTx(buf, tx); err != nil {
+		return 0, err
+	}
+
+	draftSize := uint64(buf.Len())
+	draftInputs := tx.StandardTx().Inputs
+
+	// All inputs have a ring of the same size, and so the same encoding size
+	var inputSize uint64
+	if len(draftInputs) > 0 {
+		buf.Reset()
+		if err := message.MarshalInput(buf, draftInputs[0], true); err != nil {
+			return 0, err
+		}
+
+		inputSize = uint64(buf.Len())
+	}
+
+	for i := 0; i < maxFeeEstimates; i++ {
+		if amount > math.MaxInt64-sent {
+			return 0, errFeeTooHigh
+		}
+
+		count, err := t.w.CountInputs(int64(sent + amount))
+		if err != nil {
+			return 0, err
+		}
+
+		size := draftSize + feeSizeMargin
+		if count > len(draftInputs) {
+			size += uint64(count-len(draftInputs)) * inputSize
+		}
+
+		required := transactions.FeeForRate(fee.Rate, size)
+		if required <= amount {
+			return int64(amount), nil
+		}
+
+		if required > math.MaxInt64 {
+			return 0, errFeeTooHigh
+		}
+
+		amount = required
+	}
+
+	return 0, errFeeNotConverged
+}
+
 func (t *Transactor) syncWallet() error {
 	var totalSpent, totalReceived uint64
 	// keep looping until tipHash = currentBlockHash
@@ -0,0 +1,77 @@
+package transactor
+
+import (
+	"crypto/rand"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	cfg "github.com/dusk-network/dusk-blockchain/pkg/config"
+	walletdb "github.com/dusk-network/dusk-blockchain/pkg/core/data/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/wallet"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test that a standard tx pays each recipient with an output, plus a single
+// change output, and that the recipients which do not fit in a tx are refused.
+func TestCreateStandardTx(t *testing.T) {
+	dir, err := ioutil.TempDir("", "transactor")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	db, err := walletdb.New(filepath.Join(dir, "walletDB"))
+	assert.NoError(t, err)
+	defer func() {
+		_ = db.Close()
+	}()
+
+	w, err := wallet.New(rand.Read, testnet, db, wallet.GenerateDecoys, wallet.GenerateInputs, "pass", filepath.Join(dir, "wallet.dat"))
+	assert.NoError(t, err)
+	tr := &Transactor{w: w}
+
+	tests := []struct {
+		name       string
+		recipients []Recipient
+		err        bool
+	}{
+		{"single recipient", randRecipients(t, 1), false},
+		{"several recipients", randRecipients(t, 3), false},
+		{"as many recipients as fit in a tx", randRecipients(t, transactions.MaxRecipients), false},
+		{"no recipients", nil, true},
+		{"too many recipients", randRecipients(t, transactions.MaxRecipients+1), true},
+		{"zero amount", []Recipient{{Address: randRecipients(t, 1)[0].Address}}, true},
+		{"invalid address", []Recipient{{Address: "notanaddress", Amount: 1}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, err := tr.CreateStandardTx(tt.recipients, Fee{})
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Len(t, tx.StandardTx().Outputs, len(tt.recipients)+1)
+			assert.Equal(t, uint64(cfg.MinFee), tx.StandardTx().Fee.BigInt().Uint64())
+		})
+	}
+}
+
+func randRecipients(t *testing.T, n int) []Recipient {
+	recipients := make([]Recipient, n)
+	for i := range recipients {
+		seed := make([]byte, 64)
+		_, err := rand.Read(seed)
+		assert.NoError(t, err)
+
+		address, err := key.NewKeyPair(seed).PublicKey().PublicAddress(testnet)
+		assert.NoError(t, err)
+		recipients[i] = Recipient{Address: address.String(), Amount: uint64(i+1) * wallet.DUSK}
+	}
+
+	return recipients
+}
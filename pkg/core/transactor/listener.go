@@ -11,8 +11,10 @@ import (
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/initiator"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/wallet"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/rpc/nodeext"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
 	"github.com/dusk-network/dusk-protobuf/autogen/go/node"
 	logger "github.com/sirupsen/logrus"
@@ -23,6 +25,12 @@ var (
 
 	errWalletNotLoaded     = errors.New("wallet is not loaded yet")
 	errWalletAlreadyLoaded = errors.New("wallet is already loaded")
+	errAmbiguousFee        = errors.New("either a fee or a fee rate can be set")
+	errFeeTooHigh          = errors.New("fee is too high")
+	errFeeNotConverged     = errors.New("could not compute a fee paying the fee rate")
+	errNoRecipients        = errors.New("a tx needs at least one recipient")
+	errTooManyRecipients   = fmt.Errorf("a tx can have at most %d recipients", transactions.MaxRecipients)
+	errUnsignedFeeRate     = errors.New("an unsigned tx can not pay a fee rate, as the size of the signed tx is not known")
 )
 
 func loadResponse(pubKey []byte) *node.LoadResponse {
@@ -38,6 +46,8 @@ func (t *Transactor) Listen() {
 		// Wallet requests to respond to
 		case r := <-t.createWalletChan:
 			handleRequest(r, t.handleCreateWallet, "CreateWallet")
+		case r := <-t.createWalletWithMnemonicChan:
+			handleRequest(r, t.handleCreateWalletWithMnemonic, "CreateWalletWithMnemonic")
 		case r := <-t.createFromSeedChan:
 			handleRequest(r, t.handleCreateFromSeed, "CreateWalletFromSeed")
 		case r := <-t.loadWalletChan:
@@ -46,6 +56,10 @@ func (t *Transactor) Listen() {
 			handleRequest(r, t.handleAutomateConsensusTxs, "AutomateConsensusTxs")
 		case r := <-t.clearWalletDatabaseChan:
 			handleRequest(r, t.handleClearWalletDatabase, "ClearWalletDatabase")
+		case r := <-t.changePasswordChan:
+			handleRequest(r, t.handleChangePassword, "ChangePassword")
+		case r := <-t.loadWatchOnlyWalletChan:
+			handleRequest(r, t.handleLoadWatchOnlyWallet, "LoadWatchOnlyWallet")
 
 		// Transaction requests to respond to
 		case r := <-t.sendBidTxChan:
@@ -54,6 +68,10 @@ func (t *Transactor) Listen() {
 			handleRequest(r, t.handleSendStakeTx, "StakeTx")
 		case r := <-t.sendStandardTxChan:
 			handleRequest(r, t.handleSendStandardTx, "StandardTx")
+		case r := <-t.createUnsignedTxChan:
+			handleRequest(r, t.handleCreateUnsignedTx, "CreateUnsignedTx")
+		case r := <-t.submitSignedTxChan:
+			handleRequest(r, t.handleSubmitSignedTx, "SubmitSignedTx")
 
 		// Information requests to respond to
 		case r := <-t.getBalanceChan:
@@ -95,7 +113,7 @@ func (t *Transactor) handleCreateWallet(r rpcbus.Request) error {
 
 	req := r.Params.(*node.CreateRequest)
 
-	pubKey, err := t.createWallet(req.Password)
+	pubKey, _, err := t.createWallet(req.Password)
 	if err != nil {
 		return err
 	}
@@ -107,6 +125,25 @@ func (t *Transactor) handleCreateWallet(r rpcbus.Request) error {
 	return nil
 }
 
+func (t *Transactor) handleCreateWalletWithMnemonic(r rpcbus.Request) error {
+	if t.w != nil {
+		return errWalletAlreadyLoaded
+	}
+
+	req := r.Params.(*nodeext.CreateWalletRequest)
+
+	pubKey, phrase, err := t.createWallet(req.Password)
+	if err != nil {
+		return err
+	}
+
+	t.launchConsensus()
+
+	r.RespChan <- rpcbus.Response{Resp: &nodeext.CreateWalletResponse{Address: pubKey, Mnemonic: phrase}, Err: nil}
+
+	return nil
+}
+
 func (t *Transactor) handleAddress(r rpcbus.Request) error {
 	if t.w == nil {
 		return errWalletNotLoaded
@@ -199,11 +236,11 @@ func (t *Transactor) handleSendBidTx(r rpcbus.Request) error {
 		return errWalletNotLoaded
 	}
 
-	req := r.Params.(*node.ConsensusTxRequest)
+	amount, lockTime, fee := consensusTxParams(r.Params)
 	// create and sign transaction
-	log.Tracef("Create a bid tx (%d,%d)", req.Amount, req.LockTime)
+	log.Tracef("Create a bid tx (%d,%d)", amount, lockTime)
 
-	tx, err := t.CreateBidTx(req.Amount, req.LockTime)
+	tx, err := t.CreateBidTx(amount, lockTime, fee)
 	if err != nil {
 		return err
 	}
@@ -229,11 +266,11 @@ func (t *Transactor) handleSendStakeTx(r rpcbus.Request) error {
 		return errWalletNotLoaded
 	}
 
-	req := r.Params.(*node.ConsensusTxRequest)
+	amount, lockTime, fee := consensusTxParams(r.Params)
 	// create and sign transaction
-	log.Tracef("Create a stake tx (%d,%d)", req.Amount, req.LockTime)
+	log.Tracef("Create a stake tx (%d,%d)", amount, lockTime)
 
-	tx, err := t.CreateStakeTx(req.Amount, req.LockTime)
+	tx, err := t.CreateStakeTx(amount, lockTime, fee)
 	if err != nil {
 		return err
 	}
@@ -255,11 +292,17 @@ func (t *Transactor) handleSendStandardTx(r rpcbus.Request) error {
 		return errWalletNotLoaded
 	}
 
-	req := r.Params.(*node.TransferRequest)
+	// The wallet is synced with the stored blocks first, so that a tx sent
+	// as soon as a block is accepted can spend the outputs of the block
+	if err := t.syncWallet(); err != nil {
+		return err
+	}
+
+	recipients, fee := transferParams(r.Params)
 	// create and sign transaction
-	log.Tracef("Create a standard tx (%d,%s)", req.Amount, string(req.Address))
+	log.Tracef("Create a standard tx to %d recipients", len(recipients))
 
-	tx, err := t.CreateStandardTx(req.Amount, string(req.Address))
+	tx, err := t.CreateStandardTx(recipients, fee)
 	if err != nil {
 		return err
 	}
@@ -275,6 +318,100 @@ func (t *Transactor) handleSendStandardTx(r rpcbus.Request) error {
 	return nil
 }
 
+// handleLoadWatchOnlyWallet loads a watch-only wallet. No consensus is run,
+// as the wallet holds no consensus keys
+func (t *Transactor) handleLoadWatchOnlyWallet(r rpcbus.Request) error {
+	if t.w != nil {
+		return errWalletAlreadyLoaded
+	}
+
+	req := r.Params.(*nodeext.LoadWatchOnlyRequest)
+	addr, err := t.loadWatchOnly(req.ViewKey, req.Password)
+	if err != nil {
+		return err
+	}
+
+	r.RespChan <- rpcbus.Response{Resp: &nodeext.LoadWatchOnlyResponse{Address: addr}, Err: nil}
+	return nil
+}
+
+func (t *Transactor) handleCreateUnsignedTx(r rpcbus.Request) error {
+	if t.w == nil {
+		return errWalletNotLoaded
+	}
+
+	recipients, fee := transferParams(r.Params)
+	log.Tracef("Create an unsigned tx to %d recipients", len(recipients))
+
+	u, err := t.CreateUnsignedTx(recipients, fee)
+	if err != nil {
+		return err
+	}
+
+	buf := new(bytes.Buffer)
+	if err := wallet.MarshalUnsignedTx(buf, u); err != nil {
+		return err
+	}
+
+	r.RespChan <- rpcbus.Response{Resp: &nodeext.UnsignedTxResponse{Tx: buf.Bytes()}, Err: nil}
+	return nil
+}
+
+// handleSubmitSignedTx publishes a tx signed offline. It needs no wallet, but
+// the inputs spent by the tx are removed from a loaded watch-only wallet,
+// which does not detect them otherwise
+func (t *Transactor) handleSubmitSignedTx(r rpcbus.Request) error {
+	tx := r.Params.(transactions.Transaction)
+
+	//  Publish transaction to the mempool processing
+	txid, err := t.publishTx(tx)
+	if err != nil {
+		return err
+	}
+
+	if t.w != nil && t.w.WatchOnly() {
+		if err := t.w.RemoveSpentInputs(tx); err != nil {
+			log.WithError(err).Warnln("could not remove the inputs spent by the signed tx")
+		}
+	}
+
+	r.RespChan <- rpcbus.Response{Resp: &node.TransferResponse{Hash: txid}, Err: nil}
+	return nil
+}
+
+// transferParams reads a transfer request. Requests of the NodeExt service
+// carry a custom fee and possibly several recipients, while the ones of the
+// Node service pay the minimum fee to a single recipient
+func transferParams(params interface{}) ([]Recipient, Fee) {
+	switch req := params.(type) {
+	case *nodeext.TransferRequest:
+		recipient := Recipient{Address: string(req.Address), Amount: req.Amount}
+		return []Recipient{recipient}, Fee{Amount: req.Fee, Rate: req.FeeRate}
+	case *nodeext.MultiTransferRequest:
+		recipients := make([]Recipient, len(req.Recipients))
+		for i, r := range req.Recipients {
+			recipients[i] = Recipient{Address: string(r.Address), Amount: r.Amount}
+		}
+
+		return recipients, Fee{Amount: req.Fee, Rate: req.FeeRate}
+	}
+
+	req := params.(*node.TransferRequest)
+	return []Recipient{{Address: string(req.Address), Amount: req.Amount}}, Fee{}
+}
+
+// consensusTxParams reads a bid or stake request. Requests of the NodeExt
+// service carry a custom fee, while the ones of the Node service pay the
+// minimum fee
+func consensusTxParams(params interface{}) (uint64, uint64, Fee) {
+	if req, ok := params.(*nodeext.ConsensusTxRequest); ok {
+		return req.Amount, req.LockTime, Fee{Amount: req.Fee, Rate: req.FeeRate}
+	}
+
+	req := params.(*node.ConsensusTxRequest)
+	return req.Amount, req.LockTime, Fee{}
+}
+
 func (t *Transactor) handleBalance(r rpcbus.Request) error {
 
 	if t.w == nil {
@@ -342,6 +479,20 @@ func (t *Transactor) handleClearWalletDatabase(r rpcbus.Request) error {
 	return nil
 }
 
+func (t *Transactor) handleChangePassword(r rpcbus.Request) error {
+	if t.w == nil {
+		return errWalletNotLoaded
+	}
+
+	req := r.Params.(*nodeext.ChangePasswordRequest)
+	if err := t.w.ChangePassword(req.OldPassword, req.NewPassword); err != nil {
+		return err
+	}
+
+	r.RespChan <- rpcbus.Response{Resp: &nodeext.ChangePasswordResponse{Response: "Wallet password changed."}, Err: nil}
+	return nil
+}
+
 func (t *Transactor) handleIsWalletLoaded(r rpcbus.Request) error {
 	r.RespChan <- rpcbus.Response{Resp: &node.WalletStatusResponse{Loaded: t.w != nil}, Err: nil}
 	return nil
@@ -33,19 +33,24 @@ type Transactor struct { // TODO: rename
 	acceptedBlockChan <-chan block.Block
 
 	// rpcbus channels
-	createWalletChan          chan rpcbus.Request
-	createFromSeedChan        chan rpcbus.Request
-	loadWalletChan            chan rpcbus.Request
-	sendBidTxChan             chan rpcbus.Request
-	sendStakeTxChan           chan rpcbus.Request
-	sendStandardTxChan        chan rpcbus.Request
-	getBalanceChan            chan rpcbus.Request
-	getUnconfirmedBalanceChan chan rpcbus.Request
-	getAddressChan            chan rpcbus.Request
-	getTxHistoryChan          chan rpcbus.Request
-	automateConsensusTxsChan  chan rpcbus.Request
-	isWalletLoadedChan        chan rpcbus.Request
-	clearWalletDatabaseChan   chan rpcbus.Request
+	createWalletChan             chan rpcbus.Request
+	createWalletWithMnemonicChan chan rpcbus.Request
+	createFromSeedChan           chan rpcbus.Request
+	loadWalletChan               chan rpcbus.Request
+	sendBidTxChan                chan rpcbus.Request
+	sendStakeTxChan              chan rpcbus.Request
+	sendStandardTxChan           chan rpcbus.Request
+	getBalanceChan               chan rpcbus.Request
+	getUnconfirmedBalanceChan    chan rpcbus.Request
+	getAddressChan               chan rpcbus.Request
+	getTxHistoryChan             chan rpcbus.Request
+	automateConsensusTxsChan     chan rpcbus.Request
+	isWalletLoadedChan           chan rpcbus.Request
+	clearWalletDatabaseChan      chan rpcbus.Request
+	changePasswordChan           chan rpcbus.Request
+	loadWatchOnlyWalletChan      chan rpcbus.Request
+	createUnsignedTxChan         chan rpcbus.Request
+	submitSignedTxChan           chan rpcbus.Request
 }
 
 // New Instantiate a new Transactor struct.
@@ -66,19 +71,24 @@ func New(eb *eventbus.EventBus, rb *rpcbus.RPCBus, db database.DB,
 		fetchInputs: finputs,
 		walletOnly:  walletOnly,
 
-		createWalletChan:          make(chan rpcbus.Request, 1),
-		createFromSeedChan:        make(chan rpcbus.Request, 1),
-		loadWalletChan:            make(chan rpcbus.Request, 1),
-		sendBidTxChan:             make(chan rpcbus.Request, 1),
-		sendStakeTxChan:           make(chan rpcbus.Request, 1),
-		sendStandardTxChan:        make(chan rpcbus.Request, 1),
-		getBalanceChan:            make(chan rpcbus.Request, 1),
-		getUnconfirmedBalanceChan: make(chan rpcbus.Request, 1),
-		getAddressChan:            make(chan rpcbus.Request, 1),
-		getTxHistoryChan:          make(chan rpcbus.Request, 1),
-		automateConsensusTxsChan:  make(chan rpcbus.Request, 1),
-		isWalletLoadedChan:        make(chan rpcbus.Request, 1),
-		clearWalletDatabaseChan:   make(chan rpcbus.Request, 1),
+		createWalletChan:             make(chan rpcbus.Request, 1),
+		createWalletWithMnemonicChan: make(chan rpcbus.Request, 1),
+		createFromSeedChan:           make(chan rpcbus.Request, 1),
+		loadWalletChan:               make(chan rpcbus.Request, 1),
+		sendBidTxChan:                make(chan rpcbus.Request, 1),
+		sendStakeTxChan:              make(chan rpcbus.Request, 1),
+		sendStandardTxChan:           make(chan rpcbus.Request, 1),
+		getBalanceChan:               make(chan rpcbus.Request, 1),
+		getUnconfirmedBalanceChan:    make(chan rpcbus.Request, 1),
+		getAddressChan:               make(chan rpcbus.Request, 1),
+		getTxHistoryChan:             make(chan rpcbus.Request, 1),
+		automateConsensusTxsChan:     make(chan rpcbus.Request, 1),
+		isWalletLoadedChan:           make(chan rpcbus.Request, 1),
+		clearWalletDatabaseChan:      make(chan rpcbus.Request, 1),
+		changePasswordChan:           make(chan rpcbus.Request, 1),
+		loadWatchOnlyWalletChan:      make(chan rpcbus.Request, 1),
+		createUnsignedTxChan:         make(chan rpcbus.Request, 1),
+		submitSignedTxChan:           make(chan rpcbus.Request, 1),
 	}
 
 	if t.fetchDecoys == nil {
@@ -149,7 +159,27 @@ func (t *Transactor) registerMethods() error {
 		return err
 	}
 
-	return t.rb.Register(topics.ClearWalletDatabase, t.clearWalletDatabaseChan)
+	if err := t.rb.Register(topics.ClearWalletDatabase, t.clearWalletDatabaseChan); err != nil {
+		return err
+	}
+
+	if err := t.rb.Register(topics.ChangePassword, t.changePasswordChan); err != nil {
+		return err
+	}
+
+	if err := t.rb.Register(topics.LoadWatchOnlyWallet, t.loadWatchOnlyWalletChan); err != nil {
+		return err
+	}
+
+	if err := t.rb.Register(topics.CreateUnsignedTx, t.createUnsignedTxChan); err != nil {
+		return err
+	}
+
+	if err := t.rb.Register(topics.SubmitSignedTx, t.submitSignedTxChan); err != nil {
+		return err
+	}
+
+	return t.rb.Register(topics.CreateWalletWithMnemonic, t.createWalletWithMnemonicChan)
 }
 
 // Wallet return wallet instance and err
@@ -62,12 +62,8 @@ func fetchDecoys(numMixins int) []mlsag.PubKeys {
 	return pubKeys
 }
 
-func fetchInputs(netPrefix byte, db *walletdb.DB, totalAmount int64, key *key.Key) ([]*transactions.Input, int64, error) {
+func fetchInputs(netPrefix byte, db *walletdb.DB, totalAmount int64, _ *key.Key) ([]*transactions.Input, int64, error) {
 	// Fetch all inputs from database that are >= totalAmount
 	// returns error if inputs do not add up to total amount
-	privSpend, err := key.PrivateSpend()
-	if err != nil {
-		return nil, 0, err
-	}
-	return db.FetchInputs(privSpend.Bytes(), totalAmount)
+	return db.FetchInputs(totalAmount)
 }
@@ -0,0 +1,220 @@
+package verifiers
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/pkg/errors"
+)
+
+// txSnapshot holds the chain state needed to verify a batch of txs. It is read
+// under a single database snapshot, so that the txs are verified against the
+// same state without opening a database transaction per check.
+type txSnapshot struct {
+	height uint64
+
+	// unlock heights of the existing outputs referenced by the tx inputs
+	outputs map[string]uint64
+
+	// key images of the tx inputs which are already spent on chain
+	spent map[string]struct{}
+}
+
+// CheckTxs verifies all txs of a block, as CheckTx does for a single tx.
+//
+// The chain state needed by all txs is read in one pass under a single
+// database snapshot. Key images spent twice within the block are detected in
+// one pass as well. Then, txs are verified concurrently by a pool of workers.
+// If more than one tx is invalid, the error of the first one is returned.
+func CheckTxs(db database.DB, blockHeight uint64, blockTime uint64, txs []transactions.Transaction) error {
+	return checkTxs(db, blockHeight, blockTime, txs, runtime.NumCPU())
+}
+
+func checkTxs(db database.DB, blockHeight uint64, blockTime uint64, txs []transactions.Transaction, workers int) error {
+	if err := checkBlockDoubleSpent(txs); err != nil {
+		return err
+	}
+
+	s, err := newTxSnapshot(db, txs)
+	if err != nil {
+		return err
+	}
+
+	if workers > len(txs) {
+		workers = len(txs)
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs = make([]error, len(txs))
+		// index of the first invalid tx found so far. Txs after it do not
+		// need to be verified.
+		firstInvalid = len(txs)
+	)
+
+	indexes := make(chan int, len(txs))
+	for i := range txs {
+		indexes <- i
+	}
+	close(indexes)
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				mu.Lock()
+				skip := i > firstInvalid
+				mu.Unlock()
+
+				if skip {
+					continue
+				}
+
+				if err := s.checkTx(uint64(i), blockHeight, blockTime, txs[i]); err != nil {
+					mu.Lock()
+					errs[i] = err
+					if i < firstInvalid {
+						firstInvalid = i
+					}
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if firstInvalid < len(txs) {
+		return errs[firstInvalid]
+	}
+
+	return nil
+}
+
+// checkBlockDoubleSpent returns an error if a key image is spent by more than
+// one input of the block txs
+func checkBlockDoubleSpent(txs []transactions.Transaction) error {
+	spentBy := make(map[string]int)
+	for i, tx := range txs {
+		if tx.Type() == transactions.CoinbaseType {
+			continue
+		}
+
+		for _, input := range tx.StandardTx().Inputs {
+			ki := string(input.KeyImage.Bytes())
+			if j, ok := spentBy[ki]; ok && j != i {
+				return fmt.Errorf("tx %d spends a key image already spent by tx %d in the same block", i, j)
+			}
+			spentBy[ki] = i
+		}
+	}
+
+	return nil
+}
+
+// newTxSnapshot reads the chain state referenced by the txs
+func newTxSnapshot(db database.DB, txs []transactions.Transaction) (*txSnapshot, error) {
+	s := &txSnapshot{
+		outputs: make(map[string]uint64),
+		spent:   make(map[string]struct{}),
+	}
+
+	err := db.View(func(t database.Transaction) error {
+		var err error
+		s.height, err = t.FetchCurrentHeight()
+		if err != nil {
+			return err
+		}
+
+		for _, tx := range txs {
+			if tx.Type() == transactions.CoinbaseType {
+				continue
+			}
+
+			for _, input := range tx.StandardTx().Inputs {
+				for _, keyV := range input.Signature.PubKeys {
+					key := keyV.OutputKey()
+					k := string(key.Bytes())
+					if _, ok := s.outputs[k]; ok {
+						continue
+					}
+
+					// A missing output is reported by the checks on the
+					// snapshot, any other lookup failure aborts the batch
+					exists, err := t.FetchOutputExists(key.Bytes())
+					if err == database.ErrOutputNotFound {
+						continue
+					}
+
+					if err != nil {
+						return err
+					}
+
+					if !exists {
+						continue
+					}
+
+					unlockHeight, err := t.FetchOutputUnlockHeight(key.Bytes())
+					if err != nil {
+						return err
+					}
+
+					s.outputs[k] = unlockHeight
+				}
+
+				exists, txID, _ := t.FetchKeyImageExists(input.KeyImage.Bytes())
+				if exists || txID != nil {
+					s.spent[string(input.KeyImage.Bytes())] = struct{}{}
+				}
+			}
+		}
+
+		return nil
+	})
+
+	return s, err
+}
+
+// checkTx verifies a tx against the snapshot. It is safe for concurrent use.
+func (s *txSnapshot) checkTx(index uint64, blockHeight uint64, blockTime uint64, tx transactions.Transaction) error {
+	if tx.Type() != transactions.CoinbaseType {
+		if err := s.checkStandardTx(tx.StandardTx()); err != nil {
+			return err
+		}
+	}
+
+	return CheckSpecialFields(index, blockHeight, blockTime, tx)
+}
+
+// checkStandardTx is the counterpart of CheckStandardTx against the snapshot
+func (s *txSnapshot) checkStandardTx(tx *transactions.Standard) error {
+	if err := checkStandardFields(tx); err != nil {
+		return err
+	}
+
+	for _, input := range tx.Inputs {
+		for _, keyV := range input.Signature.PubKeys {
+			key := keyV.OutputKey()
+			unlockHeight, ok := s.outputs[string(key.Bytes())]
+			if !ok {
+				return errors.New("This key is not a previous output ")
+			}
+
+			// Found an input which is still locked
+			if unlockHeight > s.height {
+				return errors.New("transaction contains one or more locked inputs")
+			}
+		}
+
+		if _, ok := s.spent[string(input.KeyImage.Bytes())]; ok {
+			return errors.New("already spent")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package verifiers_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bwesterb/go-ristretto"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database/lite"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/verifiers"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
+	"github.com/dusk-network/dusk-crypto/mlsag"
+	"github.com/stretchr/testify/assert"
+)
+
+// number of txs in a block on benchmarking
+const benchBlockTxs = 1000
+
+func TestCheckTxs(t *testing.T) {
+	assert := assert.New(t)
+
+	db, txs := prepareBatch(t, 20)
+	blockTime := uint64(time.Now().Unix())
+
+	// Valid txs should pass both the sequential and the batch verification
+	for i, tx := range txs {
+		assert.NoError(verifiers.CheckTx(db, uint64(i), 1, blockTime, tx))
+	}
+	assert.NoError(verifiers.CheckTxs(db, 1, blockTime, txs))
+
+	// A key image spent twice in the block is not detected by the checks of
+	// each single tx
+	txs[5].StandardTx().Inputs[0].KeyImage = txs[2].StandardTx().Inputs[1].KeyImage
+	assert.NoError(verifiers.CheckTx(db, 5, 1, blockTime, txs[5]))
+	assert.Error(verifiers.CheckTxs(db, 1, blockTime, txs))
+
+	// A tx spending an unknown output should fail
+	db, txs = prepareBatch(t, 20)
+	var p ristretto.Point
+	p.Rand()
+	var pk mlsag.PubKeys
+	pk.AddPubKey(p)
+	txs[7].StandardTx().Inputs[0].Signature.PubKeys = []mlsag.PubKeys{pk}
+	assert.Error(verifiers.CheckTx(db, 7, 1, blockTime, txs[7]))
+	assert.EqualError(verifiers.CheckTxs(db, 1, blockTime, txs), "This key is not a previous output ")
+}
+
+func BenchmarkCheckTxsSequential(b *testing.B) {
+	db, txs := prepareBatch(b, benchBlockTxs)
+	blockTime := uint64(time.Now().Unix())
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		for i, tx := range txs {
+			if err := verifiers.CheckTx(db, uint64(i), 1, blockTime, tx); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkCheckTxsBatch(b *testing.B) {
+	db, txs := prepareBatch(b, benchBlockTxs)
+	blockTime := uint64(time.Now().Unix())
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		if err := verifiers.CheckTxs(db, 1, blockTime, txs); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// prepareBatch creates a chain with a single block, which funds the outputs
+// spent by the returned standard txs
+func prepareBatch(tb testing.TB, size int) (database.DB, []transactions.Transaction) {
+	db, err := lite.NewDatabase("", protocol.TestNet, false)
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	funding, err := transactions.NewStandard(0, 2, 100)
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	txs := make([]transactions.Transaction, size)
+	for i := range txs {
+		tx, err := transactions.NewStandard(0, 2, 100)
+		if err != nil {
+			tb.Fatal(err)
+		}
+
+		for j := 0; j < 2; j++ {
+			var p ristretto.Point
+			p.Rand()
+
+			// the output spent by the input
+			out := &transactions.Output{}
+			out.PubKey.P = p
+			funding.Outputs = append(funding.Outputs, out)
+
+			var pk mlsag.PubKeys
+			pk.AddPubKey(p)
+
+			in := &transactions.Input{Signature: &mlsag.Signature{PubKeys: []mlsag.PubKeys{pk}}}
+			in.KeyImage.Rand()
+			tx.Inputs = append(tx.Inputs, in)
+
+			// the output created by the tx
+			var dest ristretto.Point
+			dest.Rand()
+			out = &transactions.Output{}
+			out.PubKey.P = dest
+			tx.Outputs = append(tx.Outputs, out)
+		}
+
+		txs[i] = tx
+	}
+
+	blk := block.NewBlock()
+	blk.Header.Height = 0
+	blk.Header.Timestamp = time.Now().Unix()
+	blk.Header.Hash = make([]byte, 32)
+	blk.Header.Seed = make([]byte, 33)
+	blk.Header.PrevBlockHash = make([]byte, 32)
+	blk.Header.TxRoot = make([]byte, 32)
+	blk.AddTx(funding)
+
+	if err := db.Update(func(t database.Transaction) error {
+		return t.StoreBlock(blk)
+	}); err != nil {
+		tb.Fatal(err)
+	}
+
+	return db, txs
+}
@@ -3,17 +3,20 @@ package verifiers
 import (
 	"bytes"
 	"errors"
+	"fmt"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/agreement"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/sortedset"
 	"github.com/dusk-network/dusk-crypto/bls"
 )
 
-// CheckBlockCertificate ensures that the block certificate is valid.
-func CheckBlockCertificate(provisioners user.Provisioners, blk block.Block) error {
+// CheckBlockCertificate ensures that the block certificate is valid. The
+// activation heights of the network set the size of the committees
+func CheckBlockCertificate(provisioners user.Provisioners, blk block.Block, act block.Activation) error {
 	if blk.Header.Height < 2 {
 		return nil
 	}
@@ -35,17 +38,16 @@ func CheckBlockCertificate(provisioners user.Provisioners, blk block.Block) erro
 	}
 
 	// Now, check the certificate's correctness for both reduction steps
-	if err := checkBlockCertificateForStep(stepOneBatchedSig, blk.Header.Certificate.StepOneCommittee, blk.Header.Height, stepOne, provisioners, blk.Header.Hash); err != nil {
+	maxSize := agreement.MaxCommitteeSizeAt(act, blk.Header.Height)
+	if err := checkBlockCertificateForStep(stepOneBatchedSig, blk.Header.Certificate.StepOneCommittee, maxSize, blk.Header.Height, stepOne, provisioners, blk.Header.Hash); err != nil {
 		return err
 	}
 
-	return checkBlockCertificateForStep(stepTwoBatchedSig, blk.Header.Certificate.StepTwoCommittee, blk.Header.Height, stepTwo, provisioners, blk.Header.Hash)
+	return checkBlockCertificateForStep(stepTwoBatchedSig, blk.Header.Certificate.StepTwoCommittee, maxSize, blk.Header.Height, stepTwo, provisioners, blk.Header.Hash)
 }
 
-func checkBlockCertificateForStep(batchedSig *bls.Signature, bitSet uint64, round uint64, step uint8, provisioners user.Provisioners, blockHash []byte) error {
-	size := committeeSize(provisioners.SubsetSizeAt(round))
-	committee := provisioners.CreateVotingCommittee(round, step, size)
-	subcommittee := committee.IntersectCluster(bitSet)
+func checkBlockCertificateForStep(batchedSig *bls.Signature, bitSet sortedset.Bitset, maxSize int, round uint64, step uint8, provisioners user.Provisioners, blockHash []byte) error {
+	subcommittee := certifiedSubcommittee(bitSet, maxSize, round, step, provisioners)
 	apk, err := agreement.ReconstructApk(subcommittee.Set)
 	if err != nil {
 		return err
@@ -54,9 +56,17 @@ func checkBlockCertificateForStep(batchedSig *bls.Signature, bitSet uint64, roun
 	return header.VerifySignatures(round, step, blockHash, apk, batchedSig)
 }
 
-func committeeSize(memberAmount int) int {
-	if memberAmount > agreement.MaxCommitteeSize {
-		return agreement.MaxCommitteeSize
+// certifiedSubcommittee returns the members of the committee of a step who are
+// set in the bitset of a certificate, with their votes
+func certifiedSubcommittee(bitSet sortedset.Bitset, maxSize int, round uint64, step uint8, provisioners user.Provisioners) sortedset.Cluster {
+	size := committeeSize(provisioners.SubsetSizeAt(round), maxSize)
+	committee := provisioners.CreateVotingCommittee(round, step, size)
+	return committee.IntersectClusterBitset(bitSet)
+}
+
+func committeeSize(memberAmount, maxSize int) int {
+	if memberAmount > maxSize {
+		return maxSize
 	}
 
 	return memberAmount
@@ -65,10 +75,12 @@ func committeeSize(memberAmount int) int {
 // CheckBlockHeader checks whether a block header is malformed,
 // These are stateless and stateful checks
 // returns nil, if all checks pass
-func CheckBlockHeader(prevBlock block.Block, blk block.Block) error {
-	// Version
-	if blk.Header.Version > 0 {
-		return errors.New("unsupported block version")
+func CheckBlockHeader(prevBlock block.Block, blk block.Block, act block.Activation) error {
+	// Version. It is set by the height, so that the format of the
+	// certificate and the rule of the coinbase change at the same height
+	// on every node
+	if version := act.VersionAt(blk.Header.Height); blk.Header.Version != version {
+		return fmt.Errorf("block version %d does not match the version %d of its height", blk.Header.Version, version)
 	}
 
 	// blk.Headerhash = prevHeaderHash
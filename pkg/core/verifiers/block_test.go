@@ -0,0 +1,39 @@
+package verifiers_test
+
+import (
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/tests/helper"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/verifiers"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test that a block header is only accepted with the version of its height.
+func TestCheckBlockHeaderVersion(t *testing.T) {
+	act := block.Activation{BitsetHeight: 10, RewardSplitHeight: 20}
+	var tests = []struct {
+		height  uint64
+		version uint8
+		valid   bool
+	}{
+		{9, block.LegacyVersion, true},
+		{9, block.BitsetVersion, false},
+		{10, block.LegacyVersion, false},
+		{10, block.BitsetVersion, true},
+		{19, block.CurrentVersion, false},
+		{20, block.BitsetVersion, false},
+		{20, block.CurrentVersion, true},
+	}
+
+	for _, tt := range tests {
+		prevBlock := helper.RandomBlock(t, tt.height-1, 1)
+		blk := helper.RandomBlock(t, tt.height, 1)
+		blk.Header.Version = tt.version
+		blk.Header.PrevBlockHash = prevBlock.Header.Hash
+		blk.Header.Timestamp = prevBlock.Header.Timestamp + 1
+
+		err := verifiers.CheckBlockHeader(*prevBlock, *blk, act)
+		assert.Equal(t, tt.valid, err == nil, "height %d, version %d: %v", tt.height, tt.version, err)
+	}
+}
@@ -0,0 +1,223 @@
+package verifiers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/agreement"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/sortedset"
+)
+
+// ProvisionerReward is the share of the block reward and fees of a
+// provisioner
+type ProvisionerReward struct {
+	PubKeyBLS []byte
+	Address   *key.PublicKey
+	Amount    uint64
+}
+
+// Rewards is the distribution of the block reward and fees of a block
+type Rewards struct {
+	Generator    uint64
+	Provisioners []ProvisionerReward
+}
+
+// BlockRewards distributes the block reward (config.GeneratorReward) and the
+// `fees` of a block, according to the following rule:
+//
+//   - config.ProvisionerRewardShare percent of the total goes to the
+//     provisioners who voted in the two reduction steps certified by the
+//     previous block. The amount is split in proportion to the votes of each
+//     provisioner, summed over both steps, rounding down
+//   - a provisioner without a valid reward address forfeits its share
+//   - the generator gets the rest, including the forfeited shares and the
+//     remainder of the divisions
+//
+// The provisioners are sorted by BLS public key, and the ones with a share of
+// zero are left out. The previous blocks below height 2 carry no certificate,
+// in which case the generator gets the whole amount.
+func BlockRewards(provisioners user.Provisioners, prevBlock block.Block, fees uint64, act block.Activation) Rewards {
+	return NewRewardSplit(provisioners, prevBlock, act).Rewards(fees)
+}
+
+// RewardVoter is a provisioner certified by a block, with a valid reward
+// address
+type RewardVoter struct {
+	PubKeyBLS []byte
+	Address   *key.PublicKey
+	Votes     uint64
+}
+
+// RewardSplit holds the provisioners sharing the rewards of a block. They
+// are drawn from the certificate of the previous block, against the
+// provisioner set which produced it. As the set changes once the previous
+// block is accepted, the split is to be computed before that
+type RewardSplit struct {
+	// Voters are sorted by BLS public key
+	Voters []RewardVoter
+	// Votes is the total amount of votes, forfeited ones included
+	Votes uint64
+}
+
+// NewRewardSplit returns the split of the rewards of the block following
+// `prevBlock`, among the `provisioners` certified by it
+func NewRewardSplit(provisioners user.Provisioners, prevBlock block.Block, act block.Activation) RewardSplit {
+	voters, votes := certificateVoters(provisioners, prevBlock, act)
+	split := RewardSplit{Votes: votes}
+	for _, bigI := range voters.Set {
+		pk := bigI.Bytes()
+		m := provisioners.GetMember(pk)
+		if m == nil || len(m.RewardAddress) == 0 {
+			continue
+		}
+
+		address, err := key.PublicKeyFromBytes(m.RewardAddress)
+		if err != nil {
+			continue
+		}
+
+		split.Voters = append(split.Voters, RewardVoter{
+			PubKeyBLS: pk,
+			Address:   address,
+			Votes:     uint64(voters.OccurrencesOf(pk)),
+		})
+	}
+
+	return split
+}
+
+// Rewards distributes the block reward and the `fees` of a block as
+// BlockRewards does
+func (s RewardSplit) Rewards(fees uint64) Rewards {
+	total := config.GeneratorReward + fees
+	rewards := Rewards{Generator: total}
+	if s.Votes == 0 {
+		return rewards
+	}
+
+	share := total * config.ProvisionerRewardShare / 100
+	for _, v := range s.Voters {
+		amount := share * v.Votes / s.Votes
+		if amount == 0 {
+			continue
+		}
+
+		rewards.Provisioners = append(rewards.Provisioners, ProvisionerReward{
+			PubKeyBLS: v.PubKeyBLS,
+			Address:   v.Address,
+			Amount:    amount,
+		})
+		rewards.Generator -= amount
+	}
+
+	return rewards
+}
+
+// certificateVoters returns the provisioners who voted in the reduction steps
+// certified by a block, with their votes, and the total amount of votes
+func certificateVoters(provisioners user.Provisioners, blk block.Block, act block.Activation) (sortedset.Cluster, uint64) {
+	voters := sortedset.NewCluster()
+	cert := blk.Header.Certificate
+	if blk.Header.Height < 2 || cert == nil || cert.Step < 2 {
+		return voters, 0
+	}
+
+	maxSize := agreement.MaxCommitteeSizeAt(act, blk.Header.Height)
+	steps := []struct {
+		step   uint8
+		bitSet sortedset.Bitset
+	}{
+		{cert.Step - 2, cert.StepOneCommittee},
+		{cert.Step - 1, cert.StepTwoCommittee},
+	}
+
+	var votes uint64
+	for _, s := range steps {
+		subcommittee := certifiedSubcommittee(s.bitSet, maxSize, blk.Header.Height, s.step, provisioners)
+		for _, pk := range subcommittee.Unravel() {
+			voters.Insert(pk)
+			votes++
+		}
+	}
+
+	return voters, votes
+}
+
+// BlockFees returns the sum of the fees of the transactions of a block
+func BlockFees(txs []transactions.Transaction) uint64 {
+	var fees uint64
+	for _, tx := range txs {
+		if tx.Type() == transactions.CoinbaseType {
+			continue
+		}
+
+		fees += tx.StandardTx().Fee.BigInt().Uint64()
+	}
+
+	return fees
+}
+
+// VerifyCoinbase checks that the coinbase of a block distributes the block
+// reward and fees as BlockRewards does, given the previous block and the
+// provisioner set which produced it
+func VerifyCoinbase(provisioners user.Provisioners, prevBlock block.Block, blk block.Block, act block.Activation) error {
+	return VerifyRewards(NewRewardSplit(provisioners, prevBlock, act), blk, act)
+}
+
+// VerifyRewards checks that the coinbase of a block distributes the block
+// reward and fees according to the RewardSplit of the block. The blocks below
+// the activation of the reward split must reward the generator only, with
+// config.GeneratorReward
+func VerifyRewards(split RewardSplit, blk block.Block, act block.Activation) error {
+	if len(blk.Txs) == 0 {
+		return errors.New("block has no coinbase transaction")
+	}
+
+	tx, ok := blk.Txs[0].(*transactions.Coinbase)
+	if !ok {
+		return errors.New("coinbase transaction is not in the first position")
+	}
+
+	if !act.RewardSplit(blk.Header.Height) {
+		if len(tx.Rewards) != 1 {
+			return errors.New("coinbase transaction must include 1 reward output")
+		}
+
+		// Ensure the reward is the fixed one
+		if tx.Rewards[0].EncryptedAmount.BigInt().Uint64() != config.GeneratorReward {
+			return fmt.Errorf("coinbase transaction must include a fixed reward of %d", config.GeneratorReward)
+		}
+
+		return nil
+	}
+
+	rewards := split.Rewards(BlockFees(blk.Txs))
+	if len(tx.Rewards) != len(rewards.Provisioners)+1 {
+		return fmt.Errorf("coinbase transaction must include %d reward outputs", len(rewards.Provisioners)+1)
+	}
+
+	if amount := tx.Rewards[0].EncryptedAmount.BigInt().Uint64(); amount != rewards.Generator {
+		return fmt.Errorf("coinbase transaction rewards the generator with %d instead of %d", amount, rewards.Generator)
+	}
+
+	nonce := tx.ProvisionerNonce()
+	for i, reward := range rewards.Provisioners {
+		output := tx.Rewards[i+1]
+		if amount := output.EncryptedAmount.BigInt().Uint64(); amount != reward.Amount {
+			return fmt.Errorf("coinbase transaction rewards provisioner %d with %d instead of %d", i, amount, reward.Amount)
+		}
+
+		expected := reward.Address.StealthAddress(nonce, uint32(i+1))
+		if !bytes.Equal(output.PubKey.P.Bytes(), expected.P.Bytes()) {
+			return fmt.Errorf("coinbase transaction rewards provisioner %d at the wrong address", i)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,166 @@
+package verifiers_test
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/bwesterb/go-ristretto"
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	consensuskey "github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/tests/helper"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/verifiers"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test that the block reward and fees are split between the generator and the
+// provisioners who voted for the previous block, and that the coinbase
+// carrying the split is accepted.
+func TestBlockRewards(t *testing.T) {
+	p, keys, wallets := rewardedProvisioners(4)
+	prevBlock := certifiedBlock(t, 10, p, keys)
+
+	blk := helper.RandomBlock(t, 11, 1)
+	blk.Header.Version = block.CurrentVersion
+	fees := verifiers.BlockFees(blk.Txs)
+	rewards := verifiers.BlockRewards(*p, *prevBlock, fees, block.Activation{})
+
+	// The last provisioner has no reward address
+	assert.NotEmpty(t, rewards.Provisioners)
+	assert.True(t, len(rewards.Provisioners) < len(keys))
+
+	// Nothing is created or lost in the split
+	total := rewards.Generator
+	for _, reward := range rewards.Provisioners {
+		total += reward.Amount
+	}
+	assert.Equal(t, config.GeneratorReward+fees, total)
+	assert.True(t, rewards.Generator >= (config.GeneratorReward+fees)*(100-config.ProvisionerRewardShare)/100)
+
+	cb := coinbaseFor(t, rewards)
+	blk.Txs[0] = cb
+	assert.NoError(t, verifiers.VerifyCoinbase(*p, *prevBlock, *blk, block.Activation{}))
+
+	// Every provisioner recognizes its reward
+	for i, reward := range rewards.Provisioners {
+		wallet := wallets[string(reward.PubKeyBLS)]
+		output := cb.Rewards[i+1]
+		_, ok := wallet.DidReceiveTx(cb.TxPubKeyAt(i+1), output.PubKey, uint32(i+1))
+		assert.True(t, ok)
+	}
+}
+
+// Test that a coinbase which does not follow the reward split is rejected.
+func TestVerifyCoinbaseInvalid(t *testing.T) {
+	p, keys, _ := rewardedProvisioners(4)
+	prevBlock := certifiedBlock(t, 10, p, keys)
+
+	blk := helper.RandomBlock(t, 11, 1)
+	blk.Header.Version = block.CurrentVersion
+	rewards := verifiers.BlockRewards(*p, *prevBlock, verifiers.BlockFees(blk.Txs), block.Activation{})
+
+	// Generator takes everything
+	greedy := verifiers.Rewards{Generator: rewards.Generator}
+	for _, reward := range rewards.Provisioners {
+		greedy.Generator += reward.Amount
+	}
+	blk.Txs[0] = coinbaseFor(t, greedy)
+	assert.Error(t, verifiers.VerifyCoinbase(*p, *prevBlock, *blk, block.Activation{}))
+
+	// Tampered provisioner amount
+	tampered := rewards
+	tampered.Provisioners = append([]verifiers.ProvisionerReward{}, rewards.Provisioners...)
+	tampered.Provisioners[0].Amount++
+	tampered.Generator--
+	blk.Txs[0] = coinbaseFor(t, tampered)
+	assert.Error(t, verifiers.VerifyCoinbase(*p, *prevBlock, *blk, block.Activation{}))
+
+	// Reward sent to the wrong address
+	misdirected := rewards
+	misdirected.Provisioners = append([]verifiers.ProvisionerReward{}, rewards.Provisioners...)
+	misdirected.Provisioners[0].Address = key.NewKeyPair([]byte("not a provisioner")).PublicKey()
+	blk.Txs[0] = coinbaseFor(t, misdirected)
+	assert.Error(t, verifiers.VerifyCoinbase(*p, *prevBlock, *blk, block.Activation{}))
+
+	// The blocks below the activation of the reward split only reward the
+	// generator, with the fixed reward
+	act := block.Activation{RewardSplitHeight: 12}
+	blk.Header.Version = act.VersionAt(blk.Header.Height)
+	blk.Txs[0] = coinbaseFor(t, rewards)
+	assert.Error(t, verifiers.VerifyCoinbase(*p, *prevBlock, *blk, act))
+	blk.Txs[0] = coinbaseFor(t, verifiers.Rewards{Generator: config.GeneratorReward})
+	assert.NoError(t, verifiers.VerifyCoinbase(*p, *prevBlock, *blk, act))
+}
+
+// Test that the generator gets the whole amount when the previous block
+// carries no certificate.
+func TestBlockRewardsNoCertificate(t *testing.T) {
+	p, _, _ := rewardedProvisioners(4)
+	prevBlock := helper.RandomBlock(t, 1, 1)
+
+	rewards := verifiers.BlockRewards(*p, *prevBlock, 100, block.Activation{})
+	assert.Empty(t, rewards.Provisioners)
+	assert.Equal(t, config.GeneratorReward+100, rewards.Generator)
+}
+
+// rewardedProvisioners mocks `amount` provisioners, all but the last with a
+// reward address. It returns the wallet keys, indexed by BLS public key
+func rewardedProvisioners(amount int) (*user.Provisioners, []consensuskey.Keys, map[string]*key.Key) {
+	p, keys := consensus.MockProvisioners(amount)
+	wallets := make(map[string]*key.Key)
+	for i := 0; i < amount-1; i++ {
+		wallet := key.NewKeyPair(keys[i].BLSPubKeyBytes)
+		p.GetMember(keys[i].BLSPubKeyBytes).RewardAddress = wallet.PublicKey().Bytes()
+		wallets[string(keys[i].BLSPubKeyBytes)] = wallet
+	}
+
+	return p, keys, wallets
+}
+
+// certifiedBlock creates a block at `height`, with a certificate voted by the
+// provisioners
+func certifiedBlock(t *testing.T, height uint64, p *user.Provisioners, keys []consensuskey.Keys) *block.Block {
+	blk := helper.RandomBlock(t, height, 1)
+	blk.Header.Version = block.CurrentVersion
+
+	votes := message.GenVotes(blk.Header.Hash, height, 3, keys, p)
+	blk.Header.Certificate = &block.Certificate{
+		StepOneBatchedSig: votes[0].Signature.Compress(),
+		StepTwoBatchedSig: votes[1].Signature.Compress(),
+		Step:              3,
+		StepOneCommittee:  votes[0].BitSet,
+		StepTwoCommittee:  votes[1].BitSet,
+	}
+
+	return blk
+}
+
+// coinbaseFor creates a coinbase distributing `rewards`
+func coinbaseFor(t *testing.T, rewards verifiers.Rewards) *transactions.Coinbase {
+	cb := transactions.NewCoinbase(make([]byte, 30), make([]byte, 32), 2)
+	generator := key.NewKeyPair([]byte("generator"))
+	assert.NoError(t, cb.AddReward(*generator.PublicKey(), scalar(rewards.Generator)))
+
+	for _, reward := range rewards.Provisioners {
+		assert.NoError(t, cb.AddProvisionerReward(*reward.Address, scalar(reward.Amount)))
+	}
+
+	// Sanity check the stealth addresses are distinct
+	for i := 1; i < len(cb.Rewards); i++ {
+		assert.False(t, bytes.Equal(cb.Rewards[i-1].PubKey.P.Bytes(), cb.Rewards[i].PubKey.P.Bytes()))
+	}
+
+	return cb
+}
+
+func scalar(amount uint64) ristretto.Scalar {
+	var s ristretto.Scalar
+	s.SetBigInt(new(big.Int).SetUint64(amount))
+	return s
+}
@@ -1,28 +1,38 @@
 package verifiers
 
 import (
+	"bytes"
 	"fmt"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/msg"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/key"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/transactions"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/pkg/errors"
 )
 
+// ErrTimelockNotMatured is returned for a Timelock which cannot be included in
+// a block with the given height and timestamp, but may be in a later one
+var ErrTimelockNotMatured = errors.New("timelock not matured")
+
 // CheckTx will verify whether a transaction is valid by checking:
 // - It has not been double spent
 // - It is not malformed
+// - Its lock, if any, has been met
 // Index indicates the position that the transaction is in, in a block
 // If it is a solo transaction, this is set to 0
-// blockTime indicates what time the transaction will be included in a block
+// blockHeight and blockTime indicate the height and the time of the block the
+// transaction will be included in
 // If it is a solo transaction, the blockTime is calculated by using currentBlockTime+consensusSeconds
 // Returns nil if a tx is valid
-func CheckTx(db database.DB, index uint64, blockTime uint64, tx transactions.Transaction) error {
+func CheckTx(db database.DB, index uint64, blockHeight uint64, blockTime uint64, tx transactions.Transaction) error {
 	if err := CheckStandardTx(db, tx.StandardTx()); err != nil && tx.Type() != transactions.CoinbaseType {
 		return err
 	}
 
-	if err := CheckSpecialFields(index, blockTime, tx); err != nil {
+	if err := CheckSpecialFields(index, blockHeight, blockTime, tx); err != nil {
 		return err
 	}
 
@@ -32,13 +42,33 @@ func CheckTx(db database.DB, index uint64, blockTime uint64, tx transactions.Tra
 // CheckStandardTx checks whether the standard fields are correct against the
 // passed blockchain db. These checks are both stateless and stateful.
 func CheckStandardTx(db database.DB, tx *transactions.Standard) error {
-	// Version -- currently we only accept Version 0
-	if tx.Version != 0 {
+	if err := checkStandardFields(tx); err != nil {
+		return err
+	}
+
+	// Inputs - should be unlocked
+	if err := checkInputsLocked(db, tx.Inputs); err != nil {
+		return err
+	}
+
+	// KeyImage - should not be present in the database
+	if err := checkTXDoubleSpent(db, tx.Inputs); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// checkStandardFields runs the stateless checks on the standard fields
+func checkStandardFields(tx *transactions.Standard) error {
+	// Version -- currently we only accept Version 0, and the Stakes up to
+	// the version carrying a reward address
+	if tx.Version != 0 && (tx.TxType != transactions.StakeType || tx.Version > transactions.StakeRewardVersion) {
 		return errors.New("invalid transaction version")
 	}
 
-	// Type - currently we only have five types
-	if tx.TxType > 5 {
+	// Type - currently we only have seven types
+	if tx.TxType > transactions.SlashType {
 		return errors.New("invalid transaction type")
 	}
 
@@ -56,11 +86,6 @@ func CheckStandardTx(db database.DB, tx *transactions.Standard) error {
 		return errors.New("there are duplicate key images in this transaction")
 	}
 
-	// Inputs - should be unlocked
-	if err := checkInputsLocked(db, tx.Inputs); err != nil {
-		return err
-	}
-
 	// Outputs - must contain atleast one
 	if len(tx.Outputs) == 0 {
 		return errors.New("transaction must contain atleast one output")
@@ -94,25 +119,25 @@ func CheckStandardTx(db database.DB, tx *transactions.Standard) error {
 	// if err := checkRangeProof(rp); err != nil {
 	// 	return err
 	// }
-	// KeyImage - should not be present in the database
-	if err := checkTXDoubleSpent(db, tx.Inputs); err != nil {
-		return err
-	}
 
 	return nil
 }
 
 // CheckSpecialFields TBD
-func CheckSpecialFields(txIndex uint64, blockTime uint64, tx transactions.Transaction) error {
+func CheckSpecialFields(txIndex uint64, blockHeight uint64, blockTime uint64, tx transactions.Transaction) error {
 	switch x := tx.(type) {
 	case *transactions.Timelock:
-		return VerifyTimelock(txIndex, blockTime, x)
+		return VerifyTimelock(txIndex, blockHeight, blockTime, x)
 	case *transactions.Bid:
 		return VerifyBid(txIndex, blockTime, x)
 	case *transactions.Coinbase:
-		return VerifyCoinbase(txIndex, x)
+		return checkCoinbase(txIndex, x)
 	case *transactions.Stake:
 		return VerifyStake(txIndex, blockTime, x)
+	case *transactions.Unstake:
+		return VerifyUnstake(x)
+	case *transactions.Slash:
+		return VerifySlash(blockHeight, x)
 	case *transactions.Standard:
 		return VerifyStandard(x)
 	default:
@@ -125,19 +150,15 @@ func VerifyStandard(tx *transactions.Standard) error {
 	return nil
 }
 
-// VerifyCoinbase transaction
-func VerifyCoinbase(txIndex uint64, tx *transactions.Coinbase) error {
+// checkCoinbase runs the stateless checks on a coinbase transaction. The
+// distribution of the rewards is checked by VerifyCoinbase
+func checkCoinbase(txIndex uint64, tx *transactions.Coinbase) error {
 	if txIndex != 0 {
 		return errors.New("coinbase transaction is not in the first position")
 	}
 
-	if len(tx.Rewards) != 1 {
-		return fmt.Errorf("coinbase transaction must include 1 reward output")
-	}
-
-	// Ensure the reward is the fixed one
-	if tx.Rewards[0].EncryptedAmount.BigInt().Uint64() != config.GeneratorReward {
-		return fmt.Errorf("coinbase transaction must include a fixed reward of %d", config.GeneratorReward)
+	if len(tx.Rewards) == 0 {
+		return errors.New("coinbase transaction must include the generator reward")
 	}
 
 	return nil
@@ -145,7 +166,7 @@ func VerifyCoinbase(txIndex uint64, tx *transactions.Coinbase) error {
 
 // VerifyBid tx
 func VerifyBid(index uint64, blockTime uint64, tx *transactions.Bid) error {
-	if err := checkLockTimeValid(tx.Lock, blockTime); err != nil {
+	if err := checkLockTimeValid(tx.Lock); err != nil {
 		return err
 	}
 	return nil
@@ -153,23 +174,84 @@ func VerifyBid(index uint64, blockTime uint64, tx *transactions.Bid) error {
 
 //VerifyStake tx
 func VerifyStake(index uint64, blockTime uint64, tx *transactions.Stake) error {
-	if err := checkLockTimeValid(tx.Lock, blockTime); err != nil {
+	if err := checkLockTimeValid(tx.Lock); err != nil {
 		return err
 	}
+
+	if tx.Version >= transactions.StakeRewardVersion {
+		if _, err := key.PublicKeyFromBytes(tx.RewardAddress); err != nil {
+			return fmt.Errorf("invalid reward address: %v", err)
+		}
+	}
 	return nil
 }
 
-// VerifyTimelock tx
-func VerifyTimelock(index uint64, blockTime uint64, tx *transactions.Timelock) error {
-	if err := checkLockTimeValid(tx.Lock, blockTime); err != nil {
+// VerifyUnstake checks that the transaction is signed by the provisioner
+// whose stakes it is ending
+func VerifyUnstake(tx *transactions.Unstake) error {
+	if len(tx.PubKeyBLS) != 129 {
+		return fmt.Errorf("public key is %v bytes long instead of 129", len(tx.PubKeyBLS))
+	}
+
+	txid, err := tx.CalculateHash()
+	if err != nil {
 		return err
 	}
+
+	// the crypto package mutates the signature when decompressing it
+	sig := make([]byte, len(tx.Signature))
+	copy(sig, tx.Signature)
+	if err := msg.VerifyBLSSignature(tx.PubKeyBLS, txid, sig); err != nil {
+		return errors.Wrap(err, "invalid unstake signature")
+	}
+
+	return nil
+}
+
+// VerifySlash checks that the transaction carries a valid equivocation
+// evidence. Evidence older than MaxLockTime blocks is rejected, since every
+// stake active at the time of the offence has expired by then
+func VerifySlash(blockHeight uint64, tx *transactions.Slash) error {
+	e := message.Evidence{}
+	if err := message.UnmarshalEvidence(bytes.NewBuffer(tx.Evidence), &e); err != nil {
+		return errors.Wrap(err, "malformed slash evidence")
+	}
+
+	if e.First.Header.Round+transactions.MaxLockTime < blockHeight {
+		return errors.New("slash evidence expired")
+	}
+
+	if err := e.Verify(); err != nil {
+		return errors.Wrap(err, "invalid slash evidence")
+	}
+
+	return nil
+}
+
+// VerifyTimelock tx. A height-based lock must be met by blockHeight, while a
+// timestamp-based lock must be met by blockTime. A lock which is not met yet
+// yields ErrTimelockNotMatured.
+func VerifyTimelock(index uint64, blockHeight uint64, blockTime uint64, tx *transactions.Timelock) error {
+	// A height-based lock can not be further than MaxLockTime blocks ahead
+	if !tx.IsTimestampLock() && tx.Lock > blockHeight {
+		if err := checkLockTimeValid(tx.Lock - blockHeight); err != nil {
+			return err
+		}
+	}
+
+	// A timestamp-based lock can not be further than MaxLockDuration ahead
+	if tx.IsTimestampLock() && tx.Lock > blockTime && tx.Lock-blockTime > transactions.MaxLockDuration {
+		return errors.New("timelock greater than MaxLockDuration")
+	}
+
+	if !tx.Matured(blockHeight, blockTime) {
+		return ErrTimelockNotMatured
+	}
 	return nil
 }
 
-// TODO: use the blockTime to verify the lockTime
-//nolint:unparam
-func checkLockTimeValid(lockTime, blockTime uint64) error {
+// checkLockTimeValid checks a lock time, relative to the block height
+func checkLockTimeValid(lockTime uint64) error {
 	if lockTime > transactions.MaxLockTime {
 		return errors.New("timelock greater than MaxTimeLock")
 	}
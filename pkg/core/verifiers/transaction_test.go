@@ -11,6 +11,7 @@ import (
 
 	"github.com/bwesterb/go-ristretto"
 	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	consensuskey "github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	walletdb "github.com/dusk-network/dusk-blockchain/pkg/core/data/database"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/key"
@@ -18,7 +19,10 @@ import (
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/wallet"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database/heavy"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/tests/helper"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/verifiers"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	crypto "github.com/dusk-network/dusk-crypto/hash"
 	"github.com/dusk-network/dusk-crypto/mlsag"
 	"github.com/stretchr/testify/assert"
 )
@@ -77,9 +81,7 @@ func TestLockedInputs(t *testing.T) {
 	assert.NoError(t, err)
 
 	// Unlock the output for the wallet, so we can use it in the next tx
-	privSpend, err := alice.PrivateSpend()
-	assert.NoError(t, err)
-	aliceDB.UpdateLockedInputs(privSpend, 10001)
+	assert.NoError(t, aliceDB.UpdateLockedInputs(10001))
 
 	// Now, set our FetchInputs function to get inputs from the db
 	alice, err = wallet.LoadFromFile(2, aliceDB, fetchDecoys, fetchInputs, "pass", "alice.dat")
@@ -94,7 +96,75 @@ func TestLockedInputs(t *testing.T) {
 	assert.NoError(t, err)
 
 	// Checking the tx should fail with a specific error
-	assert.Equal(t, "transaction contains one or more locked inputs", verifiers.CheckTx(db, 0, uint64(time.Now().Unix()), tx).Error())
+	assert.Equal(t, "transaction contains one or more locked inputs", verifiers.CheckTx(db, 0, 2, uint64(time.Now().Unix()), tx).Error())
+}
+
+// Test that a Timelock is valid only once its lock is met.
+func TestVerifyTimelock(t *testing.T) {
+	assert := assert.New(t)
+
+	// Height-based lock
+	tx, err := transactions.NewTimelock(0, 2, 100, 10)
+	assert.NoError(err)
+	assert.Equal(verifiers.ErrTimelockNotMatured, verifiers.VerifyTimelock(0, 9, uint64(time.Now().Unix()), tx))
+	assert.NoError(verifiers.VerifyTimelock(0, 10, 0, tx))
+	assert.NoError(verifiers.VerifyTimelock(0, 11, 0, tx))
+
+	// A height-based lock too far ahead is invalid
+	tx.Lock = transactions.MaxLockTime + 2
+	assert.EqualError(verifiers.VerifyTimelock(0, 1, 0, tx), "timelock greater than MaxTimeLock")
+
+	// Timestamp-based lock
+	tx.Lock = transactions.LockTimeThreshold + 1000
+	assert.Equal(verifiers.ErrTimelockNotMatured, verifiers.VerifyTimelock(0, 10, transactions.LockTimeThreshold+999, tx))
+	assert.NoError(verifiers.VerifyTimelock(0, 10, transactions.LockTimeThreshold+1000, tx))
+
+	// A timestamp-based lock too far ahead is invalid
+	tx.Lock = transactions.LockTimeThreshold + transactions.MaxLockDuration + 1
+	assert.EqualError(verifiers.VerifyTimelock(0, 10, transactions.LockTimeThreshold, tx), "timelock greater than MaxLockDuration")
+	assert.Equal(verifiers.ErrTimelockNotMatured, verifiers.VerifyTimelock(0, 10, transactions.LockTimeThreshold+1, tx))
+
+	// Outputs are spendable once the lock is met
+	tx.Lock = 10
+	assert.Equal(uint64(10), transactions.UnlockHeight(tx, 5))
+	assert.Equal(uint64(12), transactions.UnlockHeight(tx, 12))
+}
+
+// Test that an Unstake is valid only if signed by the provisioner it refers to.
+func TestVerifyUnstake(t *testing.T) {
+	keys, _ := consensuskey.NewRandKeys()
+	tx := helper.RandomUnstakeTx(t, keys)
+	assert.NoError(t, verifiers.VerifyUnstake(tx))
+
+	// signed by someone else
+	other, _ := consensuskey.NewRandKeys()
+	assert.NoError(t, tx.Sign(other.BLSSecretKey, other.BLSPubKey))
+	assert.Error(t, verifiers.VerifyUnstake(tx))
+
+	// malformed public key
+	tx.PubKeyBLS = tx.PubKeyBLS[:32]
+	assert.Error(t, verifiers.VerifyUnstake(tx))
+}
+
+// Test that a Slash is valid only if it carries a valid and recent evidence.
+func TestVerifySlash(t *testing.T) {
+	keys, _ := consensuskey.NewRandKeys()
+	hash1, _ := crypto.RandEntropy(32)
+	hash2, _ := crypto.RandEntropy(32)
+
+	tx := helper.RandomSlashTx(t, message.MockEvidence(hash1, hash2, 10, 1, []consensuskey.Keys{keys}))
+	assert.NoError(t, verifiers.VerifySlash(11, tx))
+
+	// evidence too old
+	assert.EqualError(t, verifiers.VerifySlash(11+transactions.MaxLockTime, tx), "slash evidence expired")
+
+	// votes for the same hash are no equivocation
+	tx = helper.RandomSlashTx(t, message.MockEvidence(hash1, hash1, 10, 1, []consensuskey.Keys{keys}))
+	assert.Error(t, verifiers.VerifySlash(11, tx))
+
+	// garbage evidence
+	tx.Evidence = []byte{1, 2, 3}
+	assert.Error(t, verifiers.VerifySlash(11, tx))
 }
 
 // Write a block with one transaction to the db.
@@ -140,12 +210,8 @@ func fetchDecoys(numMixins int) []mlsag.PubKeys {
 	return pubKeys
 }
 
-func fetchInputs(netPrefix byte, db *walletdb.DB, totalAmount int64, key *key.Key) ([]*transactions.Input, int64, error) {
+func fetchInputs(netPrefix byte, db *walletdb.DB, totalAmount int64, _ *key.Key) ([]*transactions.Input, int64, error) {
 	// Fetch all inputs from database that are >= totalAmount
 	// returns error if inputs do not add up to total amount
-	privSpend, err := key.PrivateSpend()
-	if err != nil {
-		return nil, 0, err
-	}
-	return db.FetchInputs(privSpend.Bytes(), totalAmount)
+	return db.FetchInputs(totalAmount)
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/didip/tollbooth"
 	"github.com/didip/tollbooth/limiter"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/txevent"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database/heavy"
 	"github.com/dusk-network/dusk-blockchain/pkg/gql/notifications"
@@ -24,8 +25,9 @@ import (
 var log = logger.WithFields(logger.Fields{"prefix": "gql"})
 
 const (
-	endpointWS  = "/ws"
-	endpointGQL = "/graphql"
+	endpointWS    = "/ws"
+	endpointWSTxs = "/ws/txs"
+	endpointGQL   = "/graphql"
 )
 
 // Server defines the HTTP server of the GraphQL service node.
@@ -192,6 +194,31 @@ func (s *Server) EnableNotifications(serverMux *http.ServeMux) error {
 	middleware := tollbooth.LimitFuncHandler(s.lmt, wsHandler)
 	serverMux.Handle(endpointWS, middleware)
 
+	// Tx lifecycle events, optionally filtered by one or more txid query
+	// params (e.g /ws/txs?txid=<hex>&txid=<hex>)
+	wsTxsHandler := func(w http.ResponseWriter, r *http.Request) {
+
+		if !s.started {
+			return
+		}
+
+		filter, err := txevent.NewFilter(r.URL.Query()["txid"])
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			log.Errorf("Failed to set websocket upgrade: %v", err)
+			return
+		}
+		s.pool.PushTxConn(conn, filter)
+	}
+
+	txsMiddleware := tollbooth.LimitFuncHandler(s.lmt, wsTxsHandler)
+	serverMux.Handle(endpointWSTxs, txsMiddleware)
+
 	return nil
 }
 
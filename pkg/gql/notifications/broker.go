@@ -7,6 +7,8 @@ import (
 
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/txevent"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
 	logger "github.com/sirupsen/logrus"
@@ -18,6 +20,9 @@ const (
 	writeDeadline = 3 * time.Second
 
 	maxTxsPerMsg = 15
+
+	// size of the queue of tx lifecycle events pending to be broadcast
+	txEventsQueueSize = 1000
 )
 
 var log = logger.WithField("process", "broker")
@@ -45,6 +50,8 @@ type Broker struct {
 	eventBus          eventbus.Broker
 	acceptedBlockChan chan block.Block
 	acceptedBlockID   uint32
+	txEventChan       chan message.Message
+	txEventID         uint32
 }
 
 // NewBroker creates a new Broker instance
@@ -54,6 +61,11 @@ func NewBroker(id uint, eventBus eventbus.Broker, maxClientsCount uint, connChan
 	b.eventBus = eventBus
 	b.ConnectionChan = connChan
 	b.acceptedBlockChan, b.acceptedBlockID = consensus.InitAcceptedBlockUpdate(eventBus)
+
+	// tx events are dropped if the queue is full, so that the broker does not
+	// block the publishers
+	b.txEventChan = make(chan message.Message, txEventsQueueSize)
+	b.txEventID = eventBus.Subscribe(topics.TxEvent, eventbus.NewChanListener(b.txEventChan))
 	b.clients = list.New()
 	b.maxClientsCount = maxClientsCount
 	b.id = id
@@ -74,6 +86,7 @@ func (b *Broker) Run() {
 
 		// Unsubscribe from all eventBus events
 		b.eventBus.Unsubscribe(topics.AcceptedBlock, b.acceptedBlockID)
+		b.eventBus.Unsubscribe(topics.TxEvent, b.txEventID)
 
 		// Terminate all clients goroutines
 		for e := b.clients.Front(); e != nil; e = e.Next() {
@@ -99,6 +112,9 @@ func (b *Broker) Run() {
 		// new accepted block from node
 		case blk := <-b.acceptedBlockChan:
 			b.handleBlock(blk)
+		// new tx lifecycle event from node
+		case m := <-b.txEventChan:
+			b.handleTxEvent(m.Payload().(txevent.Event))
 		case <-time.After(30 * time.Second):
 			b.handleIdle()
 		}
@@ -125,6 +141,32 @@ func (b *Broker) handleBlock(blk block.Block) {
 	b.broadcastMessage(msg)
 }
 
+// handleTxEvent handles the topics.TxEvent event emitted from node layer. It
+// packs a json from the event and sends it to the clients subscribed for it
+func (b *Broker) handleTxEvent(e txevent.Event) {
+
+	defer func() {
+		if r := recover(); r != nil {
+			log.Errorf("handleTxEvent recovered from err: %v", r)
+		}
+	}()
+
+	b.reap()
+
+	msg, err := MarshalTxEventMsg(e)
+	if err != nil {
+		log.Errorf("encoding err: %v", err)
+		return
+	}
+
+	for el := b.clients.Front(); el != nil; el = el.Next() {
+		c := el.Value.(*wsClient)
+		if c.txEvents && c.txFilter.Match(e) {
+			c.msgChan <- []byte(msg)
+		}
+	}
+}
+
 // handleConn handles a new websocket conn pushed from webserver layer It stores
 // the conn to list of active clients
 func (b *Broker) handleConn(conn wsConn) {
@@ -155,6 +197,12 @@ func (b *Broker) handleConn(conn wsConn) {
 		id:      conn.RemoteAddr().String(),
 	}
 
+	if tc, ok := conn.(txConn); ok {
+		c.conn = tc.wsConn
+		c.txEvents = true
+		c.txFilter = tc.filter
+	}
+
 	_ = b.clients.PushBack(c)
 
 	// Start a writer-goroutine dedicated for websocket conn. All messages to a
@@ -185,7 +233,9 @@ func (b *Broker) broadcastMessage(data string) {
 
 	for e := b.clients.Front(); e != nil; e = e.Next() {
 		c := e.Value.(*wsClient)
-		c.msgChan <- []byte(data)
+		if !c.txEvents {
+			c.msgChan <- []byte(data)
+		}
 	}
 }
 
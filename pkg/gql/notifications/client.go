@@ -4,6 +4,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/txevent"
 	"github.com/gorilla/websocket"
 )
 
@@ -14,6 +15,11 @@ type wsClient struct {
 	msgChan chan []byte
 	id      string
 
+	// txEvents is set if the client is subscribed for tx lifecycle events
+	// instead of accepted blocks. Only the events passing txFilter are sent.
+	txEvents bool
+	txFilter txevent.Filter
+
 	closed int32
 }
 
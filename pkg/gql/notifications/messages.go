@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/txevent"
 )
 
 // BlockMsg represents the data need by Explorer UI on each new block accepted
@@ -53,3 +54,29 @@ func MarshalBlockMsg(blk block.Block) (string, error) {
 
 	return string(msg), nil
 }
+
+// TxEventMsg represents a change in the lifecycle of a tx
+type TxEventMsg struct {
+	TxID   string
+	Status string
+	Reason string `json:",omitempty"`
+	Height uint64 `json:",omitempty"`
+}
+
+// MarshalTxEventMsg builds the JSON from a tx lifecycle event
+func MarshalTxEventMsg(e txevent.Event) (string, error) {
+
+	p := TxEventMsg{
+		TxID:   hex.EncodeToString(e.TxID),
+		Status: e.Status.String(),
+		Reason: e.Reason,
+		Height: e.Height,
+	}
+
+	msg, err := json.Marshal(p)
+	if err != nil {
+		return "", err
+	}
+
+	return string(msg), nil
+}
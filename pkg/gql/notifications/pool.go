@@ -5,6 +5,7 @@ import (
 	"net"
 	"time"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/txevent"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
 	"github.com/gorilla/websocket"
 )
@@ -20,6 +21,13 @@ type wsConn interface {
 	Close() error
 }
 
+// txConn is a websocket connection subscribed for the lifecycle events of the
+// txs passing the filter
+type txConn struct {
+	wsConn
+	filter txevent.Filter
+}
+
 // BrokerPool is a set of broker workers to provide a simple load balancing.
 // Running multiple broker workers also could provide failover
 type BrokerPool struct {
@@ -69,6 +77,21 @@ func (bp *BrokerPool) PushConn(conn *websocket.Conn) {
 	}
 }
 
+// PushTxConn pushes a websocket connection subscribed for tx lifecycle events
+// to the broker pool. If all brokers are busy the connection gets discarded
+func (bp *BrokerPool) PushTxConn(conn *websocket.Conn, filter txevent.Filter) {
+
+	if conn == nil {
+		return
+	}
+
+	select {
+	case bp.ConnectionsChan <- txConn{wsConn: conn, filter: filter}:
+	default:
+		log.Errorf("Queue is full. Discarding connection from %s", conn.RemoteAddr().String())
+	}
+}
+
 // Close the BrokerPool by closing the underlying connection channel
 func (bp *BrokerPool) Close() {
 
@@ -10,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/txevent"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/tests/helper"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
@@ -115,3 +116,72 @@ func TestPoolBasicScenario(t *testing.T) {
 		t.Fatal("invalid test context")
 	}
 }
+
+// mockTxEventConn records the tx events received by a websocket conn
+type mockTxEventConn struct {
+	mockWebsocketConn
+	events []TxEventMsg
+}
+
+func (c *mockTxEventConn) WriteMessage(messageType int, data []byte) error {
+
+	var p TxEventMsg
+	if err := json.Unmarshal(data, &p); err == nil {
+		c.mu.Lock()
+		c.events = append(c.events, p)
+		c.mu.Unlock()
+	}
+
+	return nil
+}
+
+func TestPoolTxEvents(t *testing.T) {
+
+	eb := eventbus.New()
+	pool := NewPool(eb, 1, 10)
+	defer pool.Close()
+
+	watched := make([]byte, 32)
+	watched[0] = 1
+	other := make([]byte, 32)
+	other[0] = 2
+
+	filter, err := txevent.NewFilter([]string{hex.EncodeToString(watched)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A client watching a single tx and a client watching all txs
+	filteredConn := &mockTxEventConn{}
+	allConn := &mockTxEventConn{}
+	pool.ConnectionsChan <- txConn{wsConn: filteredConn, filter: filter}
+	pool.ConnectionsChan <- txConn{wsConn: allConn}
+
+	time.Sleep(1 * time.Second)
+
+	txevent.Publish(eb, txevent.Event{TxID: watched, Status: txevent.Accepted})
+	txevent.Publish(eb, txevent.Event{TxID: other, Status: txevent.Rejected, Reason: "invalid"})
+	txevent.Publish(eb, txevent.Event{TxID: watched, Status: txevent.Included, Height: 5})
+
+	time.Sleep(1 * time.Second)
+
+	filteredConn.mu.RLock()
+	defer filteredConn.mu.RUnlock()
+	if len(filteredConn.events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(filteredConn.events))
+	}
+
+	if filteredConn.events[0].Status != "accepted" || filteredConn.events[1].Status != "included" || filteredConn.events[1].Height != 5 {
+		t.Fatalf("unexpected events %v", filteredConn.events)
+	}
+
+	allConn.mu.RLock()
+	defer allConn.mu.RUnlock()
+	if len(allConn.events) != 3 {
+		t.Fatalf("expected 3 events, got %d", len(allConn.events))
+	}
+
+	if allConn.events[1].Reason != "invalid" {
+		t.Fatalf("expected a rejection reason, got %q", allConn.events[1].Reason)
+	}
+}
@@ -0,0 +1,129 @@
+package query
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/graphql-go/graphql"
+)
+
+const (
+	evidenceOffenderArg = "offender"
+	evidenceRoundArg    = "round"
+)
+
+// queryEvidence is a data-wrapper for all message.Evidence relevant fields
+// that can be fetched via graphql
+type queryEvidence struct {
+	ID        []byte
+	Offender  []byte
+	Round     uint64
+	Step      uint8
+	VoteTopic string
+	Votes     []queryVote
+}
+
+type queryVote struct {
+	BlockHash []byte
+	Signature []byte
+}
+
+type evidence struct {
+}
+
+func newQueryEvidence(e message.Evidence) (queryEvidence, error) {
+	id, err := e.ID()
+	if err != nil {
+		return queryEvidence{}, err
+	}
+
+	votes := make([]queryVote, 0, 2)
+	for _, v := range []message.Vote{e.First, e.Second} {
+		votes = append(votes, queryVote{
+			BlockHash: v.Header.BlockHash,
+			Signature: v.Signature,
+		})
+	}
+
+	return queryEvidence{
+		ID:        id,
+		Offender:  e.Offender(),
+		Round:     e.First.Header.Round,
+		Step:      e.First.Header.Step,
+		VoteTopic: e.VoteTopic.String(),
+		Votes:     votes,
+	}, nil
+}
+
+func (e evidence) getQuery() *graphql.Field {
+	return &graphql.Field{
+		Type: graphql.NewList(Evidence),
+		Args: graphql.FieldConfigArgument{
+			evidenceOffenderArg: &graphql.ArgumentConfig{
+				Type: graphql.String,
+			},
+			evidenceRoundArg: &graphql.ArgumentConfig{
+				Type: graphql.Int,
+			},
+		},
+		Resolve: e.resolve,
+	}
+}
+
+func (e evidence) resolve(p graphql.ResolveParams) (interface{}, error) {
+
+	// Retrieve DB conn from context
+	db, ok := p.Context.Value("database").(database.DB)
+	if !ok {
+		return nil, errors.New("context does not store database conn")
+	}
+
+	var offender []byte
+	if o, ok := p.Args[evidenceOffenderArg].(string); ok {
+		var err error
+		offender, err = hex.DecodeString(o)
+		if err != nil {
+			return nil, errors.New("invalid offender")
+		}
+	}
+
+	round, filterRound := p.Args[evidenceRoundArg].(int)
+
+	var stored [][]byte
+	err := db.View(func(t database.Transaction) error {
+		var err error
+		stored, err = t.FetchEvidence()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]queryEvidence, 0)
+	for _, s := range stored {
+		ev := message.Evidence{}
+		if err := message.UnmarshalEvidence(bytes.NewBuffer(s), &ev); err != nil {
+			return nil, err
+		}
+
+		if offender != nil && !bytes.Equal(offender, ev.Offender()) {
+			continue
+		}
+
+		if filterRound && uint64(round) != ev.First.Header.Round {
+			continue
+		}
+
+		q, err := newQueryEvidence(ev)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, q)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,79 @@
+package query
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	crypto "github.com/dusk-network/dusk-crypto/hash"
+)
+
+func TestEvidenceByOffender(t *testing.T) {
+	k, _ := key.NewRandKeys()
+	hash1, _ := crypto.RandEntropy(32)
+	hash2, _ := crypto.RandEntropy(32)
+	e := message.MockEvidence(hash1, hash2, 7, 3, []key.Keys{k})
+	id, _ := e.ID()
+
+	buf := new(bytes.Buffer)
+	if err := message.MarshalEvidence(buf, e); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := db.Update(func(t database.Transaction) error {
+		return t.StoreEvidence(id, buf.Bytes())
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	query := fmt.Sprintf(`
+		{
+		  evidence(offender: "%s", round: 7) {
+			id
+			round
+			step
+			votetopic
+			votes {
+			  blockhash
+			}
+		  }
+		}
+		`, hex.EncodeToString(k.BLSPubKeyBytes))
+
+	response := fmt.Sprintf(`
+		{
+		  "data": {
+			"evidence": [
+			  {
+				"id": "%s",
+				"round": 7,
+				"step": 3,
+				"votetopic": "reduction",
+				"votes": [
+				  { "blockhash": "%s" },
+				  { "blockhash": "%s" }
+				]
+			  }
+			]
+		  }
+		}
+	`, hex.EncodeToString(id),
+		hex.EncodeToString(e.First.Header.BlockHash),
+		hex.EncodeToString(e.Second.Header.BlockHash))
+
+	assertQuery(t, query, response)
+
+	// filtering on another round should not return anything
+	query = fmt.Sprintf(`
+		{
+		  evidence(offender: "%s", round: 8) {
+			id
+		  }
+		}
+		`, hex.EncodeToString(k.BLSPubKeyBytes))
+	assertQuery(t, query, `{ "data": { "evidence": [] } }`)
+}
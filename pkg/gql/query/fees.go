@@ -0,0 +1,63 @@
+package query
+
+import (
+	"errors"
+	"time"
+
+	mp "github.com/dusk-network/dusk-blockchain/pkg/core/mempool"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
+	"github.com/graphql-go/graphql"
+)
+
+const feeTargetArg = "target"
+
+// queryFeeEstimate is a data-wrapper for all mempool.FeeEstimate relevant
+// fields that can be fetched via graphql
+type queryFeeEstimate struct {
+	FeeRate      uint64
+	TargetBlocks uint64
+	MinFee       uint64
+	Blocks       int
+}
+
+type fees struct {
+	rpcBus *rpcbus.RPCBus
+}
+
+func (f fees) getQuery() *graphql.Field {
+	return &graphql.Field{
+		Type: FeeEstimate,
+		Args: graphql.FieldConfigArgument{
+			feeTargetArg: &graphql.ArgumentConfig{
+				Type:         graphql.Int,
+				DefaultValue: 1,
+			},
+		},
+		Resolve: f.resolve,
+	}
+}
+
+func (f fees) resolve(p graphql.ResolveParams) (interface{}, error) {
+	target, ok := p.Args[feeTargetArg].(int)
+	if !ok || target < 1 || target > mp.MaxFeeTarget {
+		return nil, mp.ErrInvalidFeeTarget
+	}
+
+	if f.rpcBus == nil {
+		return nil, errors.New("fee estimation is not available")
+	}
+
+	resp, err := f.rpcBus.Call(topics.EstimateFee, rpcbus.NewRequest(uint64(target)), 5*time.Second)
+	if err != nil {
+		return nil, err
+	}
+
+	estimate := resp.(mp.FeeEstimate)
+	return queryFeeEstimate{
+		FeeRate:      estimate.FeeRate,
+		TargetBlocks: estimate.TargetBlocks,
+		MinFee:       estimate.MinFee,
+		Blocks:       estimate.Blocks,
+	}, nil
+}
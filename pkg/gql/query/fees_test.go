@@ -0,0 +1,75 @@
+package query
+
+import (
+	"encoding/json"
+	"testing"
+
+	mp "github.com/dusk-network/dusk-blockchain/pkg/core/mempool"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
+	"github.com/graphql-go/graphql"
+)
+
+func TestFeeEstimate(t *testing.T) {
+	rpcBus := rpcbus.New()
+	estimateFeeChan := make(chan rpcbus.Request, 1)
+	if err := rpcBus.Register(topics.EstimateFee, estimateFeeChan); err != nil {
+		t.Fatal(err)
+	}
+
+	go func() {
+		r := <-estimateFeeChan
+		target := r.Params.(uint64)
+		r.RespChan <- rpcbus.Response{Resp: mp.FeeEstimate{FeeRate: 250, TargetBlocks: target, MinFee: 100, Blocks: 42}}
+	}()
+
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{Query: NewRoot(rpcBus).Query})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	query := `
+		{
+		  feeestimate(target: 3) {
+			feerate
+			targetblocks
+			minfee
+			blocks
+		  }
+		}
+		`
+
+	response := `
+		{
+		  "data": {
+			"feeestimate": {
+			  "feerate": 250,
+			  "targetblocks": 3,
+			  "minfee": 100,
+			  "blocks": 42
+			}
+		  }
+		}
+		`
+
+	result, err := json.Marshal(execute(query, schema, db))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	equal, err := assertJSONs(result, []byte(response))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !equal {
+		t.Errorf("expecting other response from this query: %s", result)
+	}
+}
+
+func TestFeeEstimateInvalidTarget(t *testing.T) {
+	result := execute(`{ feeestimate(target: 0) { feerate } }`, sc, db)
+	if len(result.Errors) == 0 {
+		t.Error("expecting an invalid target error")
+	}
+}
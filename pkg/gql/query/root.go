@@ -10,10 +10,12 @@ type Root struct {
 	Query *graphql.Object
 }
 
-// NewRoot returns a Root with blocks, transactions and mempool setup
+// NewRoot returns a Root with blocks, transactions, mempool, evidence and fee
+// estimate setup
 func NewRoot(rpcBus *rpcbus.RPCBus) *Root {
 
 	m := mempool{rpcBus: rpcBus}
+	f := fees{rpcBus: rpcBus}
 
 	root := Root{
 		Query: graphql.NewObject(
@@ -23,6 +25,8 @@ func NewRoot(rpcBus *rpcbus.RPCBus) *Root {
 					"blocks":       blocks{}.getQuery(),
 					"transactions": transactions{}.getQuery(),
 					"mempool":      m.getQuery(),
+					"evidence":     evidence{}.getQuery(),
+					"feeestimate":  f.getQuery(),
 				},
 			},
 		),
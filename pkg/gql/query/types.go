@@ -108,6 +108,69 @@ var Input = graphql.NewObject(
 	},
 )
 
+// Evidence is the graphql object representing an equivocation evidence
+var Evidence = graphql.NewObject(
+	graphql.ObjectConfig{
+		Name: "Evidence",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{
+				Type: Hex,
+			},
+			"offender": &graphql.Field{
+				Type: Hex,
+			},
+			"round": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"step": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"votetopic": &graphql.Field{
+				Type: graphql.String,
+			},
+			"votes": &graphql.Field{
+				Type: graphql.NewList(Vote),
+			},
+		},
+	},
+)
+
+// Vote is the graphql object representing a signed consensus vote
+var Vote = graphql.NewObject(
+	graphql.ObjectConfig{
+		Name: "Vote",
+		Fields: graphql.Fields{
+			"blockhash": &graphql.Field{
+				Type: Hex,
+			},
+			"signature": &graphql.Field{
+				Type: Hex,
+			},
+		},
+	},
+)
+
+// FeeEstimate is the graphql object representing a suggested fee rate
+var FeeEstimate = graphql.NewObject(
+	graphql.ObjectConfig{
+		Name: "FeeEstimate",
+		Fields: graphql.Fields{
+			"feerate": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"targetblocks": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"minfee": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"blocks": &graphql.Field{
+				Type: graphql.Int,
+			},
+		},
+	},
+)
+
 // Hex is the graphql object representing a hex scalar
 var Hex = graphql.NewScalar(graphql.ScalarConfig{
 	Name:        "Hex",
@@ -10,6 +10,7 @@ import (
 
 	log "github.com/sirupsen/logrus"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database/heavy"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer/dupemap"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer/processing"
@@ -117,6 +118,7 @@ func NewReader(conn net.Conn, gossip *processing.Gossip, dupeMap *dupemap.DupeMa
 			candidateBroker:   responding.NewCandidateBroker(rpcBus, responseChan),
 			ponger:            processing.NewPonger(responseChan),
 			peerInfo:          conn.RemoteAddr().String(),
+			activation:        config.Activation(),
 		},
 	}
 
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer/dupemap"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer/processing"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer/processing/chainsync"
@@ -29,11 +30,15 @@ type messageRouter struct {
 	ponger            processing.Ponger
 
 	peerInfo string
+
+	// activation selects the encoding of the messages which changed over
+	// time
+	activation block.Activation
 }
 
 func (m *messageRouter) Collect(packet []byte) error {
 	b := bytes.NewBuffer(packet)
-	msg, err := message.Unmarshal(b)
+	msg, err := message.Unmarshal(b, m.activation)
 	if err != nil {
 		return err
 	}
@@ -47,7 +52,8 @@ func (m *messageRouter) CanRoute(topic topics.Topic) bool {
 		topics.Score,
 		topics.Reduction,
 		topics.Agreement,
-		topics.RoundResults:
+		topics.RoundResults,
+		topics.Evidence:
 		return true
 	}
 
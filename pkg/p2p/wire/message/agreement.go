@@ -25,7 +25,7 @@ type (
 	// the committee for both Reduction steps
 	StepVotes struct {
 		Apk       *bls.Apk
-		BitSet    uint64
+		BitSet    sortedset.Bitset
 		Signature *bls.Signature
 		Step      uint8
 	}
@@ -46,6 +46,10 @@ type (
 		signedVotes  []byte
 		VotesPerStep []*StepVotes
 		Repr         *big.Int
+
+		// legacy is set if the StepVotes are encoded as uint64 bitmaps,
+		// as before the activation of the bitsets (see block.Activation)
+		legacy bool
 	}
 )
 
@@ -88,7 +92,7 @@ func (s StepVotes) IsEmpty() bool {
 // String representation
 func (s StepVotes) String() string {
 	var sb strings.Builder
-	_, _ = sb.WriteString(fmt.Sprintf("BitSet: %d Step: %d\n Sig: %v\n Apk: %v\n", s.BitSet, s.Step, s.Signature, s.Apk))
+	_, _ = sb.WriteString(fmt.Sprintf("BitSet: %x Step: %d\n Sig: %v\n Apk: %v\n", s.BitSet, s.Step, s.Signature, s.Apk))
 	return sb.String()
 }
 
@@ -136,13 +140,13 @@ func (a Agreement) GenerateCertificate() *block.Certificate {
 }
 
 // UnmarshalAgreementMessage unmarshal a network inbound Agreement
-func UnmarshalAgreementMessage(r *bytes.Buffer, m SerializableMessage) error {
+func UnmarshalAgreementMessage(r *bytes.Buffer, m SerializableMessage, act block.Activation) error {
 	aggro := newAgreement()
 	if err := header.Unmarshal(r, &aggro.hdr); err != nil {
 		return err
 	}
 
-	if err := UnmarshalAgreement(r, aggro); err != nil {
+	if err := UnmarshalAgreement(r, aggro, act); err != nil {
 		return err
 	}
 
@@ -154,7 +158,7 @@ func UnmarshalAgreementMessage(r *bytes.Buffer, m SerializableMessage) error {
 func NewStepVotes() *StepVotes {
 	return &StepVotes{
 		Apk:       nil,
-		BitSet:    uint64(0),
+		BitSet:    sortedset.Bitset{},
 		Signature: nil,
 		Step:      uint8(0),
 	}
@@ -197,7 +201,8 @@ func (s *StepVotes) Add(signature, sender []byte, step uint8) error {
 	return nil
 }
 
-// MarshalAgreement marshals an Agreement event into a buffer.
+// MarshalAgreement marshals an Agreement event into a buffer. The encoding of
+// the StepVotes depends on the round the Agreement was created for
 func MarshalAgreement(r *bytes.Buffer, a Agreement) error {
 	if err := header.Marshal(r, a.State()); err != nil {
 		return err
@@ -209,7 +214,7 @@ func MarshalAgreement(r *bytes.Buffer, a Agreement) error {
 	}
 
 	// Marshal VotesPerStep
-	if err := MarshalVotes(r, a.VotesPerStep); err != nil {
+	if err := MarshalVotes(r, a.VotesPerStep, !a.legacy); err != nil {
 		return err
 	}
 
@@ -220,7 +225,7 @@ func MarshalAgreement(r *bytes.Buffer, a Agreement) error {
 // Field order is the following:
 // * Header [BLS Public Key; Round; Step]
 // * Agreement [Signed Vote Set; Vote Set; BlockHash]
-func UnmarshalAgreement(r *bytes.Buffer, a *Agreement) error {
+func UnmarshalAgreement(r *bytes.Buffer, a *Agreement, act block.Activation) error {
 	signedVotes := make([]byte, 33)
 	if err := encoding.ReadBLS(r, signedVotes); err != nil {
 		return err
@@ -228,7 +233,8 @@ func UnmarshalAgreement(r *bytes.Buffer, a *Agreement) error {
 	a.SetSignature(signedVotes)
 
 	votesPerStep := make([]*StepVotes, 2)
-	if err := UnmarshalVotes(r, votesPerStep); err != nil {
+	a.legacy = !act.Bitset(a.State().Round)
+	if err := UnmarshalVotes(r, votesPerStep, !a.legacy); err != nil {
 		return err
 	}
 
@@ -237,10 +243,12 @@ func UnmarshalAgreement(r *bytes.Buffer, a *Agreement) error {
 }
 
 // NewAgreement returns an empty Agreement event. It is supposed to be used by
-// the (secondstep reducer) for creating Agreement messages
-func NewAgreement(hdr header.Header) *Agreement {
+// the (secondstep reducer) for creating Agreement messages. The activation
+// heights of the network select the encoding of its StepVotes
+func NewAgreement(hdr header.Header, act block.Activation) *Agreement {
 	aggro := newAgreement()
 	aggro.hdr = hdr
+	aggro.legacy = !act.Bitset(hdr.Round)
 	return aggro
 }
 
@@ -260,7 +268,7 @@ func newAgreement() *Agreement {
 // XXX: either use this function or delete it!! Right now it is not used
 func SignAgreement(a *Agreement, keys key.Keys) error {
 	buffer := new(bytes.Buffer)
-	if err := MarshalVotes(buffer, a.VotesPerStep); err != nil {
+	if err := MarshalVotes(buffer, a.VotesPerStep, !a.legacy); err != nil {
 		return err
 	}
 
@@ -273,8 +281,10 @@ func SignAgreement(a *Agreement, keys key.Keys) error {
 	return nil
 }
 
-// UnmarshalVotes unmarshals the array of StepVotes for a single Agreement
-func UnmarshalVotes(r *bytes.Buffer, votes []*StepVotes) error {
+// UnmarshalVotes unmarshals the array of StepVotes for a single Agreement.
+// The bitset flag tells whether the committees are encoded as variable
+// length bitsets rather than uint64 bitmaps
+func UnmarshalVotes(r *bytes.Buffer, votes []*StepVotes, bitset bool) error {
 	length, err := encoding.ReadVarInt(r)
 	if err != nil {
 		return err
@@ -287,7 +297,7 @@ func UnmarshalVotes(r *bytes.Buffer, votes []*StepVotes) error {
 	}
 
 	for i := uint64(0); i < length; i++ {
-		sv, err := UnmarshalStepVotes(r)
+		sv, err := UnmarshalStepVotes(r, bitset)
 		if err != nil {
 			return err
 		}
@@ -298,8 +308,9 @@ func UnmarshalVotes(r *bytes.Buffer, votes []*StepVotes) error {
 	return nil
 }
 
-// UnmarshalStepVotes unmarshals a single StepVote
-func UnmarshalStepVotes(r *bytes.Buffer) (*StepVotes, error) {
+// UnmarshalStepVotes unmarshals a single StepVote. The BitSet is encoded as
+// a variable length bitset if the bitset flag is set, as a uint64 otherwise
+func UnmarshalStepVotes(r *bytes.Buffer, bitset bool) (*StepVotes, error) {
 	sv := NewStepVotes()
 	// APK
 	var apk []byte
@@ -314,8 +325,18 @@ func UnmarshalStepVotes(r *bytes.Buffer) (*StepVotes, error) {
 	}
 
 	// BitSet
-	if e := encoding.ReadUint64LE(r, &sv.BitSet); e != nil {
-		return nil, e
+	if !bitset {
+		var bitSet uint64
+		if e := encoding.ReadUint64LE(r, &bitSet); e != nil {
+			return nil, e
+		}
+		sv.BitSet = sortedset.BitsetFromUint64(bitSet)
+	} else {
+		var bitSet []byte
+		if e := encoding.ReadVarBytes(r, &bitSet); e != nil {
+			return nil, e
+		}
+		sv.BitSet = bitSet
 	}
 
 	// Signature
@@ -332,14 +353,14 @@ func UnmarshalStepVotes(r *bytes.Buffer) (*StepVotes, error) {
 	return sv, nil
 }
 
-// MarshalVotes marshals an array of StepVotes
-func MarshalVotes(r *bytes.Buffer, votes []*StepVotes) error {
+// MarshalVotes marshals an array of StepVotes (see MarshalStepVotes)
+func MarshalVotes(r *bytes.Buffer, votes []*StepVotes, bitset bool) error {
 	if err := encoding.WriteVarInt(r, uint64(len(votes))); err != nil {
 		return err
 	}
 
 	for _, stepVotes := range votes {
-		if err := MarshalStepVotes(r, stepVotes); err != nil {
+		if err := MarshalStepVotes(r, stepVotes, bitset); err != nil {
 			return err
 		}
 	}
@@ -348,15 +369,25 @@ func MarshalVotes(r *bytes.Buffer, votes []*StepVotes) error {
 }
 
 // MarshalStepVotes marshals the aggregated form of the BLS PublicKey and Signature
-// for an ordered set of votes
-func MarshalStepVotes(r *bytes.Buffer, vote *StepVotes) error {
+// for an ordered set of votes. The BitSet is encoded as a variable length
+// bitset if the bitset flag is set, as a uint64 otherwise
+func MarshalStepVotes(r *bytes.Buffer, vote *StepVotes, bitset bool) error {
 	// APK
 	if err := encoding.WriteVarBytes(r, vote.Apk.Marshal()); err != nil {
 		return err
 	}
 
 	// BitSet
-	if err := encoding.WriteUint64LE(r, vote.BitSet); err != nil {
+	if !bitset {
+		bitSet, err := vote.BitSet.Uint64()
+		if err != nil {
+			return err
+		}
+
+		if err := encoding.WriteUint64LE(r, bitSet); err != nil {
+			return err
+		}
+	} else if err := encoding.WriteVarBytes(r, vote.BitSet); err != nil {
 		return err
 	}
 
@@ -384,7 +415,7 @@ func MockAgreement(hash []byte, round uint64, step uint8, keys []key.Keys, p *us
 	}
 
 	hdr := header.Header{Round: round, Step: step, BlockHash: hash, PubKeyBLS: cKeys[idx].BLSPubKeyBytes}
-	a := NewAgreement(hdr)
+	a := NewAgreement(hdr, block.Activation{})
 
 	// generating reduction events (votes) and signing them
 	steps := GenVotes(hash, round, step, keys, p)
@@ -432,9 +463,9 @@ func GenVotes(hash []byte, round uint64, step uint8, keys []key.Keys, p *user.Pr
 	return []*StepVotes{stepVotes1, stepVotes2}
 }
 
-func createBitSet(set sortedset.Set, round uint64, step uint8, size int, p *user.Provisioners) uint64 {
+func createBitSet(set sortedset.Set, round uint64, step uint8, size int, p *user.Provisioners) sortedset.Bitset {
 	committee := p.CreateVotingCommittee(round, step, size)
-	return committee.Bits(set)
+	return committee.BitsetOf(set)
 }
 
 func createCommitteeKeySet(c user.VotingCommittee, k []key.Keys) (keys []key.Keys) {
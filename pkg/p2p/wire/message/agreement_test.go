@@ -20,7 +20,7 @@ func TestStepVotes(t *testing.T) {
 	//inserting a third key in the set to better test packing and unpacking
 	genKeys(&set)
 
-	bitset := set.Bits(subset)
+	bitset := set.BitsetOf(subset)
 
 	s, err := bls.Sign(k1.BLSSecretKey, k1.BLSPubKey, hash)
 	assert.NoError(t, err)
@@ -41,13 +41,36 @@ func TestStepVotes(t *testing.T) {
 
 	buf := new(bytes.Buffer)
 
-	assert.NoError(t, MarshalStepVotes(buf, expectedStepVotes))
+	assert.NoError(t, MarshalStepVotes(buf, expectedStepVotes, true))
 
-	result, err := UnmarshalStepVotes(buf)
+	result, err := UnmarshalStepVotes(buf, true)
 	assert.NoError(t, err)
 
 	assert.Equal(t, expectedStepVotes, result)
 	assert.NoError(t, bls.Verify(result.Apk, hash, result.Signature))
+
+	// the rounds before the activation encode the BitSet as a uint64
+	buf.Reset()
+	assert.NoError(t, MarshalStepVotes(buf, expectedStepVotes, false))
+
+	result, err = UnmarshalStepVotes(buf, false)
+	assert.NoError(t, err)
+	assert.True(t, expectedStepVotes.BitSet.Equal(result.BitSet))
+	assert.NoError(t, bls.Verify(result.Apk, hash, result.Signature))
+}
+
+func TestStepVotesLegacyOverflow(t *testing.T) {
+	sv := NewStepVotes()
+	set := sortedset.New()
+	hash := []byte("this is a mock message")
+	assert.NoError(t, sv.Add(genReduction(hash, &set)))
+	sv.BitSet.Set(64)
+
+	// a committee of more than 64 members can not be encoded before
+	// the activation of the bitsets
+	buf := new(bytes.Buffer)
+	assert.Equal(t, sortedset.ErrBitsetOverflow, MarshalStepVotes(buf, sv, false))
+	assert.NoError(t, MarshalStepVotes(new(bytes.Buffer), sv, true))
 }
 
 // Test that adding Reduction events to a StepVotes struct results in a properly
@@ -8,6 +8,7 @@ import (
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/transactions"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/encoding"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/sortedset"
 )
 
 // MarshalBlock marshals a block into a binary buffer
@@ -139,7 +140,7 @@ func MarshalHeader(r *bytes.Buffer, h *block.Header) error {
 		return err
 	}
 
-	if err := MarshalCertificate(r, h.Certificate); err != nil {
+	if err := MarshalBlockCertificate(r, h.Certificate, h.Version); err != nil {
 		return err
 	}
 
@@ -150,6 +151,26 @@ func MarshalHeader(r *bytes.Buffer, h *block.Header) error {
 	return nil
 }
 
+// MarshalBlockCertificate marshals a certificate in the format of the blocks
+// of a version
+func MarshalBlockCertificate(r *bytes.Buffer, c *block.Certificate, version uint8) error {
+	if version == block.LegacyVersion {
+		return MarshalLegacyCertificate(r, c)
+	}
+
+	return MarshalCertificate(r, c)
+}
+
+// UnmarshalBlockCertificate unmarshals a certificate in the format of the
+// blocks of a version
+func UnmarshalBlockCertificate(r *bytes.Buffer, c *block.Certificate, version uint8) error {
+	if version == block.LegacyVersion {
+		return UnmarshalLegacyCertificate(r, c)
+	}
+
+	return UnmarshalCertificate(r, c)
+}
+
 // UnmarshalHeader unmarshal a block header from a binary buffer
 func UnmarshalHeader(r *bytes.Buffer, h *block.Header) error {
 	if err := encoding.ReadUint8(r, &h.Version); err != nil {
@@ -181,7 +202,7 @@ func UnmarshalHeader(r *bytes.Buffer, h *block.Header) error {
 		return err
 	}
 
-	if err := UnmarshalCertificate(r, h.Certificate); err != nil {
+	if err := UnmarshalBlockCertificate(r, h.Certificate, h.Version); err != nil {
 		return err
 	}
 
@@ -193,8 +214,53 @@ func UnmarshalHeader(r *bytes.Buffer, h *block.Header) error {
 	return nil
 }
 
-// MarshalCertificate marshals a certificate
+// MarshalCertificate marshals a certificate. The committees are encoded as
+// variable length bitsets
 func MarshalCertificate(r *bytes.Buffer, c *block.Certificate) error {
+	if err := marshalCertificateSignatures(r, c); err != nil {
+		return err
+	}
+
+	if err := encoding.WriteVarBytes(r, c.StepOneCommittee); err != nil {
+		return err
+	}
+
+	if err := encoding.WriteVarBytes(r, c.StepTwoCommittee); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// MarshalLegacyCertificate marshals the certificate of a block with
+// block.LegacyVersion, whose committees are encoded as uint64 bitmaps
+func MarshalLegacyCertificate(r *bytes.Buffer, c *block.Certificate) error {
+	if err := marshalCertificateSignatures(r, c); err != nil {
+		return err
+	}
+
+	stepOneCommittee, err := c.StepOneCommittee.Uint64()
+	if err != nil {
+		return err
+	}
+
+	if err := encoding.WriteUint64LE(r, stepOneCommittee); err != nil {
+		return err
+	}
+
+	stepTwoCommittee, err := c.StepTwoCommittee.Uint64()
+	if err != nil {
+		return err
+	}
+
+	if err := encoding.WriteUint64LE(r, stepTwoCommittee); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func marshalCertificateSignatures(r *bytes.Buffer, c *block.Certificate) error {
 	if err := encoding.WriteBLS(r, c.StepOneBatchedSig); err != nil {
 		return err
 	}
@@ -207,38 +273,65 @@ func MarshalCertificate(r *bytes.Buffer, c *block.Certificate) error {
 		return err
 	}
 
-	if err := encoding.WriteUint64LE(r, c.StepOneCommittee); err != nil {
+	return nil
+}
+
+// UnmarshalCertificate unmarshals a certificate whose committees are encoded
+// as variable length bitsets
+func UnmarshalCertificate(r *bytes.Buffer, c *block.Certificate) error {
+	if err := unmarshalCertificateSignatures(r, c); err != nil {
 		return err
 	}
 
-	if err := encoding.WriteUint64LE(r, c.StepTwoCommittee); err != nil {
+	var stepOneCommittee []byte
+	if err := encoding.ReadVarBytes(r, &stepOneCommittee); err != nil {
 		return err
 	}
+	c.StepOneCommittee = stepOneCommittee
+
+	var stepTwoCommittee []byte
+	if err := encoding.ReadVarBytes(r, &stepTwoCommittee); err != nil {
+		return err
+	}
+	c.StepTwoCommittee = stepTwoCommittee
 
 	return nil
 }
 
-// UnmarshalCertificate unmarshals a certificate
-func UnmarshalCertificate(r *bytes.Buffer, c *block.Certificate) error {
-	c.StepOneBatchedSig = make([]byte, 33)
-	if err := encoding.ReadBLS(r, c.StepOneBatchedSig); err != nil {
+// UnmarshalLegacyCertificate unmarshals the certificate of a block with
+// block.LegacyVersion, whose committees are encoded as uint64 bitmaps
+func UnmarshalLegacyCertificate(r *bytes.Buffer, c *block.Certificate) error {
+	if err := unmarshalCertificateSignatures(r, c); err != nil {
 		return err
 	}
 
-	c.StepTwoBatchedSig = make([]byte, 33)
-	if err := encoding.ReadBLS(r, c.StepTwoBatchedSig); err != nil {
+	var stepOneCommittee uint64
+	if err := encoding.ReadUint64LE(r, &stepOneCommittee); err != nil {
 		return err
 	}
+	c.StepOneCommittee = sortedset.BitsetFromUint64(stepOneCommittee)
 
-	if err := encoding.ReadUint8(r, &c.Step); err != nil {
+	var stepTwoCommittee uint64
+	if err := encoding.ReadUint64LE(r, &stepTwoCommittee); err != nil {
+		return err
+	}
+	c.StepTwoCommittee = sortedset.BitsetFromUint64(stepTwoCommittee)
+
+	return nil
+}
+
+func unmarshalCertificateSignatures(r *bytes.Buffer, c *block.Certificate) error {
+	c.StepOneBatchedSig = make([]byte, 33)
+	if err := encoding.ReadBLS(r, c.StepOneBatchedSig); err != nil {
 		return err
 	}
 
-	if err := encoding.ReadUint64LE(r, &c.StepOneCommittee); err != nil {
+	c.StepTwoBatchedSig = make([]byte, 33)
+	if err := encoding.ReadBLS(r, c.StepTwoBatchedSig); err != nil {
 		return err
 	}
 
-	if err := encoding.ReadUint64LE(r, &c.StepTwoCommittee); err != nil {
+	if err := encoding.ReadUint8(r, &c.Step); err != nil {
 		return err
 	}
 
@@ -8,6 +8,7 @@ import (
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/tests/helper"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/sortedset"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -77,6 +78,45 @@ func TestEncodeDecodeHeader(t *testing.T) {
 	assert.True(hdr.Equals(decHdr))
 }
 
+// TestEncodeDecodeLargeCommittee tests that the headers with the current
+// version carry committees larger than 64 members
+func TestEncodeDecodeLargeCommittee(t *testing.T) {
+	assert := assert.New(t)
+
+	hdr := helper.RandomHeader(t, 200)
+	hdr.Version = block.CurrentVersion
+	hdr.StepOneCommittee = sortedset.NewBitset(200)
+	hdr.StepOneCommittee.Set(3)
+	hdr.StepOneCommittee.Set(150)
+	hdr.StepTwoCommittee = sortedset.NewBitset(200)
+	hdr.StepTwoCommittee.Set(199)
+	hash, err := hdr.CalculateHash()
+	assert.Nil(err)
+	hdr.Hash = hash
+
+	buf := new(bytes.Buffer)
+	assert.Nil(message.MarshalHeader(buf, hdr))
+
+	decHdr := block.NewHeader()
+	assert.Nil(message.UnmarshalHeader(buf, decHdr))
+	assert.True(hdr.Equals(decHdr))
+	assert.True(decHdr.StepOneCommittee.IsSet(150))
+	assert.True(decHdr.StepTwoCommittee.IsSet(199))
+}
+
+// TestEncodeLegacyCommitteeOverflow tests that a legacy header refuses to
+// encode a committee which does not fit its uint64 bitmaps
+func TestEncodeLegacyCommitteeOverflow(t *testing.T) {
+	hdr := helper.RandomHeader(t, 200)
+	hdr.Version = block.LegacyVersion
+	hdr.StepOneCommittee = sortedset.NewBitset(100)
+	hdr.StepOneCommittee.Set(99)
+	hdr.Hash = make([]byte, 32)
+
+	buf := new(bytes.Buffer)
+	assert.Equal(t, sortedset.ErrBitsetOverflow, message.MarshalHeader(buf, hdr))
+}
+
 func TestDecodeLegacyGenesis(t *testing.T) { //nolint
 	config.DecodeGenesis()
 }
@@ -92,13 +92,14 @@ func UnmarshalCandidateMessage(b *bytes.Buffer, m SerializableMessage) error {
 }
 
 // UnmarshalCandidate consumes a buffer, instantiate and fills the Candidate
-// fields
+// fields. The Certificate is encoded in the format of the Block version, so
+// that the older nodes can decode the Candidates of the legacy blocks
 func UnmarshalCandidate(b *bytes.Buffer, c *Candidate) error {
 	if err := UnmarshalBlock(b, c.Block); err != nil {
 		return err
 	}
 
-	return UnmarshalCertificate(b, c.Certificate)
+	return UnmarshalBlockCertificate(b, c.Certificate, c.Block.Header.Version)
 }
 
 // MarshalCandidate encodes a Candidate to a binary form
@@ -107,7 +108,7 @@ func MarshalCandidate(b *bytes.Buffer, c Candidate) error {
 		return err
 	}
 
-	return MarshalCertificate(b, c.Certificate)
+	return MarshalBlockCertificate(b, c.Certificate, c.Block.Header.Version)
 }
 
 // MockCertificate mocks a certificate
@@ -0,0 +1,211 @@
+package message
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/msg"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/encoding"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-crypto/hash"
+)
+
+var (
+	// ErrNotEquivocation is returned when the two votes of an Evidence do not
+	// come from the same provisioner for the same round and step, or when
+	// they vote for the same block hash
+	ErrNotEquivocation = errors.New("votes are not conflicting")
+	// ErrUnsupportedVote is returned when the Evidence refers to votes which
+	// are neither Reduction nor Agreement messages
+	ErrUnsupportedVote = errors.New("unsupported vote topic")
+)
+
+type (
+	// Vote is a signed consensus header. Both Reduction and Agreement
+	// messages carry a BLS signature of the header (see
+	// header.MarshalSignableVote), which makes the vote verifiable by itself
+	Vote struct {
+		Header    header.Header
+		Signature []byte
+	}
+
+	// Evidence proves that a provisioner signed two different block hashes
+	// for the same round and step. It is self-contained, as it carries both
+	// signed headers, and can be verified knowing nothing but the votes
+	Evidence struct {
+		// VoteTopic is the topic of the conflicting votes, either
+		// topics.Reduction or topics.Agreement
+		VoteTopic topics.Topic
+		First     Vote
+		Second    Vote
+	}
+)
+
+// NewEvidence creates an Evidence out of two conflicting votes. The votes are
+// sorted by block hash, so that the same equivocation always produces the
+// same Evidence, regardless of the order the votes have been received in
+func NewEvidence(voteTopic topics.Topic, first, second Vote) Evidence {
+	if bytes.Compare(first.Header.BlockHash, second.Header.BlockHash) > 0 {
+		first, second = second, first
+	}
+
+	return Evidence{
+		VoteTopic: voteTopic,
+		First:     first,
+		Second:    second,
+	}
+}
+
+// Verify checks that the two votes belong to the same provisioner, round and
+// step, that they vote for different block hashes and that both signatures
+// are valid
+func (e Evidence) Verify() error {
+	if e.VoteTopic != topics.Reduction && e.VoteTopic != topics.Agreement {
+		return ErrUnsupportedVote
+	}
+
+	h1, h2 := e.First.Header, e.Second.Header
+	if !bytes.Equal(h1.PubKeyBLS, h2.PubKeyBLS) ||
+		h1.Round != h2.Round ||
+		h1.Step != h2.Step ||
+		bytes.Equal(h1.BlockHash, h2.BlockHash) {
+		return ErrNotEquivocation
+	}
+
+	if err := e.First.Verify(); err != nil {
+		return err
+	}
+
+	return e.Second.Verify()
+}
+
+// Offender returns the BLS public key of the equivocating provisioner
+func (e Evidence) Offender() []byte {
+	return e.First.Header.PubKeyBLS
+}
+
+// Offence identifies the slot in which the provisioner equivocated. Any
+// Evidence of the same equivocation (e.g. including a third conflicting vote)
+// refers to the same Offence
+func (e Evidence) Offence() []byte {
+	h := e.First.Header
+	offence := make([]byte, 0, len(h.PubKeyBLS)+10)
+	offence = append(offence, h.PubKeyBLS...)
+	offence = append(offence, byte(e.VoteTopic), h.Step)
+	round := make([]byte, 8)
+	binary.LittleEndian.PutUint64(round, h.Round)
+	return append(offence, round...)
+}
+
+// ID returns the hash of the marshaled Evidence
+func (e Evidence) ID() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := MarshalEvidence(buf, e); err != nil {
+		return nil, err
+	}
+
+	return hash.Sha3256(buf.Bytes())
+}
+
+func (e Evidence) String() string {
+	var sb strings.Builder
+	_, _ = sb.WriteString(fmt.Sprintf("vote topic='%s'", e.VoteTopic.String()))
+	_, _ = sb.WriteString(" first=[")
+	_, _ = sb.WriteString(e.First.Header.String())
+	_, _ = sb.WriteString("] second=[")
+	_, _ = sb.WriteString(e.Second.Header.String())
+	_, _ = sb.WriteString("]")
+	return sb.String()
+}
+
+// Verify the BLS signature of the Vote against the signable fields of its
+// header
+func (v Vote) Verify() error {
+	r := new(bytes.Buffer)
+	if err := header.MarshalSignableVote(r, v.Header); err != nil {
+		return err
+	}
+
+	// the crypto package mutates the signature when decompressing it, so we
+	// work on a copy
+	sig := make([]byte, len(v.Signature))
+	copy(sig, v.Signature)
+	return msg.VerifyBLSSignature(v.Header.PubKeyBLS, r.Bytes(), sig)
+}
+
+// UnmarshalEvidenceMessage unmarshals an Evidence from a buffer into a
+// SerializableMessage
+func UnmarshalEvidenceMessage(r *bytes.Buffer, m SerializableMessage) error {
+	e := Evidence{}
+	if err := UnmarshalEvidence(r, &e); err != nil {
+		return err
+	}
+
+	m.SetPayload(e)
+	return nil
+}
+
+// MarshalEvidence marshals an Evidence into a buffer
+func MarshalEvidence(r *bytes.Buffer, e Evidence) error {
+	if err := encoding.WriteUint8(r, uint8(e.VoteTopic)); err != nil {
+		return err
+	}
+
+	if err := marshalVote(r, e.First); err != nil {
+		return err
+	}
+
+	return marshalVote(r, e.Second)
+}
+
+// UnmarshalEvidence unmarshals a buffer into an Evidence
+func UnmarshalEvidence(r *bytes.Buffer, e *Evidence) error {
+	var voteTopic uint8
+	if err := encoding.ReadUint8(r, &voteTopic); err != nil {
+		return err
+	}
+	e.VoteTopic = topics.Topic(voteTopic)
+
+	if err := unmarshalVote(r, &e.First); err != nil {
+		return err
+	}
+
+	return unmarshalVote(r, &e.Second)
+}
+
+func marshalVote(r *bytes.Buffer, v Vote) error {
+	if err := header.Marshal(r, v.Header); err != nil {
+		return err
+	}
+
+	return encoding.WriteBLS(r, v.Signature)
+}
+
+func unmarshalVote(r *bytes.Buffer, v *Vote) error {
+	if err := header.Unmarshal(r, &v.Header); err != nil {
+		return err
+	}
+
+	v.Signature = make([]byte, 33)
+	return encoding.ReadBLS(r, v.Signature)
+}
+
+/********************/
+/* MOCKUP FUNCTIONS */
+/********************/
+
+// MockEvidence mocks an Evidence of a provisioner casting two Reduction votes
+// for different block hashes in the same round and step
+func MockEvidence(hash1, hash2 []byte, round uint64, step uint8, keys []key.Keys, iterativeIdx ...int) Evidence {
+	r1 := MockReduction(hash1, round, step, keys, iterativeIdx...)
+	r2 := MockReduction(hash2, round, step, keys, iterativeIdx...)
+	return NewEvidence(topics.Reduction,
+		Vote{Header: r1.State(), Signature: r1.SignedHash},
+		Vote{Header: r2.State(), Signature: r2.SignedHash},
+	)
+}
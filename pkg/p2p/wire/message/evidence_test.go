@@ -0,0 +1,92 @@
+package message_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	crypto "github.com/dusk-network/dusk-crypto/hash"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test that an Evidence survives a marshaling roundtrip through the generic
+// message Unmarshal, and that it still verifies afterwards
+func TestEvidenceUnMarshal(t *testing.T) {
+	e := mockEvidence(t)
+
+	msg := message.New(topics.Evidence, e)
+	buf, err := message.Marshal(msg)
+	assert.NoError(t, err)
+
+	decoded, err := message.Unmarshal(&buf, block.Activation{})
+	assert.NoError(t, err)
+
+	e2 := decoded.Payload().(message.Evidence)
+	assert.Equal(t, e, e2)
+	assert.NoError(t, e2.Verify())
+}
+
+// Test that the vote order does not influence the Evidence ID
+func TestEvidenceID(t *testing.T) {
+	e := mockEvidence(t)
+	swapped := message.NewEvidence(e.VoteTopic, e.Second, e.First)
+
+	id1, err := e.ID()
+	assert.NoError(t, err)
+	id2, err := swapped.ID()
+	assert.NoError(t, err)
+	assert.Equal(t, id1, id2)
+}
+
+// Test that Verify rejects votes which do not constitute an equivocation
+func TestEvidenceVerify(t *testing.T) {
+	k1, _ := key.NewRandKeys()
+	k2, _ := key.NewRandKeys()
+	hash1, _ := crypto.RandEntropy(32)
+	hash2, _ := crypto.RandEntropy(32)
+
+	r1 := message.MockReduction(hash1, 1, 1, []key.Keys{k1})
+	r2 := message.MockReduction(hash2, 1, 1, []key.Keys{k1})
+	vote1 := message.Vote{Header: r1.State(), Signature: r1.SignedHash}
+	vote2 := message.Vote{Header: r2.State(), Signature: r2.SignedHash}
+
+	// same block hash
+	e := message.NewEvidence(topics.Reduction, vote1, vote1)
+	assert.Equal(t, message.ErrNotEquivocation, e.Verify())
+
+	// different step
+	r3 := message.MockReduction(hash2, 1, 2, []key.Keys{k1})
+	e = message.NewEvidence(topics.Reduction, vote1, message.Vote{Header: r3.State(), Signature: r3.SignedHash})
+	assert.Equal(t, message.ErrNotEquivocation, e.Verify())
+
+	// different provisioner
+	r4 := message.MockReduction(hash2, 1, 1, []key.Keys{k2})
+	e = message.NewEvidence(topics.Reduction, vote1, message.Vote{Header: r4.State(), Signature: r4.SignedHash})
+	assert.Equal(t, message.ErrNotEquivocation, e.Verify())
+
+	// unsupported topic
+	e = message.NewEvidence(topics.Score, vote1, vote2)
+	assert.Equal(t, message.ErrUnsupportedVote, e.Verify())
+
+	// forged signature
+	forged := vote2
+	forged.Signature = vote1.Signature
+	e = message.NewEvidence(topics.Reduction, vote1, forged)
+	assert.Error(t, e.Verify())
+
+	// genuine equivocation
+	e = message.NewEvidence(topics.Reduction, vote1, vote2)
+	assert.NoError(t, e.Verify())
+	assert.True(t, bytes.Equal(k1.BLSPubKeyBytes, e.Offender()))
+}
+
+func mockEvidence(t *testing.T) message.Evidence {
+	k, err := key.NewRandKeys()
+	assert.NoError(t, err)
+	hash1, _ := crypto.RandEntropy(32)
+	hash2, _ := crypto.RandEntropy(32)
+	return message.MockEvidence(hash1, hash2, 1, 2, []key.Keys{k})
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/transactions"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
 )
@@ -118,8 +119,10 @@ func (m *simple) initPayloadBuffer(b bytes.Buffer) {
 
 // Unmarshal mutates the buffer by extracting the topic. It create the Message
 // by setting the topic and unmarshaling the payload into the proper structure
-// It also caches the serialized form within the message
-func Unmarshal(b *bytes.Buffer) (Message, error) {
+// It also caches the serialized form within the message. The activation
+// heights of the network select the encoding of the messages which changed
+// over time
+func Unmarshal(b *bytes.Buffer, act block.Activation) (Message, error) {
 	var err error
 	msg := &simple{}
 	msg.initPayloadBuffer(*b)
@@ -140,7 +143,9 @@ func Unmarshal(b *bytes.Buffer) (Message, error) {
 	case topics.Reduction:
 		err = UnmarshalReductionMessage(b, msg)
 	case topics.Agreement:
-		err = UnmarshalAgreementMessage(b, msg)
+		err = UnmarshalAgreementMessage(b, msg, act)
+	case topics.Evidence:
+		err = UnmarshalEvidenceMessage(b, msg)
 	}
 
 	if err != nil {
@@ -227,6 +232,9 @@ func marshalMessage(topic topics.Topic, payload interface{}, buf *bytes.Buffer)
 	case topics.Agreement:
 		agreement := payload.(Agreement)
 		err = MarshalAgreement(buf, agreement)
+	case topics.Evidence:
+		evidence := payload.(Evidence)
+		err = MarshalEvidence(buf, evidence)
 	default:
 		return fmt.Errorf("unsupported marshaling of message type: %v", topic.String())
 	}
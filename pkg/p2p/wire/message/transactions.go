@@ -63,6 +63,22 @@ func UnmarshalTx(r *bytes.Buffer) (transactions.Transaction, error) {
 		tx := &transactions.Coinbase{TxType: transactions.TxType(txType)}
 		err := UnmarshalCoinbase(r, tx)
 		return tx, err
+	case transactions.UnstakeType:
+		tx, err := transactions.NewUnstake(0, 0, 0, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		err = UnmarshalUnstake(r, tx)
+		return tx, err
+	case transactions.SlashType:
+		tx, err := transactions.NewSlash(0, 0, 0, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		err = UnmarshalSlash(r, tx)
+		return tx, err
 	default:
 		return nil, fmt.Errorf("unknown transaction type: %d", txType)
 	}
@@ -81,6 +97,10 @@ func MarshalTx(r *bytes.Buffer, tx transactions.Transaction) error {
 		return MarshalStake(r, tx.(*transactions.Stake))
 	case transactions.CoinbaseType:
 		return MarshalCoinbase(r, tx.(*transactions.Coinbase))
+	case transactions.UnstakeType:
+		return MarshalUnstake(r, tx.(*transactions.Unstake))
+	case transactions.SlashType:
+		return MarshalSlash(r, tx.(*transactions.Slash))
 	default:
 		return fmt.Errorf("unknown transaction type: %d", tx.Type())
 	}
@@ -187,7 +207,33 @@ func marshalStake(r *bytes.Buffer, tx *transactions.Stake, encodeSignature bool)
 		return err
 	}
 
-	return nil
+	if tx.Version < transactions.StakeRewardVersion {
+		return nil
+	}
+
+	return encoding.WriteVarBytes(r, tx.RewardAddress)
+}
+
+// MarshalUnstake into a buffer
+func MarshalUnstake(r *bytes.Buffer, tx *transactions.Unstake) error {
+	if err := marshalStandard(r, tx.Standard, true); err != nil {
+		return err
+	}
+
+	if err := encoding.WriteVarBytes(r, tx.PubKeyBLS); err != nil {
+		return err
+	}
+
+	return encoding.WriteBLS(r, tx.Signature)
+}
+
+// MarshalSlash into a buffer
+func MarshalSlash(r *bytes.Buffer, tx *transactions.Slash) error {
+	if err := marshalStandard(r, tx.Standard, true); err != nil {
+		return err
+	}
+
+	return encoding.WriteVarBytes(r, tx.Evidence)
 }
 
 // MarshalCoinbase into a buffer
@@ -323,7 +369,34 @@ func UnmarshalStake(r *bytes.Buffer, tx *transactions.Stake) error {
 		return err
 	}
 
-	return nil
+	if tx.Version < transactions.StakeRewardVersion {
+		return nil
+	}
+
+	return encoding.ReadVarBytes(r, &tx.RewardAddress)
+}
+
+// UnmarshalUnstake from a buffer
+func UnmarshalUnstake(r *bytes.Buffer, tx *transactions.Unstake) error {
+	if err := UnmarshalStandard(r, tx.Standard); err != nil {
+		return err
+	}
+
+	if err := encoding.ReadVarBytes(r, &tx.PubKeyBLS); err != nil {
+		return err
+	}
+
+	tx.Signature = make([]byte, 33)
+	return encoding.ReadBLS(r, tx.Signature)
+}
+
+// UnmarshalSlash from a buffer
+func UnmarshalSlash(r *bytes.Buffer, tx *transactions.Slash) error {
+	if err := UnmarshalStandard(r, tx.Standard); err != nil {
+		return err
+	}
+
+	return encoding.ReadVarBytes(r, &tx.Evidence)
 }
 
 // UnmarshalLegacyStake is Deprecated. It is used solely to allow reutilization
@@ -6,9 +6,11 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/transactions"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/tests/helper"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	crypto "github.com/dusk-network/dusk-crypto/hash"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -138,6 +140,37 @@ func TestEncodeDecodeStake(t *testing.T) {
 	assert.Equal(transactions.StakeType, decTX.(*transactions.Stake).TxType)
 }
 
+func TestEncodeDecodeStakeRewardAddress(t *testing.T) {
+
+	assert := assert.New(t)
+
+	// random Stake tx, carrying a reward address
+	tx, err := helper.RandomStakeTx(t, false)
+	assert.Nil(err)
+	tx.Version = transactions.StakeRewardVersion
+	tx.RewardAddress = helper.RandomSlice(t, 64)
+
+	buf := new(bytes.Buffer)
+	err = message.MarshalTx(buf, tx)
+	assert.Nil(err)
+
+	decTX, err := message.UnmarshalTx(buf)
+	assert.Nil(err)
+
+	assert.True(tx.Equals(decTX))
+	assert.Equal(tx.RewardAddress, decTX.(*transactions.Stake).RewardAddress)
+
+	// The reward address is part of the hash
+	txid, err := tx.CalculateHash()
+	assert.Nil(err)
+
+	tx.RewardAddress = helper.RandomSlice(t, 64)
+	otherTxid, err := tx.CalculateHash()
+	assert.Nil(err)
+
+	assert.False(bytes.Equal(txid, otherTxid))
+}
+
 func TestEqualsMethodStake(t *testing.T) {
 
 	assert := assert.New(t)
@@ -266,6 +299,68 @@ func TestBidHashNotStandard(t *testing.T) {
 	}
 }
 
+func TestEncodeDecodeUnstake(t *testing.T) {
+
+	assert := assert.New(t)
+
+	// random unstake tx
+	keys, _ := key.NewRandKeys()
+	tx := helper.RandomUnstakeTx(t, keys)
+
+	// Encode TX into a buffer
+	buf := new(bytes.Buffer)
+	err := message.MarshalTx(buf, tx)
+	assert.Nil(err)
+
+	// Decode buffer into an unstake TX struct
+	decTX, err := message.UnmarshalTx(buf)
+	assert.Nil(err)
+
+	// Check both structs are equal
+	assert.True(tx.Equals(decTX))
+
+	// Check that Hashes are equal
+	txid, err := tx.CalculateHash()
+	assert.Nil(err)
+
+	decTxid, err := decTX.CalculateHash()
+	assert.Nil(err)
+
+	assert.True(bytes.Equal(txid, decTxid))
+}
+
+func TestEncodeDecodeSlash(t *testing.T) {
+
+	assert := assert.New(t)
+
+	// random slash tx
+	keys, _ := key.NewRandKeys()
+	hash1, _ := crypto.RandEntropy(32)
+	hash2, _ := crypto.RandEntropy(32)
+	tx := helper.RandomSlashTx(t, message.MockEvidence(hash1, hash2, 1, 1, []key.Keys{keys}))
+
+	// Encode TX into a buffer
+	buf := new(bytes.Buffer)
+	err := message.MarshalTx(buf, tx)
+	assert.Nil(err)
+
+	// Decode buffer into a slash TX struct
+	decTX, err := message.UnmarshalTx(buf)
+	assert.Nil(err)
+
+	// Check both structs are equal
+	assert.True(tx.Equals(decTX))
+
+	// Check that Hashes are equal
+	txid, err := tx.CalculateHash()
+	assert.Nil(err)
+
+	decTxid, err := decTX.CalculateHash()
+	assert.Nil(err)
+
+	assert.True(bytes.Equal(txid, decTxid))
+}
+
 // calcTxAndStandardHash calculates the hash for the transaction and
 // then the hash for the underlying standardTx. This ensures that the txhash being used,
 // is not for the standardTx, unless this is explicitly called.
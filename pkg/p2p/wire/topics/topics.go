@@ -100,6 +100,32 @@ const (
 
 	// Monitoring topics
 	SyncProgress
+
+	// Tx lifecycle topics
+	TxEvent
+
+	// Mempool dry-run RPCBus topics
+	ValidateTx
+
+	// Equivocation topics
+	Evidence
+
+	// Consensus monitoring RPCBus topics
+	GetConsensusTimeouts
+
+	// Wallet password RPCBus topics
+	ChangePassword
+
+	// Wallet backup RPCBus topics
+	CreateWalletWithMnemonic
+
+	// Fee estimation RPCBus topics
+	EstimateFee
+
+	// Offline signing RPCBus topics
+	LoadWatchOnlyWallet
+	CreateUnsignedTx
+	SubmitSignedTx
 )
 
 type topicBuf struct {
@@ -181,6 +207,16 @@ var Topics = [...]topicBuf{
 	{GetRoundResults, *(bytes.NewBuffer([]byte{byte(GetRoundResults)})), "getroundresults"},
 	{GetCandidate, *(bytes.NewBuffer([]byte{byte(GetCandidate)})), "getcandidate"},
 	{SyncProgress, *(bytes.NewBuffer([]byte{byte(SyncProgress)})), "syncprogress"},
+	{TxEvent, *(bytes.NewBuffer([]byte{byte(TxEvent)})), "txevent"},
+	{ValidateTx, *(bytes.NewBuffer([]byte{byte(ValidateTx)})), "validatetx"},
+	{Evidence, *(bytes.NewBuffer([]byte{byte(Evidence)})), "evidence"},
+	{GetConsensusTimeouts, *(bytes.NewBuffer([]byte{byte(GetConsensusTimeouts)})), "getconsensustimeouts"},
+	{ChangePassword, *(bytes.NewBuffer([]byte{byte(ChangePassword)})), "changepassword"},
+	{CreateWalletWithMnemonic, *(bytes.NewBuffer([]byte{byte(CreateWalletWithMnemonic)})), "createwalletwithmnemonic"},
+	{EstimateFee, *(bytes.NewBuffer([]byte{byte(EstimateFee)})), "estimatefee"},
+	{LoadWatchOnlyWallet, *(bytes.NewBuffer([]byte{byte(LoadWatchOnlyWallet)})), "loadwatchonlywallet"},
+	{CreateUnsignedTx, *(bytes.NewBuffer([]byte{byte(CreateUnsignedTx)})), "createunsignedtx"},
+	{SubmitSignedTx, *(bytes.NewBuffer([]byte{byte(SubmitSignedTx)})), "submitsignedtx"},
 }
 
 func checkConsistency(topics []topicBuf) {
@@ -0,0 +1,237 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/txevent"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/mempool"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/rpc/nodeext"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
+	"github.com/dusk-network/dusk-protobuf/autogen/go/node"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// txEventsQueueSize is the number of tx events buffered per stream. Events
+// are dropped for a stream which does not keep up.
+const txEventsQueueSize = 1000
+
+// sendTxTimeout bounds the creation of a tx. It is longer than the timeout of
+// the Node service, since paying a fee rate proves drafts of the tx first
+const sendTxTimeout = 30 * time.Second
+
+// Ensure `nodeExtServer` implements `nodeext.NodeExtServer`
+var _ nodeext.NodeExtServer = (*nodeExtServer)(nil)
+
+// nodeExtServer serves the methods of the NodeExt service, which extends the
+// dusk-protobuf Node service
+type nodeExtServer struct {
+	rpcBus   *rpcbus.RPCBus
+	eventBus *eventbus.EventBus
+}
+
+// SubscribeTxEvents streams the lifecycle events of the requested txs, or of
+// all txs if none is requested, until the client cancels the stream
+func (n *nodeExtServer) SubscribeTxEvents(req *nodeext.TxEventsRequest, stream nodeext.NodeExt_SubscribeTxEventsServer) error {
+	filter, err := txevent.NewFilter(req.Txids)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	eventChan := make(chan message.Message, txEventsQueueSize)
+	id := n.eventBus.Subscribe(topics.TxEvent, eventbus.NewChanListener(eventChan))
+	defer n.eventBus.Unsubscribe(topics.TxEvent, id)
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case msg := <-eventChan:
+			e := msg.Payload().(txevent.Event)
+			if !filter.Match(e) {
+				continue
+			}
+
+			if err := stream.Send(toTxEvent(e)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// ValidateTx runs the mempool checks on a tx against the current tip, without
+// storing or advertising it
+func (n *nodeExtServer) ValidateTx(ctx context.Context, req *nodeext.ValidateTxRequest) (*nodeext.ValidateTxResponse, error) {
+	tx, err := message.UnmarshalTx(bytes.NewBuffer(req.Tx))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "could not decode tx: %v", err)
+	}
+
+	resp, err := n.rpcBus.Call(topics.ValidateTx, rpcbus.NewRequest(tx), 5*time.Second)
+	if err != nil {
+		return nil, err
+	}
+
+	result := resp.(mempool.ValidationResult)
+	out := &nodeext.ValidateTxResponse{
+		Txid:     hex.EncodeToString(result.TxID),
+		Valid:    result.Valid(),
+		Failures: make([]*nodeext.ValidationFailure, len(result.Failures)),
+	}
+
+	for i, f := range result.Failures {
+		out.Failures[i] = &nodeext.ValidationFailure{
+			Code:   nodeext.FailureCode(f.Code),
+			Reason: f.Reason,
+		}
+	}
+
+	return out, nil
+}
+
+// GetConsensusTimeouts returns the current timeouts of the consensus phases.
+// It fails if the consensus is not running
+func (n *nodeExtServer) GetConsensusTimeouts(ctx context.Context, req *nodeext.ConsensusTimeoutsRequest) (*nodeext.ConsensusTimeoutsResponse, error) {
+	resp, err := n.rpcBus.Call(topics.GetConsensusTimeouts, rpcbus.NewRequest(req), 5*time.Second)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "consensus timeouts unavailable: %v", err)
+	}
+
+	timeouts := resp.([]consensus.PhaseTimeout)
+	out := &nodeext.ConsensusTimeoutsResponse{
+		Timeouts: make([]*nodeext.PhaseTimeout, len(timeouts)),
+	}
+
+	for i, t := range timeouts {
+		out.Timeouts[i] = &nodeext.PhaseTimeout{
+			Phase:     t.Phase.String(),
+			TimeoutMs: uint64(t.Timeout / time.Millisecond),
+		}
+	}
+
+	return out, nil
+}
+
+// ChangePassword encrypts the seed file and the database of the loaded wallet
+// with a new password
+func (n *nodeExtServer) ChangePassword(ctx context.Context, req *nodeext.ChangePasswordRequest) (*nodeext.ChangePasswordResponse, error) {
+	resp, err := n.rpcBus.Call(topics.ChangePassword, rpcbus.NewRequest(req), 5*time.Second)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.(*nodeext.ChangePasswordResponse), nil
+}
+
+// CreateWalletWithMnemonic creates a wallet, and returns the mnemonic backup
+// of its seed
+func (n *nodeExtServer) CreateWalletWithMnemonic(ctx context.Context, req *nodeext.CreateWalletRequest) (*nodeext.CreateWalletResponse, error) {
+	resp, err := n.rpcBus.Call(topics.CreateWalletWithMnemonic, rpcbus.NewRequest(req), 5*time.Second)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.(*nodeext.CreateWalletResponse), nil
+}
+
+// Transfer sends an amount to an address, paying the requested fee or fee
+// rate
+func (n *nodeExtServer) Transfer(ctx context.Context, req *nodeext.TransferRequest) (*nodeext.TransferResponse, error) {
+	return n.sendTx(topics.SendStandardTx, req)
+}
+
+// MultiTransfer sends an amount to each recipient within a single tx, paying
+// the requested fee or fee rate
+func (n *nodeExtServer) MultiTransfer(ctx context.Context, req *nodeext.MultiTransferRequest) (*nodeext.TransferResponse, error) {
+	return n.sendTx(topics.SendStandardTx, req)
+}
+
+// SendBid sends a bid tx, paying the requested fee or fee rate
+func (n *nodeExtServer) SendBid(ctx context.Context, req *nodeext.ConsensusTxRequest) (*nodeext.TransferResponse, error) {
+	return n.sendTx(topics.SendBidTx, req)
+}
+
+// SendStake sends a stake tx, paying the requested fee or fee rate
+func (n *nodeExtServer) SendStake(ctx context.Context, req *nodeext.ConsensusTxRequest) (*nodeext.TransferResponse, error) {
+	return n.sendTx(topics.SendStakeTx, req)
+}
+
+// EstimateFee suggests the fee rate for a tx to be included within the
+// requested number of blocks
+func (n *nodeExtServer) EstimateFee(ctx context.Context, req *nodeext.EstimateFeeRequest) (*nodeext.EstimateFeeResponse, error) {
+	if req.TargetBlocks == 0 || req.TargetBlocks > mempool.MaxFeeTarget {
+		return nil, status.Error(codes.InvalidArgument, mempool.ErrInvalidFeeTarget.Error())
+	}
+
+	resp, err := n.rpcBus.Call(topics.EstimateFee, rpcbus.NewRequest(req.TargetBlocks), 5*time.Second)
+	if err != nil {
+		return nil, err
+	}
+
+	estimate := resp.(mempool.FeeEstimate)
+	return &nodeext.EstimateFeeResponse{
+		FeeRate:      estimate.FeeRate,
+		TargetBlocks: estimate.TargetBlocks,
+		MinFee:       estimate.MinFee,
+		Blocks:       uint64(estimate.Blocks),
+	}, nil
+}
+
+// LoadWatchOnlyWallet loads a watch-only wallet from a view key
+func (n *nodeExtServer) LoadWatchOnlyWallet(ctx context.Context, req *nodeext.LoadWatchOnlyRequest) (*nodeext.LoadWatchOnlyResponse, error) {
+	resp, err := n.rpcBus.Call(topics.LoadWatchOnlyWallet, rpcbus.NewRequest(req), 5*time.Second)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.(*nodeext.LoadWatchOnlyResponse), nil
+}
+
+// CreateUnsignedTx builds a tx paying the recipients from the loaded
+// watch-only wallet, to be signed offline
+func (n *nodeExtServer) CreateUnsignedTx(ctx context.Context, req *nodeext.MultiTransferRequest) (*nodeext.UnsignedTxResponse, error) {
+	resp, err := n.rpcBus.Call(topics.CreateUnsignedTx, rpcbus.NewRequest(req), 5*time.Second)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.(*nodeext.UnsignedTxResponse), nil
+}
+
+// SubmitSignedTx publishes a tx signed offline, once it passes the full
+// verification of the mempool
+func (n *nodeExtServer) SubmitSignedTx(ctx context.Context, req *nodeext.SubmitSignedTxRequest) (*nodeext.TransferResponse, error) {
+	tx, err := message.UnmarshalTx(bytes.NewBuffer(req.Tx))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "could not decode tx: %v", err)
+	}
+
+	return n.sendTx(topics.SubmitSignedTx, tx)
+}
+
+// sendTx forwards a request carrying a custom fee to the transactor, which
+// creates, signs and publishes the tx
+func (n *nodeExtServer) sendTx(topic topics.Topic, req interface{}) (*nodeext.TransferResponse, error) {
+	resp, err := n.rpcBus.Call(topic, rpcbus.NewRequest(req), sendTxTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	return &nodeext.TransferResponse{Hash: resp.(*node.TransferResponse).Hash}, nil
+}
+
+func toTxEvent(e txevent.Event) *nodeext.TxEvent {
+	return &nodeext.TxEvent{
+		Txid:   hex.EncodeToString(e.TxID),
+		Status: nodeext.TxStatus(e.Status),
+		Reason: e.Reason,
+		Height: e.Height,
+	}
+}
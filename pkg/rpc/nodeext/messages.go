@@ -0,0 +1,352 @@
+package nodeext
+
+import (
+	"github.com/golang/protobuf/proto"
+)
+
+// TxStatus mirrors txevent.Status
+type TxStatus int32
+
+// TxStatus values
+const (
+	TxStatus_ACCEPTED TxStatus = 0 //nolint
+	TxStatus_REJECTED TxStatus = 1 //nolint
+	TxStatus_INCLUDED TxStatus = 2 //nolint
+	TxStatus_EVICTED  TxStatus = 3 //nolint
+	TxStatus_PENDING  TxStatus = 4 //nolint
+)
+
+// TxStatus_name maps the TxStatus values to their names
+var TxStatus_name = map[int32]string{ //nolint
+	0: "ACCEPTED",
+	1: "REJECTED",
+	2: "INCLUDED",
+	3: "EVICTED",
+	4: "PENDING",
+}
+
+// TxStatus_value maps the TxStatus names to their values
+var TxStatus_value = map[string]int32{ //nolint
+	"ACCEPTED": 0,
+	"REJECTED": 1,
+	"INCLUDED": 2,
+	"EVICTED":  3,
+	"PENDING":  4,
+}
+
+func (x TxStatus) String() string {
+	return proto.EnumName(TxStatus_name, int32(x))
+}
+
+// TxEventsRequest selects the txs to stream the lifecycle events of
+type TxEventsRequest struct {
+	// hex-encoded txids
+	Txids []string `protobuf:"bytes,1,rep,name=txids,proto3" json:"txids,omitempty"`
+}
+
+func (m *TxEventsRequest) Reset()         { *m = TxEventsRequest{} }
+func (m *TxEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*TxEventsRequest) ProtoMessage()    {}
+
+// TxEvent is a change in the lifecycle of a tx
+type TxEvent struct {
+	Txid   string   `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+	Status TxStatus `protobuf:"varint,2,opt,name=status,proto3,enum=nodeext.TxStatus" json:"status,omitempty"`
+	// set for rejected and evicted txs
+	Reason string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	// set for included txs
+	Height uint64 `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
+}
+
+func (m *TxEvent) Reset()         { *m = TxEvent{} }
+func (m *TxEvent) String() string { return proto.CompactTextString(m) }
+func (*TxEvent) ProtoMessage()    {}
+
+// FailureCode mirrors mempool.FailureCode
+type FailureCode int32
+
+// FailureCode values
+const (
+	FailureCode_HASH            FailureCode = 0 //nolint
+	FailureCode_COINBASE        FailureCode = 1 //nolint
+	FailureCode_ALREADY_EXISTS  FailureCode = 2 //nolint
+	FailureCode_DOUBLE_SPENDING FailureCode = 3 //nolint
+	FailureCode_VERIFICATION    FailureCode = 4 //nolint
+	FailureCode_IMMATURE        FailureCode = 5 //nolint
+)
+
+// FailureCode_name maps the FailureCode values to their names
+var FailureCode_name = map[int32]string{ //nolint
+	0: "HASH",
+	1: "COINBASE",
+	2: "ALREADY_EXISTS",
+	3: "DOUBLE_SPENDING",
+	4: "VERIFICATION",
+	5: "IMMATURE",
+}
+
+// FailureCode_value maps the FailureCode names to their values
+var FailureCode_value = map[string]int32{ //nolint
+	"HASH":            0,
+	"COINBASE":        1,
+	"ALREADY_EXISTS":  2,
+	"DOUBLE_SPENDING": 3,
+	"VERIFICATION":    4,
+	"IMMATURE":        5,
+}
+
+func (x FailureCode) String() string {
+	return proto.EnumName(FailureCode_name, int32(x))
+}
+
+// ValidateTxRequest carries the tx to dry-run
+type ValidateTxRequest struct {
+	// tx encoded in the wire format
+	Tx []byte `protobuf:"bytes,1,opt,name=tx,proto3" json:"tx,omitempty"`
+}
+
+func (m *ValidateTxRequest) Reset()         { *m = ValidateTxRequest{} }
+func (m *ValidateTxRequest) String() string { return proto.CompactTextString(m) }
+func (*ValidateTxRequest) ProtoMessage()    {}
+
+// ValidationFailure is a rule the tx fails
+type ValidationFailure struct {
+	Code   FailureCode `protobuf:"varint,1,opt,name=code,proto3,enum=nodeext.FailureCode" json:"code,omitempty"`
+	Reason string      `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (m *ValidationFailure) Reset()         { *m = ValidationFailure{} }
+func (m *ValidationFailure) String() string { return proto.CompactTextString(m) }
+func (*ValidationFailure) ProtoMessage()    {}
+
+// ValidateTxResponse is the outcome of a tx dry-run
+type ValidateTxResponse struct {
+	Txid     string               `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+	Valid    bool                 `protobuf:"varint,2,opt,name=valid,proto3" json:"valid,omitempty"`
+	Failures []*ValidationFailure `protobuf:"bytes,3,rep,name=failures,proto3" json:"failures,omitempty"`
+}
+
+func (m *ValidateTxResponse) Reset()         { *m = ValidateTxResponse{} }
+func (m *ValidateTxResponse) String() string { return proto.CompactTextString(m) }
+func (*ValidateTxResponse) ProtoMessage()    {}
+
+// ConsensusTimeoutsRequest asks for the current consensus timeouts
+type ConsensusTimeoutsRequest struct{}
+
+func (m *ConsensusTimeoutsRequest) Reset()         { *m = ConsensusTimeoutsRequest{} }
+func (m *ConsensusTimeoutsRequest) String() string { return proto.CompactTextString(m) }
+func (*ConsensusTimeoutsRequest) ProtoMessage()    {}
+
+// PhaseTimeout is the current timeout of a consensus phase
+type PhaseTimeout struct {
+	// one of selection, firstreduction, secondreduction, agreement
+	Phase     string `protobuf:"bytes,1,opt,name=phase,proto3" json:"phase,omitempty"`
+	TimeoutMs uint64 `protobuf:"varint,2,opt,name=timeout_ms,json=timeoutMs,proto3" json:"timeout_ms,omitempty"`
+}
+
+func (m *PhaseTimeout) Reset()         { *m = PhaseTimeout{} }
+func (m *PhaseTimeout) String() string { return proto.CompactTextString(m) }
+func (*PhaseTimeout) ProtoMessage()    {}
+
+// ConsensusTimeoutsResponse lists the current consensus timeouts
+type ConsensusTimeoutsResponse struct {
+	Timeouts []*PhaseTimeout `protobuf:"bytes,1,rep,name=timeouts,proto3" json:"timeouts,omitempty"`
+}
+
+func (m *ConsensusTimeoutsResponse) Reset()         { *m = ConsensusTimeoutsResponse{} }
+func (m *ConsensusTimeoutsResponse) String() string { return proto.CompactTextString(m) }
+func (*ConsensusTimeoutsResponse) ProtoMessage()    {}
+
+// ChangePasswordRequest carries the current and the new wallet password
+type ChangePasswordRequest struct {
+	OldPassword string `protobuf:"bytes,1,opt,name=old_password,json=oldPassword,proto3" json:"old_password,omitempty"`
+	NewPassword string `protobuf:"bytes,2,opt,name=new_password,json=newPassword,proto3" json:"new_password,omitempty"`
+}
+
+func (m *ChangePasswordRequest) Reset()         { *m = ChangePasswordRequest{} }
+func (m *ChangePasswordRequest) String() string { return proto.CompactTextString(m) }
+func (*ChangePasswordRequest) ProtoMessage()    {}
+
+// ChangePasswordResponse confirms the password change
+type ChangePasswordResponse struct {
+	Response string `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+}
+
+func (m *ChangePasswordResponse) Reset()         { *m = ChangePasswordResponse{} }
+func (m *ChangePasswordResponse) String() string { return proto.CompactTextString(m) }
+func (*ChangePasswordResponse) ProtoMessage()    {}
+
+// CreateWalletRequest carries the password of the wallet to create
+type CreateWalletRequest struct {
+	Password string `protobuf:"bytes,1,opt,name=password,proto3" json:"password,omitempty"`
+}
+
+func (m *CreateWalletRequest) Reset()         { *m = CreateWalletRequest{} }
+func (m *CreateWalletRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateWalletRequest) ProtoMessage()    {}
+
+// CreateWalletResponse carries the address of the created wallet, and the
+// backup of its seed
+type CreateWalletResponse struct {
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// space separated words encoding the wallet seed
+	Mnemonic string `protobuf:"bytes,2,opt,name=mnemonic,proto3" json:"mnemonic,omitempty"`
+}
+
+func (m *CreateWalletResponse) Reset()         { *m = CreateWalletResponse{} }
+func (m *CreateWalletResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateWalletResponse) ProtoMessage()    {}
+
+// TransferRequest carries a transfer, paying either a fee or a fee rate. If
+// none is set, the tx pays the minimum fee
+type TransferRequest struct {
+	Amount  uint64 `protobuf:"varint,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	Address []byte `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Fee     uint64 `protobuf:"varint,3,opt,name=fee,proto3" json:"fee,omitempty"`
+	// units per kB of the tx
+	FeeRate uint64 `protobuf:"varint,4,opt,name=fee_rate,json=feeRate,proto3" json:"fee_rate,omitempty"`
+}
+
+func (m *TransferRequest) Reset()         { *m = TransferRequest{} }
+func (m *TransferRequest) String() string { return proto.CompactTextString(m) }
+func (*TransferRequest) ProtoMessage()    {}
+
+// Recipient is paid an amount by a multi-recipient transfer
+type Recipient struct {
+	Address []byte `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Amount  uint64 `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+}
+
+func (m *Recipient) Reset()         { *m = Recipient{} }
+func (m *Recipient) String() string { return proto.CompactTextString(m) }
+func (*Recipient) ProtoMessage()    {}
+
+// MultiTransferRequest carries a transfer to several recipients within a
+// single tx, paying either a fee or a fee rate. If none is set, the tx pays
+// the minimum fee
+type MultiTransferRequest struct {
+	Recipients []*Recipient `protobuf:"bytes,1,rep,name=recipients,proto3" json:"recipients,omitempty"`
+	Fee        uint64       `protobuf:"varint,2,opt,name=fee,proto3" json:"fee,omitempty"`
+	// units per kB of the tx
+	FeeRate uint64 `protobuf:"varint,3,opt,name=fee_rate,json=feeRate,proto3" json:"fee_rate,omitempty"`
+}
+
+func (m *MultiTransferRequest) Reset()         { *m = MultiTransferRequest{} }
+func (m *MultiTransferRequest) String() string { return proto.CompactTextString(m) }
+func (*MultiTransferRequest) ProtoMessage()    {}
+
+// ConsensusTxRequest carries a bid or a stake, paying either a fee or a fee
+// rate. If none is set, the tx pays the minimum fee
+type ConsensusTxRequest struct {
+	Amount   uint64 `protobuf:"varint,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	LockTime uint64 `protobuf:"varint,2,opt,name=lock_time,json=lockTime,proto3" json:"lock_time,omitempty"`
+	Fee      uint64 `protobuf:"varint,3,opt,name=fee,proto3" json:"fee,omitempty"`
+	// units per kB of the tx
+	FeeRate uint64 `protobuf:"varint,4,opt,name=fee_rate,json=feeRate,proto3" json:"fee_rate,omitempty"`
+}
+
+func (m *ConsensusTxRequest) Reset()         { *m = ConsensusTxRequest{} }
+func (m *ConsensusTxRequest) String() string { return proto.CompactTextString(m) }
+func (*ConsensusTxRequest) ProtoMessage()    {}
+
+// TransferResponse carries the hash of the sent tx
+type TransferResponse struct {
+	Hash []byte `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+}
+
+func (m *TransferResponse) Reset()         { *m = TransferResponse{} }
+func (m *TransferResponse) String() string { return proto.CompactTextString(m) }
+func (*TransferResponse) ProtoMessage()    {}
+
+// EstimateFeeRequest carries the number of blocks a tx should be included
+// within
+type EstimateFeeRequest struct {
+	TargetBlocks uint64 `protobuf:"varint,1,opt,name=target_blocks,json=targetBlocks,proto3" json:"target_blocks,omitempty"`
+}
+
+func (m *EstimateFeeRequest) Reset()         { *m = EstimateFeeRequest{} }
+func (m *EstimateFeeRequest) String() string { return proto.CompactTextString(m) }
+func (*EstimateFeeRequest) ProtoMessage()    {}
+
+// EstimateFeeResponse carries the suggested fee rate
+type EstimateFeeResponse struct {
+	// units per kB of the tx. Zero if the minimum fee is enough
+	FeeRate      uint64 `protobuf:"varint,1,opt,name=fee_rate,json=feeRate,proto3" json:"fee_rate,omitempty"`
+	TargetBlocks uint64 `protobuf:"varint,2,opt,name=target_blocks,json=targetBlocks,proto3" json:"target_blocks,omitempty"`
+	// minimum fee of a tx, regardless of its size
+	MinFee uint64 `protobuf:"varint,3,opt,name=min_fee,json=minFee,proto3" json:"min_fee,omitempty"`
+	// number of recent blocks the estimate is based on
+	Blocks uint64 `protobuf:"varint,4,opt,name=blocks,proto3" json:"blocks,omitempty"`
+}
+
+func (m *EstimateFeeResponse) Reset()         { *m = EstimateFeeResponse{} }
+func (m *EstimateFeeResponse) String() string { return proto.CompactTextString(m) }
+func (*EstimateFeeResponse) ProtoMessage()    {}
+
+// LoadWatchOnlyRequest carries the view key of a watch-only wallet
+type LoadWatchOnlyRequest struct {
+	// hex-encoded view key
+	ViewKey  string `protobuf:"bytes,1,opt,name=view_key,json=viewKey,proto3" json:"view_key,omitempty"`
+	Password string `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+}
+
+func (m *LoadWatchOnlyRequest) Reset()         { *m = LoadWatchOnlyRequest{} }
+func (m *LoadWatchOnlyRequest) String() string { return proto.CompactTextString(m) }
+func (*LoadWatchOnlyRequest) ProtoMessage()    {}
+
+// LoadWatchOnlyResponse carries the address of the loaded watch-only wallet
+type LoadWatchOnlyResponse struct {
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+}
+
+func (m *LoadWatchOnlyResponse) Reset()         { *m = LoadWatchOnlyResponse{} }
+func (m *LoadWatchOnlyResponse) String() string { return proto.CompactTextString(m) }
+func (*LoadWatchOnlyResponse) ProtoMessage()    {}
+
+// UnsignedTxResponse carries a tx to be signed offline
+type UnsignedTxResponse struct {
+	// unsigned tx, encoded by wallet.MarshalUnsignedTx
+	Tx []byte `protobuf:"bytes,1,opt,name=tx,proto3" json:"tx,omitempty"`
+}
+
+func (m *UnsignedTxResponse) Reset()         { *m = UnsignedTxResponse{} }
+func (m *UnsignedTxResponse) String() string { return proto.CompactTextString(m) }
+func (*UnsignedTxResponse) ProtoMessage()    {}
+
+// SubmitSignedTxRequest carries a tx signed offline
+type SubmitSignedTxRequest struct {
+	// tx encoded in the wire format
+	Tx []byte `protobuf:"bytes,1,opt,name=tx,proto3" json:"tx,omitempty"`
+}
+
+func (m *SubmitSignedTxRequest) Reset()         { *m = SubmitSignedTxRequest{} }
+func (m *SubmitSignedTxRequest) String() string { return proto.CompactTextString(m) }
+func (*SubmitSignedTxRequest) ProtoMessage()    {}
+
+func init() {
+	proto.RegisterEnum("nodeext.TxStatus", TxStatus_name, TxStatus_value)
+	proto.RegisterType((*TxEventsRequest)(nil), "nodeext.TxEventsRequest")
+	proto.RegisterType((*TxEvent)(nil), "nodeext.TxEvent")
+	proto.RegisterEnum("nodeext.FailureCode", FailureCode_name, FailureCode_value)
+	proto.RegisterType((*ValidateTxRequest)(nil), "nodeext.ValidateTxRequest")
+	proto.RegisterType((*ValidationFailure)(nil), "nodeext.ValidationFailure")
+	proto.RegisterType((*ValidateTxResponse)(nil), "nodeext.ValidateTxResponse")
+	proto.RegisterType((*ConsensusTimeoutsRequest)(nil), "nodeext.ConsensusTimeoutsRequest")
+	proto.RegisterType((*PhaseTimeout)(nil), "nodeext.PhaseTimeout")
+	proto.RegisterType((*ConsensusTimeoutsResponse)(nil), "nodeext.ConsensusTimeoutsResponse")
+	proto.RegisterType((*ChangePasswordRequest)(nil), "nodeext.ChangePasswordRequest")
+	proto.RegisterType((*ChangePasswordResponse)(nil), "nodeext.ChangePasswordResponse")
+	proto.RegisterType((*CreateWalletRequest)(nil), "nodeext.CreateWalletRequest")
+	proto.RegisterType((*CreateWalletResponse)(nil), "nodeext.CreateWalletResponse")
+	proto.RegisterType((*TransferRequest)(nil), "nodeext.TransferRequest")
+	proto.RegisterType((*Recipient)(nil), "nodeext.Recipient")
+	proto.RegisterType((*MultiTransferRequest)(nil), "nodeext.MultiTransferRequest")
+	proto.RegisterType((*ConsensusTxRequest)(nil), "nodeext.ConsensusTxRequest")
+	proto.RegisterType((*TransferResponse)(nil), "nodeext.TransferResponse")
+	proto.RegisterType((*EstimateFeeRequest)(nil), "nodeext.EstimateFeeRequest")
+	proto.RegisterType((*EstimateFeeResponse)(nil), "nodeext.EstimateFeeResponse")
+	proto.RegisterType((*LoadWatchOnlyRequest)(nil), "nodeext.LoadWatchOnlyRequest")
+	proto.RegisterType((*LoadWatchOnlyResponse)(nil), "nodeext.LoadWatchOnlyResponse")
+	proto.RegisterType((*UnsignedTxResponse)(nil), "nodeext.UnsignedTxResponse")
+	proto.RegisterType((*SubmitSignedTxRequest)(nil), "nodeext.SubmitSignedTxRequest")
+}
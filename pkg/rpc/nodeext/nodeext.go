@@ -0,0 +1,503 @@
+// Package nodeext extends the Node gRPC service of dusk-protobuf with the
+// methods which are not released there yet. The service is described in
+// nodeext.proto and served on the same gRPC server as the Node service.
+//
+// The bindings are maintained by hand and rely on the reflection-based
+// marshaling of golang/protobuf, hence the messages carry no descriptor.
+package nodeext
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// NodeExtClient is the client API for the NodeExt service
+type NodeExtClient interface { //nolint
+	SubscribeTxEvents(ctx context.Context, in *TxEventsRequest, opts ...grpc.CallOption) (NodeExt_SubscribeTxEventsClient, error)
+	ValidateTx(ctx context.Context, in *ValidateTxRequest, opts ...grpc.CallOption) (*ValidateTxResponse, error)
+	GetConsensusTimeouts(ctx context.Context, in *ConsensusTimeoutsRequest, opts ...grpc.CallOption) (*ConsensusTimeoutsResponse, error)
+	ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*ChangePasswordResponse, error)
+	CreateWalletWithMnemonic(ctx context.Context, in *CreateWalletRequest, opts ...grpc.CallOption) (*CreateWalletResponse, error)
+	Transfer(ctx context.Context, in *TransferRequest, opts ...grpc.CallOption) (*TransferResponse, error)
+	MultiTransfer(ctx context.Context, in *MultiTransferRequest, opts ...grpc.CallOption) (*TransferResponse, error)
+	SendBid(ctx context.Context, in *ConsensusTxRequest, opts ...grpc.CallOption) (*TransferResponse, error)
+	SendStake(ctx context.Context, in *ConsensusTxRequest, opts ...grpc.CallOption) (*TransferResponse, error)
+	EstimateFee(ctx context.Context, in *EstimateFeeRequest, opts ...grpc.CallOption) (*EstimateFeeResponse, error)
+	LoadWatchOnlyWallet(ctx context.Context, in *LoadWatchOnlyRequest, opts ...grpc.CallOption) (*LoadWatchOnlyResponse, error)
+	CreateUnsignedTx(ctx context.Context, in *MultiTransferRequest, opts ...grpc.CallOption) (*UnsignedTxResponse, error)
+	SubmitSignedTx(ctx context.Context, in *SubmitSignedTxRequest, opts ...grpc.CallOption) (*TransferResponse, error)
+}
+
+type nodeExtClient struct {
+	cc *grpc.ClientConn
+}
+
+// NewNodeExtClient creates a client of the NodeExt service
+func NewNodeExtClient(cc *grpc.ClientConn) NodeExtClient {
+	return &nodeExtClient{cc}
+}
+
+func (c *nodeExtClient) SubscribeTxEvents(ctx context.Context, in *TxEventsRequest, opts ...grpc.CallOption) (NodeExt_SubscribeTxEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &serviceDesc.Streams[0], "/nodeext.NodeExt/SubscribeTxEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &nodeExtSubscribeTxEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+func (c *nodeExtClient) ValidateTx(ctx context.Context, in *ValidateTxRequest, opts ...grpc.CallOption) (*ValidateTxResponse, error) {
+	out := new(ValidateTxResponse)
+	err := c.cc.Invoke(ctx, "/nodeext.NodeExt/ValidateTx", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeExtClient) GetConsensusTimeouts(ctx context.Context, in *ConsensusTimeoutsRequest, opts ...grpc.CallOption) (*ConsensusTimeoutsResponse, error) {
+	out := new(ConsensusTimeoutsResponse)
+	err := c.cc.Invoke(ctx, "/nodeext.NodeExt/GetConsensusTimeouts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeExtClient) ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*ChangePasswordResponse, error) {
+	out := new(ChangePasswordResponse)
+	err := c.cc.Invoke(ctx, "/nodeext.NodeExt/ChangePassword", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeExtClient) CreateWalletWithMnemonic(ctx context.Context, in *CreateWalletRequest, opts ...grpc.CallOption) (*CreateWalletResponse, error) {
+	out := new(CreateWalletResponse)
+	err := c.cc.Invoke(ctx, "/nodeext.NodeExt/CreateWalletWithMnemonic", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeExtClient) Transfer(ctx context.Context, in *TransferRequest, opts ...grpc.CallOption) (*TransferResponse, error) {
+	out := new(TransferResponse)
+	err := c.cc.Invoke(ctx, "/nodeext.NodeExt/Transfer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeExtClient) MultiTransfer(ctx context.Context, in *MultiTransferRequest, opts ...grpc.CallOption) (*TransferResponse, error) {
+	out := new(TransferResponse)
+	err := c.cc.Invoke(ctx, "/nodeext.NodeExt/MultiTransfer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeExtClient) SendBid(ctx context.Context, in *ConsensusTxRequest, opts ...grpc.CallOption) (*TransferResponse, error) {
+	out := new(TransferResponse)
+	err := c.cc.Invoke(ctx, "/nodeext.NodeExt/SendBid", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeExtClient) SendStake(ctx context.Context, in *ConsensusTxRequest, opts ...grpc.CallOption) (*TransferResponse, error) {
+	out := new(TransferResponse)
+	err := c.cc.Invoke(ctx, "/nodeext.NodeExt/SendStake", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeExtClient) EstimateFee(ctx context.Context, in *EstimateFeeRequest, opts ...grpc.CallOption) (*EstimateFeeResponse, error) {
+	out := new(EstimateFeeResponse)
+	err := c.cc.Invoke(ctx, "/nodeext.NodeExt/EstimateFee", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeExtClient) LoadWatchOnlyWallet(ctx context.Context, in *LoadWatchOnlyRequest, opts ...grpc.CallOption) (*LoadWatchOnlyResponse, error) {
+	out := new(LoadWatchOnlyResponse)
+	err := c.cc.Invoke(ctx, "/nodeext.NodeExt/LoadWatchOnlyWallet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeExtClient) CreateUnsignedTx(ctx context.Context, in *MultiTransferRequest, opts ...grpc.CallOption) (*UnsignedTxResponse, error) {
+	out := new(UnsignedTxResponse)
+	err := c.cc.Invoke(ctx, "/nodeext.NodeExt/CreateUnsignedTx", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeExtClient) SubmitSignedTx(ctx context.Context, in *SubmitSignedTxRequest, opts ...grpc.CallOption) (*TransferResponse, error) {
+	out := new(TransferResponse)
+	err := c.cc.Invoke(ctx, "/nodeext.NodeExt/SubmitSignedTx", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// NodeExt_SubscribeTxEventsClient receives the streamed tx events
+type NodeExt_SubscribeTxEventsClient interface { //nolint
+	Recv() (*TxEvent, error)
+	grpc.ClientStream
+}
+
+type nodeExtSubscribeTxEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *nodeExtSubscribeTxEventsClient) Recv() (*TxEvent, error) {
+	m := new(TxEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// NodeExtServer is the server API for the NodeExt service
+type NodeExtServer interface { //nolint
+	SubscribeTxEvents(*TxEventsRequest, NodeExt_SubscribeTxEventsServer) error
+	ValidateTx(context.Context, *ValidateTxRequest) (*ValidateTxResponse, error)
+	GetConsensusTimeouts(context.Context, *ConsensusTimeoutsRequest) (*ConsensusTimeoutsResponse, error)
+	ChangePassword(context.Context, *ChangePasswordRequest) (*ChangePasswordResponse, error)
+	CreateWalletWithMnemonic(context.Context, *CreateWalletRequest) (*CreateWalletResponse, error)
+	Transfer(context.Context, *TransferRequest) (*TransferResponse, error)
+	MultiTransfer(context.Context, *MultiTransferRequest) (*TransferResponse, error)
+	SendBid(context.Context, *ConsensusTxRequest) (*TransferResponse, error)
+	SendStake(context.Context, *ConsensusTxRequest) (*TransferResponse, error)
+	EstimateFee(context.Context, *EstimateFeeRequest) (*EstimateFeeResponse, error)
+	LoadWatchOnlyWallet(context.Context, *LoadWatchOnlyRequest) (*LoadWatchOnlyResponse, error)
+	CreateUnsignedTx(context.Context, *MultiTransferRequest) (*UnsignedTxResponse, error)
+	SubmitSignedTx(context.Context, *SubmitSignedTxRequest) (*TransferResponse, error)
+}
+
+// RegisterNodeExtServer registers the NodeExt service on a gRPC server
+func RegisterNodeExtServer(s *grpc.Server, srv NodeExtServer) {
+	s.RegisterService(&serviceDesc, srv)
+}
+
+func subscribeTxEventsHandler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TxEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NodeExtServer).SubscribeTxEvents(m, &nodeExtSubscribeTxEventsServer{stream})
+}
+
+// NodeExt_SubscribeTxEventsServer sends the streamed tx events
+type NodeExt_SubscribeTxEventsServer interface { //nolint
+	Send(*TxEvent) error
+	grpc.ServerStream
+}
+
+type nodeExtSubscribeTxEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *nodeExtSubscribeTxEventsServer) Send(m *TxEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func validateTxHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateTxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeExtServer).ValidateTx(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nodeext.NodeExt/ValidateTx",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeExtServer).ValidateTx(ctx, req.(*ValidateTxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func getConsensusTimeoutsHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConsensusTimeoutsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeExtServer).GetConsensusTimeouts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nodeext.NodeExt/GetConsensusTimeouts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeExtServer).GetConsensusTimeouts(ctx, req.(*ConsensusTimeoutsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func changePasswordHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangePasswordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeExtServer).ChangePassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nodeext.NodeExt/ChangePassword",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeExtServer).ChangePassword(ctx, req.(*ChangePasswordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func createWalletWithMnemonicHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWalletRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeExtServer).CreateWalletWithMnemonic(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nodeext.NodeExt/CreateWalletWithMnemonic",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeExtServer).CreateWalletWithMnemonic(ctx, req.(*CreateWalletRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func transferHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeExtServer).Transfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nodeext.NodeExt/Transfer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeExtServer).Transfer(ctx, req.(*TransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func multiTransferHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MultiTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeExtServer).MultiTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nodeext.NodeExt/MultiTransfer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeExtServer).MultiTransfer(ctx, req.(*MultiTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func sendBidHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConsensusTxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeExtServer).SendBid(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nodeext.NodeExt/SendBid",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeExtServer).SendBid(ctx, req.(*ConsensusTxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func sendStakeHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConsensusTxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeExtServer).SendStake(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nodeext.NodeExt/SendStake",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeExtServer).SendStake(ctx, req.(*ConsensusTxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func estimateFeeHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EstimateFeeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeExtServer).EstimateFee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nodeext.NodeExt/EstimateFee",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeExtServer).EstimateFee(ctx, req.(*EstimateFeeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func loadWatchOnlyWalletHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoadWatchOnlyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeExtServer).LoadWatchOnlyWallet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nodeext.NodeExt/LoadWatchOnlyWallet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeExtServer).LoadWatchOnlyWallet(ctx, req.(*LoadWatchOnlyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func createUnsignedTxHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MultiTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeExtServer).CreateUnsignedTx(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nodeext.NodeExt/CreateUnsignedTx",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeExtServer).CreateUnsignedTx(ctx, req.(*MultiTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func submitSignedTxHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitSignedTxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeExtServer).SubmitSignedTx(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nodeext.NodeExt/SubmitSignedTx",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeExtServer).SubmitSignedTx(ctx, req.(*SubmitSignedTxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: "nodeext.NodeExt",
+	HandlerType: (*NodeExtServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ValidateTx",
+			Handler:    validateTxHandler,
+		},
+		{
+			MethodName: "GetConsensusTimeouts",
+			Handler:    getConsensusTimeoutsHandler,
+		},
+		{
+			MethodName: "ChangePassword",
+			Handler:    changePasswordHandler,
+		},
+		{
+			MethodName: "CreateWalletWithMnemonic",
+			Handler:    createWalletWithMnemonicHandler,
+		},
+		{
+			MethodName: "Transfer",
+			Handler:    transferHandler,
+		},
+		{
+			MethodName: "MultiTransfer",
+			Handler:    multiTransferHandler,
+		},
+		{
+			MethodName: "SendBid",
+			Handler:    sendBidHandler,
+		},
+		{
+			MethodName: "SendStake",
+			Handler:    sendStakeHandler,
+		},
+		{
+			MethodName: "EstimateFee",
+			Handler:    estimateFeeHandler,
+		},
+		{
+			MethodName: "LoadWatchOnlyWallet",
+			Handler:    loadWatchOnlyWalletHandler,
+		},
+		{
+			MethodName: "CreateUnsignedTx",
+			Handler:    createUnsignedTxHandler,
+		},
+		{
+			MethodName: "SubmitSignedTx",
+			Handler:    submitSignedTxHandler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeTxEvents",
+			Handler:       subscribeTxEventsHandler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "nodeext.proto",
+}
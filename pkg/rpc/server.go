@@ -10,6 +10,8 @@ import (
 
 	"github.com/dusk-network/dusk-blockchain/pkg/config"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/rpc/nodeext"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
 	"github.com/dusk-network/dusk-protobuf/autogen/go/node"
 	logger "github.com/sirupsen/logrus"
@@ -42,7 +44,7 @@ func (r *SrvWrapper) Shutdown() {
 // the rust process. It only returns an error, as we want to keep the
 // gRPC service running until the process is killed, thus we do not
 // need to return the server itself.
-func StartgRPCServer(rpcBus *rpcbus.RPCBus) (*SrvWrapper, error) {
+func StartgRPCServer(rpcBus *rpcbus.RPCBus, eventBus *eventbus.EventBus) (*SrvWrapper, error) {
 
 	conf := config.Get().RPC
 	l, err := net.Listen(conf.Network, conf.Address)
@@ -76,6 +78,7 @@ func StartgRPCServer(rpcBus *rpcbus.RPCBus) (*SrvWrapper, error) {
 	grpc.EnableTracing = false
 
 	node.RegisterNodeServer(grpcServer, &nodeServer{rpcBus})
+	nodeext.RegisterNodeExtServer(grpcServer, &nodeExtServer{rpcBus, eventBus})
 	wrapper := &SrvWrapper{grpcServer}
 
 	// This function is blocking, so we run it in a goroutine
@@ -0,0 +1,78 @@
+package signer
+
+import (
+	"context"
+	"time"
+
+	blssigner "github.com/dusk-network/dusk-blockchain/pkg/core/consensus/signer"
+	"google.golang.org/grpc"
+)
+
+var _ blssigner.Signer = (*Client)(nil)
+
+// Client is a consensus signer.Signer delegating the signatures to a
+// RemoteSigner service
+type Client struct {
+	client  RemoteSignerClient
+	timeout time.Duration
+	pubKey  []byte
+}
+
+// NewClient returns a Client of the RemoteSigner served on the connection.
+// The BLS public key is fetched once, so that an unreachable signer is
+// detected on startup. Every call fails after `timeout`
+func NewClient(cc *grpc.ClientConn, timeout time.Duration) (*Client, error) {
+	c := &Client{
+		client:  NewRemoteSignerClient(cc),
+		timeout: timeout,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	resp, err := c.client.GetPubKey(ctx, &PubKeyRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	c.pubKey = resp.PubKeyBls
+	return c, nil
+}
+
+// PubKeyBLS implements signer.Signer
+func (c *Client) PubKeyBLS() []byte {
+	return c.pubKey
+}
+
+// SignVote implements signer.Signer
+func (c *Client) SignVote(round uint64, step uint8, blockHash []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	resp, err := c.client.SignVote(ctx, &SignVoteRequest{
+		Round:     round,
+		Step:      uint32(step),
+		BlockHash: blockHash,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Signature, nil
+}
+
+// SignSeed implements signer.Signer
+func (c *Client) SignSeed(round uint64, seed []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	resp, err := c.client.SignSeed(ctx, &SignSeedRequest{
+		Round: round,
+		Seed:  seed,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Signature, nil
+}
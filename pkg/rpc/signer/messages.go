@@ -0,0 +1,59 @@
+package signer
+
+import (
+	"github.com/golang/protobuf/proto"
+)
+
+// PubKeyRequest requests the BLS public key of the provisioner
+type PubKeyRequest struct{}
+
+func (m *PubKeyRequest) Reset()         { *m = PubKeyRequest{} }
+func (m *PubKeyRequest) String() string { return proto.CompactTextString(m) }
+func (*PubKeyRequest) ProtoMessage()    {}
+
+// PubKeyResponse carries the marshaled BLS public key of the provisioner
+type PubKeyResponse struct {
+	PubKeyBls []byte `protobuf:"bytes,1,opt,name=pub_key_bls,json=pubKeyBls,proto3" json:"pub_key_bls,omitempty"`
+}
+
+func (m *PubKeyResponse) Reset()         { *m = PubKeyResponse{} }
+func (m *PubKeyResponse) String() string { return proto.CompactTextString(m) }
+func (*PubKeyResponse) ProtoMessage()    {}
+
+// SignVoteRequest is a Reduction or Agreement vote to be signed
+type SignVoteRequest struct {
+	Round     uint64 `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
+	Step      uint32 `protobuf:"varint,2,opt,name=step,proto3" json:"step,omitempty"`
+	BlockHash []byte `protobuf:"bytes,3,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+}
+
+func (m *SignVoteRequest) Reset()         { *m = SignVoteRequest{} }
+func (m *SignVoteRequest) String() string { return proto.CompactTextString(m) }
+func (*SignVoteRequest) ProtoMessage()    {}
+
+// SignSeedRequest is the seed of a round to be signed
+type SignSeedRequest struct {
+	Round uint64 `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
+	Seed  []byte `protobuf:"bytes,2,opt,name=seed,proto3" json:"seed,omitempty"`
+}
+
+func (m *SignSeedRequest) Reset()         { *m = SignSeedRequest{} }
+func (m *SignSeedRequest) String() string { return proto.CompactTextString(m) }
+func (*SignSeedRequest) ProtoMessage()    {}
+
+// SignatureResponse carries a compressed BLS signature
+type SignatureResponse struct {
+	Signature []byte `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (m *SignatureResponse) Reset()         { *m = SignatureResponse{} }
+func (m *SignatureResponse) String() string { return proto.CompactTextString(m) }
+func (*SignatureResponse) ProtoMessage()    {}
+
+func init() {
+	proto.RegisterType((*PubKeyRequest)(nil), "signer.PubKeyRequest")
+	proto.RegisterType((*PubKeyResponse)(nil), "signer.PubKeyResponse")
+	proto.RegisterType((*SignVoteRequest)(nil), "signer.SignVoteRequest")
+	proto.RegisterType((*SignSeedRequest)(nil), "signer.SignSeedRequest")
+	proto.RegisterType((*SignatureResponse)(nil), "signer.SignatureResponse")
+}
@@ -0,0 +1,141 @@
+package signer
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	blssigner "github.com/dusk-network/dusk-blockchain/pkg/core/consensus/signer"
+	logger "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var log = logger.WithField("process", "remote signer")
+
+// seedSize is the size of a seed, which is the compressed BLS signature of the
+// previous seed. It differs from the size of the preimage of a vote (see
+// header.MarshalSignableVote), so that a vote can not be signed as a seed
+const seedSize = 33
+
+// pruneMargin is the number of rounds, below the highest signed one, the
+// votes are kept for. Older rounds are forgotten, and their votes refused
+const pruneMargin = 10
+
+var _ RemoteSignerServer = (*Server)(nil)
+
+// VoteLog is a signer.VoteLog forgetting the votes of the past rounds.
+// consensus.WAL implements VoteLog
+type VoteLog interface {
+	blssigner.VoteLog
+	// Prune forgets the votes for the rounds up to `round` included
+	Prune(round uint64) error
+	// LastRound returns the highest round a vote is recorded for
+	LastRound() uint64
+}
+
+// Server serves the signatures of a consensus signer.Signer over the
+// RemoteSigner service
+type Server struct {
+	signer blssigner.Signer
+	votes  VoteLog
+
+	lock sync.Mutex
+	// highest signed round
+	lastRound uint64
+}
+
+// NewServer returns a Server signing through `s`. The Server enforces its own
+// double signing protection, independently from the one of the node: every
+// vote is recorded in `votes` before being signed, and conflicting votes are
+// refused with codes.FailedPrecondition. The same holds for the seeds, which
+// are signed once per round, with rounds only increasing.
+//
+// The votes more than pruneMargin rounds below the highest signed one are
+// pruned from `votes`, and refused from then on
+func NewServer(s blssigner.Signer, votes VoteLog) *Server {
+	return &Server{
+		signer:    blssigner.Guard(s, votes),
+		votes:     votes,
+		lastRound: votes.LastRound(),
+	}
+}
+
+// GetPubKey implements RemoteSignerServer
+func (s *Server) GetPubKey(ctx context.Context, req *PubKeyRequest) (*PubKeyResponse, error) {
+	return &PubKeyResponse{PubKeyBls: s.signer.PubKeyBLS()}, nil
+}
+
+// SignVote implements RemoteSignerServer
+func (s *Server) SignVote(ctx context.Context, req *SignVoteRequest) (*SignatureResponse, error) {
+	if req.Step > math.MaxUint8 {
+		return nil, status.Errorf(codes.InvalidArgument, "step %d out of range", req.Step)
+	}
+
+	if len(req.BlockHash) != 32 {
+		return nil, status.Error(codes.InvalidArgument, "block hash must be 32 bytes")
+	}
+
+	// the votes are signed one at a time, so that a round is not pruned
+	// while being signed
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if req.Round+pruneMargin <= s.lastRound {
+		// the votes of the round may have been pruned
+		return nil, status.Errorf(codes.FailedPrecondition, "round %d is too old", req.Round)
+	}
+
+	sig, err := s.signer.SignVote(req.Round, uint8(req.Step), req.BlockHash)
+	if err != nil {
+		var doubleSign consensus.ErrDoubleSign
+		if errors.As(err, &doubleSign) {
+			log.WithError(err).Errorln("refusing to sign vote")
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	s.prune(req.Round)
+	return &SignatureResponse{Signature: sig}, nil
+}
+
+// prune forgets the votes more than pruneMargin rounds below `round`, once a
+// vote is signed at `round`
+func (s *Server) prune(round uint64) {
+	if round <= s.lastRound {
+		return
+	}
+
+	s.lastRound = round
+	if round <= pruneMargin {
+		return
+	}
+
+	if err := s.votes.Prune(round - pruneMargin); err != nil {
+		log.WithError(err).Warnln("could not prune the signed votes")
+	}
+}
+
+// SignSeed implements RemoteSignerServer
+func (s *Server) SignSeed(ctx context.Context, req *SignSeedRequest) (*SignatureResponse, error) {
+	if len(req.Seed) != seedSize {
+		return nil, status.Errorf(codes.InvalidArgument, "seed must be %d bytes", seedSize)
+	}
+
+	sig, err := s.signer.SignSeed(req.Round, req.Seed)
+	if err != nil {
+		var doubleSign consensus.ErrSeedDoubleSign
+		if errors.As(err, &doubleSign) {
+			log.WithError(err).Errorln("refusing to sign seed")
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &SignatureResponse{Signature: sig}, nil
+}
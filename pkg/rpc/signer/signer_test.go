@@ -0,0 +1,104 @@
+package signer
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	blssigner "github.com/dusk-network/dusk-blockchain/pkg/core/consensus/signer"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRemoteSigner(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "remote_signer")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	wal, err := consensus.OpenWAL(filepath.Join(dir, "signer.wal"))
+	assert.NoError(err)
+	defer wal.Close()
+
+	keys, _ := key.NewRandKeys()
+	local := blssigner.NewLocal(keys)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(err)
+	srv := grpc.NewServer()
+	RegisterRemoteSignerServer(srv, NewServer(local, wal))
+	go func() {
+		_ = srv.Serve(l)
+	}()
+	defer srv.Stop()
+
+	conn, err := grpc.Dial(l.Addr().String(), grpc.WithInsecure())
+	assert.NoError(err)
+	defer conn.Close()
+
+	c, err := NewClient(conn, 3*time.Second)
+	assert.NoError(err)
+	assert.Equal(keys.BLSPubKeyBytes, c.PubKeyBLS())
+
+	// the remote signatures are the same as the in-process ones
+	hash := make([]byte, 32)
+	sig, err := c.SignVote(1, 2, hash)
+	assert.NoError(err)
+	expected, err := local.SignVote(1, 2, hash)
+	assert.NoError(err)
+	assert.Equal(expected, sig)
+
+	seed := make([]byte, seedSize)
+	seedSig, err := c.SignSeed(1, seed)
+	assert.NoError(err)
+	expected, err = local.SignSeed(1, seed)
+	assert.NoError(err)
+	assert.Equal(expected, seedSig)
+
+	// the remote signer refuses to sign a different hash for the same round
+	// and step
+	otherHash := make([]byte, 32)
+	otherHash[0] = 1
+	_, err = c.SignVote(1, 2, otherHash)
+	assert.Equal(codes.FailedPrecondition, status.Code(err))
+
+	// the preimage of a vote can not be signed as a seed
+	preimage := new(bytes.Buffer)
+	assert.NoError(header.MarshalSignableVote(preimage, header.Header{Round: 2, Step: 2, BlockHash: otherHash}))
+	_, err = c.SignSeed(2, preimage.Bytes())
+	assert.Equal(codes.InvalidArgument, status.Code(err))
+
+	// a single seed is signed per round, and rounds only increase
+	otherSeed := make([]byte, seedSize)
+	otherSeed[0] = 1
+	_, err = c.SignSeed(1, otherSeed)
+	assert.Equal(codes.FailedPrecondition, status.Code(err))
+	_, err = c.SignSeed(2, otherSeed)
+	assert.NoError(err)
+	_, err = c.SignSeed(1, seed)
+	assert.Equal(codes.FailedPrecondition, status.Code(err))
+
+	// the votes of the rounds too far below the highest signed one are
+	// pruned, and refused
+	_, err = c.SignVote(1+pruneMargin, 2, hash)
+	assert.NoError(err)
+	assert.Equal(uint64(1+pruneMargin), wal.LastRound())
+	_, err = c.SignVote(1, 2, otherHash)
+	assert.Equal(codes.FailedPrecondition, status.Code(err))
+
+	// the highest signed round is restored from the WAL
+	restarted := NewServer(local, wal)
+	_, err = restarted.SignVote(context.Background(), &SignVoteRequest{Round: 1, Step: 3, BlockHash: hash})
+	assert.Equal(codes.FailedPrecondition, status.Code(err))
+}
@@ -0,0 +1,129 @@
+package sortedset
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/big"
+)
+
+// ErrBitsetOverflow is returned when a Bitset can not be represented as a
+// uint64 bitmap
+var ErrBitsetOverflow = errors.New("bitset does not fit in 64 bits")
+
+// Bitset is a variable length bitmap of the subset of a Set. The i-th element
+// of the Set is represented by the (i % 8)-th bit of the (i / 8)-th byte.
+// The layout matches the little endian encoding of the legacy uint64 bitmaps,
+// so that the first 64 elements are represented the same way in both
+type Bitset []byte
+
+// NewBitset returns a Bitset large enough to represent the subsets of a Set
+// with `size` elements
+func NewBitset(size int) Bitset {
+	return make(Bitset, (size+7)/8)
+}
+
+// BitsetFromUint64 converts a legacy uint64 bitmap into a Bitset
+func BitsetFromUint64(bits uint64) Bitset {
+	b := make(Bitset, 8)
+	binary.LittleEndian.PutUint64(b, bits)
+	return b.trim()
+}
+
+// Uint64 converts the Bitset into a legacy uint64 bitmap. It returns
+// ErrBitsetOverflow if any element past the 64th is set
+func (b Bitset) Uint64() (uint64, error) {
+	b = b.trim()
+	if len(b) > 8 {
+		return 0, ErrBitsetOverflow
+	}
+
+	buf := make([]byte, 8)
+	copy(buf, b)
+	return binary.LittleEndian.Uint64(buf), nil
+}
+
+// Set flips the i-th bit to 1, growing the Bitset if needed
+func (b *Bitset) Set(i int) {
+	for len(*b) <= i/8 {
+		*b = append(*b, 0)
+	}
+	(*b)[i/8] |= 1 << uint(i%8)
+}
+
+// IsSet returns whether the i-th bit is 1
+func (b Bitset) IsSet(i int) bool {
+	if i/8 >= len(b) {
+		return false
+	}
+	return b[i/8]&(1<<uint(i%8)) != 0
+}
+
+// Len returns the amount of bits set to 1
+func (b Bitset) Len() int {
+	n := 0
+	for _, by := range b {
+		for ; by != 0; by &= by - 1 {
+			n++
+		}
+	}
+	return n
+}
+
+// Equal tests for equality with another Bitset. Trailing zero bytes are
+// ignored
+func (b Bitset) Equal(other Bitset) bool {
+	b, other = b.trim(), other.trim()
+	if len(b) != len(other) {
+		return false
+	}
+
+	for i := range b {
+		if b[i] != other[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// trim strips the trailing zero bytes of the Bitset
+func (b Bitset) trim() Bitset {
+	i := len(b)
+	for i > 0 && b[i-1] == 0 {
+		i--
+	}
+	return b[:i]
+}
+
+// IntersectBitset is the Bitset equivalent of Intersect. It returns the
+// elements of the Set whose bit is set to 1
+func (v Set) IntersectBitset(committeeSet Bitset) Set {
+	c := New()
+	for i, elem := range v {
+		if committeeSet.IsSet(i) {
+			c = append(c, elem)
+		}
+	}
+	return c
+}
+
+// BitsetOf is the variable length equivalent of Bits. It creates a Bitset of
+// the subset of a Set, no matter how many elements the Set contains
+func (v *Set) BitsetOf(subset Set) Bitset {
+	ret := NewBitset(len(*v))
+	if len(subset) == 0 {
+		return ret
+	}
+
+	var head *big.Int
+	head, subset = subset[0], subset[1:]
+	for i, elem := range *v {
+		if elem.Cmp(head) == 0 {
+			ret.Set(i)
+			if len(subset) == 0 {
+				break
+			}
+			head, subset = subset[0], subset[1:]
+		}
+	}
+	return ret
+}
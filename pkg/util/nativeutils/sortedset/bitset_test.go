@@ -0,0 +1,73 @@
+package sortedset
+
+import (
+	"math/big"
+	"math/rand"
+	"sort"
+	"testing"
+
+	crypto "github.com/dusk-network/dusk-crypto/hash"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBitsetIntersect(t *testing.T) {
+	set := New()
+	subset := New()
+
+	// way past the 64 elements a uint64 bitmap can represent
+	for i := 0; i < 300; i++ {
+		k, _ := crypto.RandEntropy(32)
+		bk := (&big.Int{}).SetBytes(k)
+		set = append(set, bk)
+		if rand.Intn(100) < 30 {
+			subset = append(subset, bk)
+		}
+	}
+
+	sort.Sort(set)
+	sort.Sort(subset)
+
+	bRepr := set.BitsetOf(subset)
+	assert.Equal(t, len(subset), bRepr.Len())
+	assert.Equal(t, subset, set.IntersectBitset(bRepr))
+}
+
+// TestBitsetLegacyLayout tests that a Bitset matches the legacy uint64
+// bitmap for sets with less than 64 elements
+func TestBitsetLegacyLayout(t *testing.T) {
+	set := New()
+	subset := New()
+
+	for i := 0; i < 50; i++ {
+		k, _ := crypto.RandEntropy(32)
+		bk := (&big.Int{}).SetBytes(k)
+		set = append(set, bk)
+		if rand.Intn(100) < 30 {
+			subset = append(subset, bk)
+		}
+	}
+
+	sort.Sort(set)
+	sort.Sort(subset)
+
+	legacy := set.Bits(subset)
+	bRepr := set.BitsetOf(subset)
+	assert.True(t, BitsetFromUint64(legacy).Equal(bRepr))
+
+	bits, err := bRepr.Uint64()
+	assert.NoError(t, err)
+	assert.Equal(t, legacy, bits)
+}
+
+func TestBitsetOverflow(t *testing.T) {
+	b := NewBitset(65)
+	b.Set(64)
+	_, err := b.Uint64()
+	assert.Equal(t, ErrBitsetOverflow, err)
+
+	b.Set(200)
+	assert.True(t, b.IsSet(200))
+	assert.False(t, b.IsSet(199))
+	assert.False(t, b.IsSet(1000))
+	assert.Equal(t, 2, b.Len())
+}
@@ -110,7 +110,16 @@ func (c *Cluster) Remove(b []byte) bool {
 // IntersectCluster performs an intersect operation with a Cluster represented
 // through a uint64 bitmap
 func (c *Cluster) IntersectCluster(committeeSet uint64) Cluster {
-	set := c.Intersect(committeeSet)
+	return c.subCluster(c.Intersect(committeeSet))
+}
+
+// IntersectClusterBitset performs an intersect operation with a Cluster
+// represented through a variable length Bitset
+func (c *Cluster) IntersectClusterBitset(committeeSet Bitset) Cluster {
+	return c.subCluster(c.IntersectBitset(committeeSet))
+}
+
+func (c *Cluster) subCluster(set Set) Cluster {
 	elems := make(map[string]int)
 	for _, elem := range set {
 		elems[string(elem.Bytes())] = c.OccurrencesOf(elem.Bytes())